@@ -40,6 +40,15 @@ else
   echo "Таблицы уже существуют, пропускаем миграции"
 fi
 
+# Применяем последующие миграции (все они идемпотентны)
+for migration in $(ls /app/migrations/*.up.sql | sort); do
+  case "$migration" in
+    */000001_*) continue ;;
+  esac
+  echo "Применение миграции $(basename $migration)..."
+  PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -v ON_ERROR_STOP=1 -f $migration
+done
+
 # Проверяем содержимое таблиц
 echo "Проверка содержимого таблиц..."
 PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -c "
@@ -63,7 +63,24 @@ func (f *ComponentFactory) CreateTelegramClient() (*telegram.Client, error) {
 		return nil, fmt.Errorf("failed to create telegram client: %w", err)
 	}
 
-	f.logger.Info("Telegram client created successfully")
+	switch f.config.UpdateMode {
+	case telegram.UpdateModeWebhook:
+		if f.config.WebhookConfig.URL == "" {
+			f.logger.Warn("WEBHOOK_URL is not set, using long polling")
+			break
+		}
+		client.SetWebhookConfig(telegram.WebhookConfig{
+			URL:            f.config.WebhookConfig.URL,
+			ListenAddr:     f.config.WebhookConfig.ListenAddr,
+			SecretToken:    f.config.WebhookConfig.SecretToken,
+			MaxConnections: f.config.WebhookConfig.MaxConnections,
+		})
+	case telegram.UpdateModePolling, "":
+	default:
+		f.logger.Warn("Unknown update mode, using long polling", zap.String("mode", f.config.UpdateMode))
+	}
+
+	f.logger.Info("Telegram client created successfully", zap.String("update_mode", f.config.UpdateMode))
 	return client, nil
 }
 
@@ -86,10 +103,15 @@ func (f *ComponentFactory) CreateScraper() scraper.Fetcher {
 		},
 		RequestDelay: f.config.ScraperConfig.RequestDelay,
 		LLMConfig: scraper.LLMConfig{
-			BaseURL: f.config.LLMConfig.BaseURL,
-			APIKey:  f.config.LLMConfig.APIKey,
-			Timeout: f.config.LLMConfig.Timeout,
-			Delay:   f.config.LLMConfig.Delay,
+			Provider:    f.config.LLMConfig.Provider,
+			BaseURL:     f.config.LLMConfig.BaseURL,
+			APIKey:      f.config.LLMConfig.APIKey,
+			Model:       f.config.LLMConfig.Model,
+			Temperature: f.config.LLMConfig.Temperature,
+			TopP:        f.config.LLMConfig.TopP,
+			MaxTokens:   f.config.LLMConfig.MaxTokens,
+			Timeout:     f.config.LLMConfig.Timeout,
+			Delay:       f.config.LLMConfig.Delay,
 		},
 	}
 	scraperInstance := scraper.NewFetcher(scraperConfig, f.logger)
@@ -126,8 +148,9 @@ func (f *ComponentFactory) CreateServices(db *storage.Postgres) (*service.Servic
 }
 
 // CreateMiddleware создает middleware
-func (f *ComponentFactory) CreateMiddleware() *middleware.Middleware {
+func (f *ComponentFactory) CreateMiddleware(services *service.Services) *middleware.Middleware {
 	middlewareManager := middleware.New(f.config, f.logger)
+	middlewareManager.SetPermissionChecker(services.Access)
 	f.logger.Info("Middleware created successfully")
 	return middlewareManager
 }
@@ -184,14 +207,22 @@ func (f *ComponentFactory) CreateBot() (*Bot, error) {
 		return nil, fmt.Errorf("failed to create telegram client: %w", err)
 	}
 
+	// Подключаем отправку уведомлений подписчикам
+	services.Subscription.SetNotifier(tgClient.GetBotAPI())
+	services.Digest.SetNotifier(tgClient.GetBotAPI())
+	services.Reminder.SetNotifier(tgClient.GetBotAPI())
+
 	// Создаем health check сервер
 	healthServer, err := f.CreateHealthServer(db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create health server: %w", err)
 	}
+	if healthServer != nil {
+		healthServer.SetCalendarProvider(service.CalendarPath, services.Calendar)
+	}
 
 	// Создаем middleware
-	middlewareManager := f.CreateMiddleware()
+	middlewareManager := f.CreateMiddleware(services)
 
 	// Создаем бота
 	bot, err := NewBot(f.config, f.logger)
@@ -5,14 +5,39 @@ import (
 	"gemfactory/internal/config"
 	"gemfactory/internal/external/telegram"
 	"gemfactory/internal/handlers"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/metrics"
 	"gemfactory/internal/middleware"
+	"gemfactory/internal/model"
 	"gemfactory/internal/service"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
 )
 
+// routedCommands команды, которые обрабатывает handleMessage. Остальные попадают в метрики как unknown,
+// чтобы произвольные команды не раздували кардинальность меток
+var routedCommands = map[string]bool{
+	"start": true, "help": true, "month": true, "search": true, "artists": true, "metrics": true,
+	"homework": true, "playlist": true, "subscribe": true, "unsubscribe": true, "subscriptions": true,
+	"calendar": true, "lang": true, "settings": true, "digest": true, "remind": true, "chat": true,
+	"admin": true, "add_artist": true, "remove_artist": true, "alias": true, "discover": true, "relation": true,
+	"clearcache": true, "clearwhitelists": true, "export": true, "config": true, "config_list": true,
+	"config_reset": true, "tasks_list": true, "task_history": true, "reload_playlist": true, "parse": true,
+	"grant": true, "revoke": true, "audit": true, "llm_metrics": true, "changes": true, "snapshots": true,
+	"replay": true,
+}
+
+// commandLabel возвращает метку команды для метрик
+func commandLabel(command string) string {
+	if routedCommands[command] {
+		return command
+	}
+	return "unknown"
+}
+
 // Router обрабатывает маршрутизацию команд
 type Router struct {
 	handlers   *handlers.Handlers
@@ -20,13 +45,16 @@ type Router struct {
 	config     *config.Config
 	services   *service.Services
 	logger     *zap.Logger
+
+	// botUsername имя бота для команд вида /month@botname, пусто - любое имя считается своим
+	botUsername string
 }
 
 // NewRouter создает новый роутер
 func NewRouter(services *service.Services, config *config.Config, logger *zap.Logger) *Router {
 	return &Router{
 		handlers:   handlers.RegisterRoutes(services, config, logger),
-		middleware: middleware.New(config, logger),
+		middleware: newRouterMiddleware(services, config, logger),
 		config:     config,
 		services:   services,
 		logger:     logger,
@@ -36,14 +64,22 @@ func NewRouter(services *service.Services, config *config.Config, logger *zap.Lo
 // NewRouterWithBotAPI создает новый роутер с BotAPI
 func NewRouterWithBotAPI(services *service.Services, config *config.Config, logger *zap.Logger, botAPI telegram.BotAPI) *Router {
 	return &Router{
-		handlers:   handlers.RegisterRoutesWithBotAPI(services, config, logger, botAPI),
-		middleware: middleware.New(config, logger),
-		config:     config,
-		services:   services,
-		logger:     logger,
+		handlers:    handlers.RegisterRoutesWithBotAPI(services, config, logger, botAPI),
+		middleware:  newRouterMiddleware(services, config, logger),
+		config:      config,
+		services:    services,
+		logger:      logger,
+		botUsername: botAPI.BotUsername(),
 	}
 }
 
+// newRouterMiddleware создает middleware с проверкой прав через AccessService
+func newRouterMiddleware(services *service.Services, config *config.Config, logger *zap.Logger) *middleware.Middleware {
+	mw := middleware.New(config, logger)
+	mw.SetPermissionChecker(services.Access)
+	return mw
+}
+
 // HandleUpdate обрабатывает обновление от Telegram
 func (r *Router) HandleUpdate(update tgbotapi.Update) {
 	// Применяем все middleware
@@ -57,6 +93,11 @@ func (r *Router) HandleUpdate(update tgbotapi.Update) {
 		if update.CallbackQuery != nil {
 			r.handleCallbackQuery(update.CallbackQuery)
 		}
+
+		// Обработка inline запросов (@bot запрос в любом чате)
+		if update.InlineQuery != nil {
+			r.handleInlineQuery(update.InlineQuery)
+		}
 	})
 }
 
@@ -66,32 +107,58 @@ func (r *Router) handleMessage(message *tgbotapi.Message) {
 		return
 	}
 
+	// Команда вида /month@otherbot адресована другому боту в группе
+	explicit, ours := r.commandAddressee(message)
+	if !ours {
+		return
+	}
+
+	// В тихом режиме группа получает ответы только на команды, явно адресованные боту
+	group := !message.Chat.IsPrivate()
+	quiet := group && r.services.ChatSettings.Get(message.Chat.ID).QuietMode
+	if quiet && !explicit {
+		return
+	}
+
 	command := strings.ToLower(message.Command())
 
-	// Список админских команд
-	adminCommands := map[string]bool{
-		"admin":           true,
-		"add_artist":      true,
-		"remove_artist":   true,
-		"clearcache":      true,
-		"clearwhitelists": true,
-		"export":          true,
-		"config":          true,
-		"config_list":     true,
-		"config_reset":    true,
-		"tasks_list":      true,
-		"reload_playlist": true,
-		"parse":           true,
+	start := time.Now()
+	label, status := commandLabel(command), metrics.StatusSuccess
+	defer func() {
+		// Паника записывается отдельным статусом и передается дальше в recovery middleware
+		if p := recover(); p != nil {
+			metrics.ObserveCommand(label, metrics.StatusPanic, time.Since(start))
+			panic(p)
+		}
+		metrics.ObserveCommand(label, status, time.Since(start))
+	}()
+
+	// Команды с ролью выше пользователя записываются в журнал аудита
+	var audit *service.AuditRecord
+	if service.RequiredRole(command) != model.RoleUser && message.From != nil {
+		audit = r.services.Audit.Begin(message.From.ID, message.From.UserName, message.Chat.ID,
+			message.MessageID, command, message.CommandArguments())
+		defer r.services.Audit.Finish(audit)
 	}
 
-	// Проверяем админские права для админских команд
-	if adminCommands[command] {
-		if !r.isAdmin(message.From) {
-			r.logger.Warn("Unauthorized access attempt to admin command",
-				zap.String("command", command),
-				zap.String("user", getUserIdentifier(message.From)))
-			return
+	// Проверяем права по роли пользователя
+	if !r.canExecute(message.From, command) {
+		audit.Deny()
+		r.logger.Warn("Unauthorized access attempt to command",
+			zap.String("command", command),
+			zap.String("user", getUserIdentifier(message.From)))
+		status = metrics.StatusUnauthorized
+		return
+	}
+
+	// Администраторы группы могут ограничить набор команд через /chat commands
+	if group && !r.services.ChatSettings.IsCommandAllowed(message.Chat.ID, command) {
+		audit.Deny()
+		status = metrics.StatusUnauthorized
+		if !quiet {
+			r.handlers.CommandDisabled(message)
 		}
+		return
 	}
 
 	switch command {
@@ -111,12 +178,36 @@ func (r *Router) handleMessage(message *tgbotapi.Message) {
 		r.handlers.Homework(message)
 	case "playlist":
 		r.handlers.Playlist(message)
+	case "subscribe":
+		r.handlers.Subscribe(message)
+	case "unsubscribe":
+		r.handlers.Unsubscribe(message)
+	case "subscriptions":
+		r.handlers.Subscriptions(message)
+	case "calendar":
+		r.handlers.Calendar(message)
+	case "lang":
+		r.handlers.Lang(message)
+	case "settings":
+		r.handlers.Settings(message)
+	case "digest":
+		r.handlers.Digest(message)
+	case "remind":
+		r.handlers.Remind(message)
+	case "chat":
+		r.handlers.Chat(message)
 	case "admin":
 		r.handlers.Admin(message)
 	case "add_artist":
 		r.handlers.AddArtist(message)
 	case "remove_artist":
 		r.handlers.RemoveArtist(message)
+	case "alias":
+		r.handlers.Alias(message)
+	case "discover":
+		r.handlers.Discover(message)
+	case "relation":
+		r.handlers.Relation(message)
 	case "clearcache":
 		r.handlers.ClearCache(message)
 	case "clearwhitelists":
@@ -131,41 +222,89 @@ func (r *Router) handleMessage(message *tgbotapi.Message) {
 		r.handlers.ConfigReset(message)
 	case "tasks_list":
 		r.handlers.TasksList(message)
+	case "task_history":
+		r.handlers.TaskHistory(message)
 	case "reload_playlist":
 		r.handlers.ReloadPlaylist(message)
 	case "parse":
 		r.handlers.ParseReleases(message)
+	case "grant":
+		r.handlers.Grant(message)
+	case "revoke":
+		r.handlers.Revoke(message)
+	case "audit":
+		r.handlers.Audit(message)
 	case "llm_metrics":
 		r.handlers.LLMMetrics(message)
+	case "changes":
+		r.handlers.Changes(message)
+	case "snapshots":
+		r.handlers.Snapshots(message)
+	case "replay":
+		r.handlers.Replay(message)
 	default:
-		r.handlers.Unknown(message)
+		// В группе команда без @botname может предназначаться другому боту
+		if !group || explicit {
+			r.handlers.Unknown(message)
+		}
 	}
 }
 
 // handleCallbackQuery обрабатывает callback query
 func (r *Router) handleCallbackQuery(query *tgbotapi.CallbackQuery) {
-	r.handlers.CallbackQuery(query)
+	r.observe("callback", func() error {
+		return r.handlers.CallbackQuery(query)
+	})
 }
 
-// RegisterBotCommands регистрирует команды бота
-func (r *Router) RegisterBotCommands() []tgbotapi.BotCommand {
-	return r.handlers.RegisterBotCommands()
+// handleInlineQuery обрабатывает inline запрос
+func (r *Router) handleInlineQuery(query *tgbotapi.InlineQuery) {
+	r.observe("inline", func() error {
+		return r.handlers.InlineQuery(query)
+	})
 }
 
-// isAdmin проверяет, является ли пользователь администратором
-func (r *Router) isAdmin(user *tgbotapi.User) bool {
-	if user == nil {
-		return false
+// observe выполняет обработчик и записывает метрику по его результату: ошибка и паника
+// получают свои статусы. Паника передается дальше в recovery middleware
+func (r *Router) observe(label string, handle func() error) {
+	start := time.Now()
+	defer func() {
+		if p := recover(); p != nil {
+			metrics.ObserveCommand(label, metrics.StatusPanic, time.Since(start))
+			panic(p)
+		}
+	}()
+
+	err := handle()
+	metrics.ObserveCommand(label, metrics.Status(err), time.Since(start))
+}
+
+// RegisterBotCommands возвращает команды бота с описаниями на указанном языке
+func (r *Router) RegisterBotCommands(lang i18n.Lang) []tgbotapi.BotCommand {
+	return r.handlers.RegisterBotCommands(lang)
+}
+
+// commandAddressee определяет адресата команды: explicit - команда явно адресована боту через @botname
+// или ответом на его сообщение, ours - команда не адресована другому боту
+func (r *Router) commandAddressee(message *tgbotapi.Message) (explicit, ours bool) {
+	_, target, mentioned := strings.Cut(message.CommandWithAt(), "@")
+	if mentioned {
+		ours = r.botUsername == "" || strings.EqualFold(target, r.botUsername)
+		return ours, ours
 	}
 
-	// Получаем username администратора из конфигурации (уже загружена через загрузчик)
-	adminUsername := r.config.AdminUsername
-	if adminUsername == "" {
-		r.logger.Warn("ADMIN_USERNAME not configured")
+	reply := message.ReplyToMessage
+	explicit = reply != nil && reply.From != nil && reply.From.IsBot &&
+		r.botUsername != "" && strings.EqualFold(reply.From.UserName, r.botUsername)
+	return explicit, true
+}
+
+// canExecute проверяет права пользователя на команду
+func (r *Router) canExecute(user *tgbotapi.User, command string) bool {
+	if user == nil {
 		return false
 	}
-
-	return user.UserName == adminUsername
+	return r.services.Access.CanExecute(user.ID, user.UserName, command)
 }
 
 // getUserIdentifier возвращает идентификатор пользователя
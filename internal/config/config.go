@@ -19,6 +19,10 @@ type Config struct {
 	BotToken      string
 	AdminUsername string
 
+	// Telegram updates
+	UpdateMode    string // polling (по умолчанию) или webhook
+	WebhookConfig WebhookConfig
+
 	// Spotify
 	SpotifyClientID     string
 	SpotifyClientSecret string
@@ -28,6 +32,9 @@ type Config struct {
 	HealthPort         string
 	HealthCheckEnabled bool
 
+	// Calendar
+	CalendarConfig CalendarConfig
+
 	// Logging
 	LogLevel string
 
@@ -83,9 +90,20 @@ func Load() (*Config, error) {
 		SpotifyClientID:     getEnv("SPOTIFY_CLIENT_ID", ""),
 		SpotifyClientSecret: getEnv("SPOTIFY_CLIENT_SECRET", ""),
 		PlaylistURL:         getEnv("PLAYLIST_URL", ""),
-		HealthPort:          getEnv("HEALTH_PORT", "8080"),
-		HealthCheckEnabled:  getEnvBool("HEALTH_CHECK_ENABLED", true),
-		LogLevel:            getEnv("LOG_LEVEL", "info"),
+		UpdateMode:          getEnv("TELEGRAM_UPDATE_MODE", "polling"),
+		WebhookConfig: WebhookConfig{
+			URL:            getEnv("WEBHOOK_URL", ""),
+			ListenAddr:     getEnv("WEBHOOK_LISTEN_ADDR", ":8443"),
+			SecretToken:    getEnv("WEBHOOK_SECRET", ""),
+			MaxConnections: getEnvInt("WEBHOOK_MAX_CONNECTIONS", 40),
+		},
+		HealthPort:         getEnv("HEALTH_PORT", "8080"),
+		HealthCheckEnabled: getEnvBool("HEALTH_CHECK_ENABLED", true),
+		LogLevel:           getEnv("LOG_LEVEL", "info"),
+		CalendarConfig: CalendarConfig{
+			BaseURL: getEnv("CALENDAR_BASE_URL", ""),
+			Secret:  getEnv("CALENDAR_SECRET", ""),
+		},
 		HTTPClientConfig: HTTPClientConfig{
 			MaxIdleConns:          getEnvInt("HTTP_MAX_IDLE_CONNS", 100),
 			MaxIdleConnsPerHost:   getEnvInt("HTTP_MAX_IDLE_CONNS_PER_HOST", 10),
@@ -121,10 +139,15 @@ func Load() (*Config, error) {
 			RequestDelay: getEnvDuration("SCRAPER_REQUEST_DELAY", 2*time.Second),
 		},
 		LLMConfig: LLMConfig{
-			BaseURL: getEnv("LLM_BASE_URL", "https://integrate.api.nvidia.com/v1"),
-			APIKey:  getEnv("LLM_API_KEY", ""),
-			Timeout: getEnvDuration("LLM_TIMEOUT", 2*time.Minute),
-			Delay:   getEnvDuration("LLM_DELAY", 1500*time.Millisecond),
+			Provider:    getEnv("LLM_PROVIDER", "openai"),
+			BaseURL:     getEnv("LLM_BASE_URL", ""),
+			APIKey:      getEnv("LLM_API_KEY", ""),
+			Model:       getEnv("LLM_MODEL", ""),
+			Temperature: getEnvFloat("LLM_TEMPERATURE", 0.2),
+			TopP:        getEnvFloat("LLM_TOP_P", 0.7),
+			MaxTokens:   getEnvInt("LLM_MAX_TOKENS", 8192),
+			Timeout:     getEnvDuration("LLM_TIMEOUT", 2*time.Minute),
+			Delay:       getEnvDuration("LLM_DELAY", 1500*time.Millisecond),
 		},
 	}
 
@@ -266,10 +289,29 @@ type ScraperRetryConfig struct {
 	BackoffMultiplier float64
 }
 
+// WebhookConfig представляет конфигурацию приема обновлений Telegram через webhook
+type WebhookConfig struct {
+	URL            string // публичный HTTPS адрес, путь используется как путь обработчика
+	ListenAddr     string
+	SecretToken    string // пустое значение - токен генерируется при запуске
+	MaxConnections int
+}
+
+// CalendarConfig представляет конфигурацию iCalendar ленты релизов
+type CalendarConfig struct {
+	BaseURL string // публичный адрес health сервера для ссылок на ленту, пустое значение - /calendar отключена
+	Secret  string // ключ подписи персональных ссылок, пустое значение - используется токен бота
+}
+
 // LLMConfig представляет конфигурацию LLM клиента
 type LLMConfig struct {
-	BaseURL string
-	APIKey  string
-	Timeout time.Duration
-	Delay   time.Duration
+	Provider    string // openai (OpenAI-совместимый API) или ollama
+	BaseURL     string // пустое значение - адрес по умолчанию для провайдера
+	APIKey      string
+	Model       string // пустое значение - модель по умолчанию для провайдера
+	Temperature float64
+	TopP        float64
+	MaxTokens   int
+	Timeout     time.Duration
+	Delay       time.Duration
 }
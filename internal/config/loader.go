@@ -2,6 +2,9 @@
 package config
 
 import (
+	"os"
+	"strconv"
+
 	"go.uber.org/zap"
 )
 
@@ -61,6 +64,8 @@ func (cl *ConfigLoader) LoadConfigFromDB(cfg *Config) {
 		cfg.LLMConfig.APIKey = value
 	})
 
+	cl.loadLLMConfigFromDB(&cfg.LLMConfig)
+
 	// BOT_TOKEN
 	cl.LoadConfigValueWithSetter(cfg.BotToken, "BOT_TOKEN", func(value string) {
 		cfg.BotToken = value
@@ -81,3 +86,50 @@ func (cl *ConfigLoader) LoadConfigFromDB(cfg *Config) {
 		cfg.PlaylistURL = value
 	})
 }
+
+// loadLLMConfigFromDB загружает параметры LLM провайдера и модели.
+// Для этих ключей у конфигурации есть значения по умолчанию, поэтому
+// приоритет env > база данных определяется по самой переменной окружения
+func (cl *ConfigLoader) loadLLMConfigFromDB(cfg *LLMConfig) {
+	// LLM_PROVIDER
+	cl.LoadConfigValueWithSetter(os.Getenv("LLM_PROVIDER"), "LLM_PROVIDER", func(value string) {
+		cfg.Provider = value
+	})
+
+	// LLM_BASE_URL
+	cl.LoadConfigValueWithSetter(os.Getenv("LLM_BASE_URL"), "LLM_BASE_URL", func(value string) {
+		cfg.BaseURL = value
+	})
+
+	// LLM_MODEL
+	cl.LoadConfigValueWithSetter(os.Getenv("LLM_MODEL"), "LLM_MODEL", func(value string) {
+		cfg.Model = value
+	})
+
+	// LLM_TEMPERATURE
+	cl.LoadConfigValueWithSetter(os.Getenv("LLM_TEMPERATURE"), "LLM_TEMPERATURE", func(value string) {
+		if temperature, err := strconv.ParseFloat(value, 64); err == nil {
+			cfg.Temperature = temperature
+		} else {
+			cl.logger.Warn("Invalid LLM_TEMPERATURE value", zap.String("value", value), zap.Error(err))
+		}
+	})
+
+	// LLM_TOP_P
+	cl.LoadConfigValueWithSetter(os.Getenv("LLM_TOP_P"), "LLM_TOP_P", func(value string) {
+		if topP, err := strconv.ParseFloat(value, 64); err == nil {
+			cfg.TopP = topP
+		} else {
+			cl.logger.Warn("Invalid LLM_TOP_P value", zap.String("value", value), zap.Error(err))
+		}
+	})
+
+	// LLM_MAX_TOKENS
+	cl.LoadConfigValueWithSetter(os.Getenv("LLM_MAX_TOKENS"), "LLM_MAX_TOKENS", func(value string) {
+		if maxTokens, err := strconv.Atoi(value); err == nil && maxTokens > 0 {
+			cfg.MaxTokens = maxTokens
+		} else {
+			cl.logger.Warn("Invalid LLM_MAX_TOKENS value", zap.String("value", value), zap.Error(err))
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// PromptVersion версия промпта для парсинга блоков.
+// Нужно увеличивать при любом изменении промптов, чтобы не использовать старые ответы из кэша
+const PromptVersion = "v3"
+
+// ResponseCache определяет хранилище ответов LLM для HTML блоков
+type ResponseCache interface {
+	// Get возвращает ответ по ключу и признак попадания
+	Get(ctx context.Context, key string) (*MultiReleaseResponse, bool, error)
+	// Set сохраняет ответ по ключу
+	Set(ctx context.Context, key, month, model string, response *MultiReleaseResponse) error
+}
+
+// CacheKey возвращает ключ кэша для очищенного блока, месяца и версии промпта
+func CacheKey(htmlBlock, month string) string {
+	hash := sha256.New()
+	hash.Write([]byte(PromptVersion))
+	hash.Write([]byte{0})
+	hash.Write([]byte(month))
+	hash.Write([]byte{0})
+	hash.Write([]byte(htmlBlock))
+	return hex.EncodeToString(hash.Sum(nil))
+}
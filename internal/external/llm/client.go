@@ -5,7 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"gemfactory/internal/metrics"
 	"net/http"
 	"sync"
 	"time"
@@ -15,9 +15,9 @@ import (
 
 // Client представляет клиент для работы с LLM API
 type Client struct {
-	baseURL     string
-	apiKey      string
-	httpClient  *http.Client
+	provider    Provider
+	params      CompletionParams
+	cache       ResponseCache
 	logger      *zap.Logger
 	delay       time.Duration
 	lastRequest time.Time
@@ -27,14 +27,21 @@ type Client struct {
 	successCount    int64
 	errorCount      int64
 	lastRequestTime time.Time
+	cacheHits       int64
+	cacheMisses     int64
 }
 
 // Config конфигурация для LLM клиента
 type Config struct {
-	BaseURL string
-	APIKey  string
-	Timeout time.Duration
-	Delay   time.Duration
+	Provider    string // openai или ollama
+	BaseURL     string
+	APIKey      string
+	Model       string
+	Temperature float64
+	TopP        float64
+	MaxTokens   int
+	Timeout     time.Duration
+	Delay       time.Duration
 }
 
 // MultiReleaseData структура для одного релиза из мультирелиза
@@ -44,6 +51,8 @@ type MultiReleaseData struct {
 	Track      string `json:"track"`   // "GO!"
 	Album      string `json:"album"`   // "1st EP COLOR OUTSIDE THE LINES"
 	YouTubeURL string `json:"youtube"` // "https://youtu.be/..."
+	Type       string `json:"type"`    // "ep": single, album, ep, ost, digital
+	Time       string `json:"time"`    // "18:00" по KST, пусто - время не указано
 }
 
 // MultiReleaseResponse ответ от LLM с мультирелизами
@@ -80,20 +89,62 @@ type Choice struct {
 	Message Message `json:"message"`
 }
 
-// NewClient создает новый LLM клиент
+// NewClient создает новый LLM клиент с провайдером из конфигурации
 func NewClient(config Config, logger *zap.Logger) *Client {
+	httpClient := &http.Client{
+		Timeout: config.Timeout,
+	}
+
+	provider, err := NewProvider(config, httpClient, logger)
+	if err != nil {
+		logger.Error("Failed to create LLM provider, falling back to OpenAI-compatible",
+			zap.String("provider", config.Provider),
+			zap.Error(err))
+		config.Provider = ProviderOpenAI
+		provider, _ = NewProvider(config, httpClient, logger)
+	}
+
+	return NewClientWithProvider(config, provider, logger)
+}
+
+// NewClientWithProvider создает LLM клиент с готовым провайдером
+func NewClientWithProvider(config Config, provider Provider, logger *zap.Logger) *Client {
+	params := CompletionParams{
+		Model:       config.Model,
+		Temperature: config.Temperature,
+		TopP:        config.TopP,
+		MaxTokens:   config.MaxTokens,
+	}
+	if params.Model == "" {
+		params.Model = defaultModel(provider.Name())
+	}
+	if params.MaxTokens <= 0 {
+		params.MaxTokens = DefaultMaxTokens
+	}
+
+	logger.Info("LLM client configured",
+		zap.String("provider", provider.Name()),
+		zap.String("model", params.Model),
+		zap.Float64("temperature", params.Temperature),
+		zap.Float64("top_p", params.TopP),
+		zap.Int("max_tokens", params.MaxTokens))
+
 	return &Client{
-		baseURL: config.BaseURL,
-		apiKey:  config.APIKey,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
+		provider:    provider,
+		params:      params,
 		logger:      logger,
 		delay:       config.Delay,
 		lastRequest: time.Time{},
 	}
 }
 
+// SetCache устанавливает кэш ответов для ParseSingleBlock
+func (c *Client) SetCache(cache ResponseCache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = cache
+}
+
 // ParseMultiRelease парсит мультирелиз через LLM (устаревший метод)
 func (c *Client) ParseMultiRelease(ctx context.Context, htmlBlock string, month string) (*MultiReleaseResponse, error) {
 	prompt := c.createComplexBlockPrompt(htmlBlock, month)
@@ -126,6 +177,11 @@ func (c *Client) ParseMultiRelease(ctx context.Context, htmlBlock string, month
 
 // ParseSingleBlock парсит один HTML блок с мультирелизами через LLM с rate limiting
 func (c *Client) ParseSingleBlock(ctx context.Context, htmlBlock string, month string) (*MultiReleaseResponse, error) {
+	cacheKey := CacheKey(htmlBlock, month)
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
+		return cached, nil
+	}
+
 	if err := c.enforceRateLimit(); err != nil {
 		return nil, fmt.Errorf("rate limit enforcement failed: %w", err)
 	}
@@ -157,9 +213,62 @@ func (c *Client) ParseSingleBlock(ctx context.Context, htmlBlock string, month s
 	c.logger.Info("Successfully parsed multi-release block response",
 		zap.Int("releases_count", len(multiReleaseResponse.Releases)))
 
+	c.putCached(ctx, cacheKey, month, multiReleaseResponse)
+
 	return multiReleaseResponse, nil
 }
 
+// getCached возвращает ответ из кэша, если он есть
+func (c *Client) getCached(ctx context.Context, key string) (*MultiReleaseResponse, bool) {
+	c.mu.Lock()
+	cache := c.cache
+	c.mu.Unlock()
+
+	if cache == nil {
+		return nil, false
+	}
+
+	response, ok, err := cache.Get(ctx, key)
+	if err != nil {
+		c.logger.Warn("Failed to read LLM cache", zap.String("key", key), zap.Error(err))
+	}
+
+	c.mu.Lock()
+	if ok && err == nil {
+		c.cacheHits++
+		metrics.LLMCacheLookupsTotal.WithLabelValues("hit").Inc()
+	} else {
+		c.cacheMisses++
+		metrics.LLMCacheLookupsTotal.WithLabelValues("miss").Inc()
+	}
+	c.mu.Unlock()
+
+	if !ok || err != nil {
+		return nil, false
+	}
+
+	c.logger.Info("LLM cache hit, skipping request",
+		zap.String("key", key),
+		zap.Int("releases_count", len(response.Releases)))
+
+	return response, true
+}
+
+// putCached сохраняет ответ в кэш
+func (c *Client) putCached(ctx context.Context, key, month string, response *MultiReleaseResponse) {
+	c.mu.Lock()
+	cache := c.cache
+	c.mu.Unlock()
+
+	if cache == nil {
+		return
+	}
+
+	if err := cache.Set(ctx, key, month, c.params.Model, response); err != nil {
+		c.logger.Warn("Failed to write LLM cache", zap.String("key", key), zap.Error(err))
+	}
+}
+
 // enforceRateLimit применяет задержку между запросами
 func (c *Client) enforceRateLimit() error {
 	c.mu.Lock()
@@ -194,6 +303,10 @@ func (c *Client) GetMetrics() map[string]interface{} {
 		"failed_requests":     c.errorCount,
 		"last_request_time":   c.lastRequestTime,
 		"delay_ms":            c.delay.Milliseconds(),
+		"provider":            c.provider.Name(),
+		"model":               c.params.Model,
+		"cache_hits":          c.cacheHits,
+		"cache_misses":        c.cacheMisses,
 	}
 }
 
@@ -216,7 +329,7 @@ func (c *Client) createComplexBlockPrompt(htmlBlock string, month string) string
 	return fmt.Sprintf(`Извлеки все релизы из HTML-блока в JSON-массив:
 
 [
-  {"artist": "NAME", "date": "DD.MM.YY", "track": "NAME", "album": "NAME", "youtube": "URL"},
+  {"artist": "NAME", "date": "DD.MM.YY", "track": "NAME", "album": "NAME", "youtube": "URL", "type": "TYPE", "time": "HH:MM"},
   ...
 ]
 
@@ -227,6 +340,8 @@ func (c *Client) createComplexBlockPrompt(htmlBlock string, month string) string
 4. Трек берется из кавычек (' ' или " " или другие)
 5. Название альбома из поля "Album" или "OST" применяется ко всем релизам
 6. YouTube ссылки из тегов <a href=...>YouTube</a> встроены в название релиза или находятся на последующих строках
+7. Тип релиза по полю "Album" или "OST": "single", "album", "ep" (EP и Mini Album), "ost", "digital" (Digital Single); пустая строка, если тип не указан
+8. Время релиза по KST в формате HH:MM, только если оно указано в строке релиза ("6 PM KST" → "18:00"); пустая строка, если время не указано. Не подставляй время сам
 
 Пример:
 <event><date>October 27, 2025</date><need_unparse><artist>GROUP</artist>
@@ -236,85 +351,36 @@ October 13: "TRACK 1" MV Release
 Music Video: <a href="https://youtu.be/abc">YouTube</a>
 October 20: "TRACK 2" MV Release
 Music Video: <a href="https://youtu.be/def">YouTube</a>
-October 27: "TRACK 3" Release
-Album: Album Name</need_unparse></event>
+October 27: "TRACK 3" Release (6 PM KST)
+Album: 1st Mini Album Album Name</need_unparse></event>
 
-→ [{"artist": "GROUP", "date": "13.10.25", "track": "TRACK 1", "album": "Album Name", "youtube": "https://youtu.be/abc"}, {"artist": "GROUP", "date": "20.10.25", "track": "TRACK 2", "album": "Album Name", "youtube": "https://youtu.be/def"}, {"artist": "GROUP", "date": "27.10.25", "track": "TRACK 3", "album": "Album Name", "youtube": ""}]
+→ [{"artist": "GROUP", "date": "13.10.25", "track": "TRACK 1", "album": "1st Mini Album Album Name", "youtube": "https://youtu.be/abc", "type": "ep", "time": ""}, {"artist": "GROUP", "date": "20.10.25", "track": "TRACK 2", "album": "1st Mini Album Album Name", "youtube": "https://youtu.be/def", "type": "ep", "time": ""}, {"artist": "GROUP", "date": "27.10.25", "track": "TRACK 3", "album": "1st Mini Album Album Name", "youtube": "", "type": "ep", "time": "18:00"}]
 
 HTML-блок:
 %s`, month, htmlBlock)
 }
 
-// sendRequest отправляет запрос к LLM API
+// systemPrompt задает формат ответа модели
+const systemPrompt = "You are a JSON extraction tool for K-pop releases. Extract releases from HTML blocks and return ONLY valid JSON array in this exact format:\n\nExtract releases from the provided block, filtering by the specified month. Use dates specified within the block or the <date> tag as fallback.\n\n[\n  {\n    \"artist\": \"ARTIST NAME\",\n    \"date\": \"DD.MM.YY\",\n    \"track\": \"TRACK NAME\",\n    \"album\": \"ALBUM NAME\",\n    \"youtube\": \"https://youtu.be/...\",\n    \"type\": \"single|album|ep|ost|digital\",\n    \"time\": \"HH:MM (KST) or empty\"\n  }\n]\n\nCRITICAL: Return ONLY valid JSON array with standard ASCII characters. No explanations, no reasoning, no markdown, no code blocks, no special Unicode characters like â, é, ñ, etc. Use only standard JSON format."
+
+// sendRequest отправляет запрос к LLM через провайдера
 func (c *Client) sendRequest(ctx context.Context, prompt string) (string, error) {
-	request := Request{
-		Model: "qwen/qwen2.5-7b-instruct",
-		Messages: []Message{
-			{
-				Role:    "system",
-				Content: "You are a JSON extraction tool for K-pop releases. Extract releases from HTML blocks and return ONLY valid JSON array in this exact format:\n\nExtract releases from the provided block, filtering by the specified month. Use dates specified within the block or the <date> tag as fallback.\n\n[\n  {\n    \"artist\": \"ARTIST NAME\",\n    \"date\": \"DD.MM.YY\",\n    \"track\": \"TRACK NAME\",\n    \"album\": \"ALBUM NAME\",\n    \"youtube\": \"https://youtu.be/...\"\n  }\n]\n\nCRITICAL: Return ONLY valid JSON array with standard ASCII characters. No explanations, no reasoning, no markdown, no code blocks, no special Unicode characters like â, é, ñ, etc. Use only standard JSON format.",
-			},
-			{
-				Role:    "user",
-				Content: prompt,
-			},
+	messages := []Message{
+		{
+			Role:    "system",
+			Content: systemPrompt,
+		},
+		{
+			Role:    "user",
+			Content: prompt,
 		},
-		Temperature: 0.2,
-		TopP:        0.7,
-		MaxTokens:   8192,
-		Stream:      false,
-	}
-
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-
-	c.logger.Debug("Sending request to LLM", zap.String("url", req.URL.String()))
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
-	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			c.logger.Warn("Failed to close response body", zap.Error(err))
-		}
-	}()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	c.logger.Info("LLM API response",
-		zap.Int("status_code", resp.StatusCode),
-		zap.String("response_body", string(body)))
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("LLM API returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var response Response
-	if err := json.Unmarshal(body, &response); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	if len(response.Choices) == 0 {
-		return "", fmt.Errorf("no choices in LLM response")
 	}
 
-	message := response.Choices[0].Message
+	start := time.Now()
+	response, err := c.provider.Complete(ctx, messages, c.params)
+	metrics.ObserveLLMRequest(c.provider.Name(), c.params.Model, err, time.Since(start))
 
-	return message.Content, nil
+	return response, err
 }
 
 // parseResponse парсит ответ от LLM в структуру MultiReleaseResponse
@@ -0,0 +1,124 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// OllamaProvider работает с нативным API Ollama /api/chat
+type OllamaProvider struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// ollamaRequest структура запроса к /api/chat
+type ollamaRequest struct {
+	Model    string        `json:"model"`
+	Messages []Message     `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Options  ollamaOptions `json:"options"`
+}
+
+// ollamaOptions параметры генерации Ollama
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p"`
+	NumPredict  int     `json:"num_predict,omitempty"`
+}
+
+// ollamaResponse ответ /api/chat без стриминга
+type ollamaResponse struct {
+	Message Message `json:"message"`
+	Done    bool    `json:"done"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// NewOllamaProvider создает провайдера для локального Ollama
+func NewOllamaProvider(baseURL string, httpClient *http.Client, logger *zap.Logger) *OllamaProvider {
+	return &OllamaProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// Name возвращает имя провайдера
+func (p *OllamaProvider) Name() string {
+	return ProviderOllama
+}
+
+// Complete отправляет запрос к /api/chat
+func (p *OllamaProvider) Complete(ctx context.Context, messages []Message, params CompletionParams) (string, error) {
+	request := ollamaRequest{
+		Model:    params.Model,
+		Messages: messages,
+		Stream:   false,
+		Options: ollamaOptions{
+			Temperature: params.Temperature,
+			TopP:        params.TopP,
+			NumPredict:  params.MaxTokens,
+		},
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	p.logger.Debug("Sending request to LLM",
+		zap.String("provider", p.Name()),
+		zap.String("model", params.Model),
+		zap.String("url", req.URL.String()))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			p.logger.Warn("Failed to close response body", zap.Error(err))
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	p.logger.Info("LLM API response",
+		zap.String("provider", p.Name()),
+		zap.Int("status_code", resp.StatusCode),
+		zap.String("response_body", string(body)))
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ollama API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response ollamaResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if response.Error != "" {
+		return "", fmt.Errorf("ollama returned error: %s", response.Error)
+	}
+
+	return response.Message.Content, nil
+}
+
+var _ Provider = (*OllamaProvider)(nil)
@@ -0,0 +1,106 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// OpenAIProvider работает с OpenAI-совместимым API /chat/completions
+// (OpenAI, NVIDIA NIM, OpenRouter, vLLM, LM Studio и т.п.)
+type OpenAIProvider struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewOpenAIProvider создает OpenAI-совместимого провайдера
+func NewOpenAIProvider(baseURL, apiKey string, httpClient *http.Client, logger *zap.Logger) *OpenAIProvider {
+	return &OpenAIProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// Name возвращает имя провайдера
+func (p *OpenAIProvider) Name() string {
+	return ProviderOpenAI
+}
+
+// Complete отправляет запрос к /chat/completions
+func (p *OpenAIProvider) Complete(ctx context.Context, messages []Message, params CompletionParams) (string, error) {
+	request := Request{
+		Model:       params.Model,
+		Messages:    messages,
+		Temperature: params.Temperature,
+		TopP:        params.TopP,
+		MaxTokens:   params.MaxTokens,
+		Stream:      false,
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	p.logger.Debug("Sending request to LLM",
+		zap.String("provider", p.Name()),
+		zap.String("model", params.Model),
+		zap.String("url", req.URL.String()))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			p.logger.Warn("Failed to close response body", zap.Error(err))
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	p.logger.Info("LLM API response",
+		zap.String("provider", p.Name()),
+		zap.Int("status_code", resp.StatusCode),
+		zap.String("response_body", string(body)))
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("LLM API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response Response
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("no choices in LLM response")
+	}
+
+	return response.Choices[0].Message.Content, nil
+}
+
+var _ Provider = (*OpenAIProvider)(nil)
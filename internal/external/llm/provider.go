@@ -0,0 +1,69 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Поддерживаемые провайдеры LLM
+const (
+	ProviderOpenAI = "openai"
+	ProviderOllama = "ollama"
+)
+
+// Значения по умолчанию для провайдеров
+const (
+	DefaultOpenAIBaseURL = "https://integrate.api.nvidia.com/v1"
+	DefaultOpenAIModel   = "qwen/qwen2.5-7b-instruct"
+	DefaultOllamaBaseURL = "http://localhost:11434"
+	DefaultOllamaModel   = "qwen2.5:7b-instruct"
+	DefaultMaxTokens     = 8192
+)
+
+// CompletionParams параметры генерации ответа
+type CompletionParams struct {
+	Model       string
+	Temperature float64
+	TopP        float64
+	MaxTokens   int
+}
+
+// Provider определяет бэкенд, выполняющий chat-запросы к модели
+type Provider interface {
+	// Name возвращает имя провайдера
+	Name() string
+	// Complete отправляет сообщения модели и возвращает текст ответа
+	Complete(ctx context.Context, messages []Message, params CompletionParams) (string, error)
+}
+
+// NewProvider создает провайдера по имени из конфигурации
+func NewProvider(config Config, httpClient *http.Client, logger *zap.Logger) (Provider, error) {
+	switch strings.ToLower(strings.TrimSpace(config.Provider)) {
+	case "", ProviderOpenAI:
+		baseURL := config.BaseURL
+		if baseURL == "" {
+			baseURL = DefaultOpenAIBaseURL
+		}
+		return NewOpenAIProvider(baseURL, config.APIKey, httpClient, logger), nil
+	case ProviderOllama:
+		baseURL := config.BaseURL
+		if baseURL == "" {
+			baseURL = DefaultOllamaBaseURL
+		}
+		return NewOllamaProvider(baseURL, httpClient, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown LLM provider: %s", config.Provider)
+	}
+}
+
+// defaultModel возвращает модель по умолчанию для провайдера
+func defaultModel(provider string) string {
+	if strings.EqualFold(strings.TrimSpace(provider), ProviderOllama) {
+		return DefaultOllamaModel
+	}
+	return DefaultOpenAIModel
+}
@@ -1,7 +1,7 @@
 package scraper
 
 import (
-	"html"
+	"gemfactory/internal/model"
 	"regexp"
 	"strings"
 	"sync"
@@ -9,60 +9,76 @@ import (
 	"go.uber.org/zap"
 )
 
+// unmatchedReleaseMarkup служебная разметка, попадающая в альбом или трек из очищенной строки
+var unmatchedReleaseMarkup = regexp.MustCompile(`</?(?:need_unparse|event|a)\b[^>]*>`)
+
 // collectArtistBlock собирает блок с артистом для LLM обработки
-func (f *fetcherImpl) collectArtistBlock(rowHTML string, artists map[string]bool, artistBlocks *[]ArtistBlock, mu *sync.Mutex, rowCount int) {
-	// Извлекаем артиста из строки - ищем <strong><mark> теги с артистами
-	// Используем более гибкий паттерн, который ищет mark теги с классом has-red-color
-	artistPattern := regexp.MustCompile(`<strong><mark[^>]*class="[^"]*has-red-color[^"]*"[^>]*>([^<]+)</mark></strong>`)
-	artistMatches := artistPattern.FindAllStringSubmatch(rowHTML, -1)
+func (f *fetcherImpl) collectArtistBlock(source Source, url, year, rowHTML string, artists ArtistFilter, artistBlocks *[]ArtistBlock, mu *sync.Mutex, rowCount int) {
+	// Извлекаем артиста из строки по разметке источника
+	artist, ok := source.MatchArtist(rowHTML, artists)
 
-	if len(artistMatches) == 0 {
+	if artist == "" {
 		blockPreview := rowHTML
 		if len(rowHTML) > 500 {
 			blockPreview = rowHTML[:500]
 		}
-		f.logger.Debug("No artist found in row", zap.String("row", blockPreview))
+		f.logger.Debug("No artist found in row", zap.String("source", source.Name()), zap.String("row", blockPreview))
 		return
 	}
 
-	// Проверяем каждого найденного артиста
-	for _, match := range artistMatches {
-		if len(match) < 2 {
-			continue
+	// Если ни один артист из строки не в списке, пропускаем строку
+	if !ok {
+		f.logger.Debug("Artist not in filter list", zap.String("artist", artist), zap.Int("row", rowCount))
+		if observer, observe := artists.(UnmatchedArtistObserver); observe {
+			observer.ObserveUnmatched(f.unmatchedArtist(source, url, year, rowHTML, artist))
 		}
+		return
+	}
 
-		artist := strings.TrimSpace(match[1])
-		// Декодируем HTML-сущности: &amp;TEAM -> &TEAM
-		artist = html.UnescapeString(artist)
-		artistKey := strings.ToLower(artist)
+	f.logger.Info("Found active artist in row", zap.String("artist", artist), zap.Int("row", rowCount))
 
-		// Проверяем, есть ли артист в списке для фильтрации
-		if _, ok := artists[artistKey]; ok {
-			f.logger.Info("Found active artist in row", zap.String("artist", artist), zap.Int("row", rowCount))
+	// Добавляем всю строку в коллекцию
+	mu.Lock()
+	*artistBlocks = append(*artistBlocks, ArtistBlock{
+		HTML:   rowHTML,
+		Artist: artist,
+		Row:    rowCount,
+	})
+	total := len(*artistBlocks)
+	mu.Unlock()
+
+	f.logger.Debug("Added artist row for LLM processing",
+		zap.String("artist", artist),
+		zap.Int("row", rowCount),
+		zap.Int("total_blocks", total))
+}
 
-			// Добавляем всю строку в коллекцию
-			mu.Lock()
-			*artistBlocks = append(*artistBlocks, ArtistBlock{
-				HTML:   rowHTML,
-				Artist: artist,
-				Row:    rowCount,
-			})
-			mu.Unlock()
+// unmatchedArtist разбирает дату и релиз из строки с артистом не из списка.
+// Разбор без LLM: строка нужна только как пример для отчета о новых артистах
+func (f *fetcherImpl) unmatchedArtist(source Source, url, year, rowHTML, artist string) UnmatchedArtist {
+	cleanedHTML := source.NormalizeRow(rowHTML)
+	quiet := zap.NewNop()
 
-			f.logger.Debug("Added artist row for LLM processing",
-				zap.String("artist", artist),
-				zap.Int("row", rowCount),
-				zap.Int("total_blocks", len(*artistBlocks)))
-			return // Нашли одного артиста из списка, этого достаточно
+	row := UnmatchedArtist{
+		Artist: artist,
+		Source: source.Name(),
+		URL:    url,
+	}
+
+	if date := extractDate(cleanedHTML, "", year, quiet); date != "" {
+		if formatted, err := model.FormatDateWithYear(date, year, quiet); err == nil {
+			row.Date = formatted
 		}
 	}
 
-	// Если дошли сюда, значит ни один артист из блока не в списке
-	firstArtist := ""
-	if len(artistMatches) > 0 && len(artistMatches[0]) > 1 {
-		firstArtist = strings.TrimSpace(artistMatches[0][1])
-		// Декодируем HTML-сущности для логирования
-		firstArtist = html.UnescapeString(firstArtist)
+	var parts []string
+	for _, part := range []string{extractAlbum(cleanedHTML, quiet), extractTrack(cleanedHTML, quiet)} {
+		part = strings.TrimSpace(unmatchedReleaseMarkup.ReplaceAllString(part, ""))
+		if part != "" {
+			parts = append(parts, part)
+		}
 	}
-	f.logger.Debug("Artist not in filter list", zap.String("artist", firstArtist), zap.Int("row", rowCount))
+	row.Release = strings.Join(parts, " / ")
+
+	return row
 }
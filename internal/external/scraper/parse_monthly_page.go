@@ -4,24 +4,26 @@ import (
 	"context"
 	"fmt"
 	"html"
+	"net/url"
 	"regexp"
 	"strings"
-	"sync"
 	"time"
 
+	"gemfactory/internal/external/llm"
 	"gemfactory/internal/model"
 
-	"github.com/gocolly/colly/v2"
 	"go.uber.org/zap"
 )
 
 // ParsedRelease представляет результат парсинга одного релиза
 type ParsedRelease struct {
-	Artist     string `json:"artist"`
-	Date       string `json:"date"`
-	Track      string `json:"track"`
-	Album      string `json:"album"`
-	YouTubeURL string `json:"youtube"`
+	Artist     string            `json:"artist"`
+	Date       string            `json:"date"`
+	Track      string            `json:"track"`
+	Album      string            `json:"album"`
+	YouTubeURL string            `json:"youtube"`
+	Type       model.ReleaseType `json:"type,omitempty"`
+	Time       string            `json:"time,omitempty"` // Время релиза по KST (15:04), пусто - не указано в источнике
 }
 
 // ParseResult представляет результат парсинга блока
@@ -31,26 +33,22 @@ type ParseResult struct {
 	Error    string          `json:"error,omitempty"`
 }
 
-// cleanYouTubeURL очищает YouTube URL от лишних параметров
-func cleanYouTubeURL(url string) string {
-	// Удаляем параметры типа ?si=... и другие tracking параметры
-	url = regexp.MustCompile(`\?si=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`&si=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`\?t=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`&t=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`\?list=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`\?v=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`&v=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`\?feature=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`&feature=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`\?index=[^&]*`).ReplaceAllString(url, "")
-	url = regexp.MustCompile(`&index=[^&]*`).ReplaceAllString(url, "")
-
-	// Удаляем оставшиеся пустые параметры
-	url = regexp.MustCompile(`\?&`).ReplaceAllString(url, "?")
-	url = regexp.MustCompile(`\?$`).ReplaceAllString(url, "")
-
-	return url
+// cleanYouTubeURL очищает YouTube URL от tracking параметров (si=, t=, feature= и т.д.).
+// У ссылок youtube.com/watch сохраняется идентификатор видео v=
+func cleanYouTubeURL(rawURL string) string {
+	parsed, err := url.Parse(html.UnescapeString(rawURL))
+	if err != nil {
+		return rawURL
+	}
+
+	videoID := parsed.Query().Get("v")
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	if videoID != "" {
+		parsed.RawQuery = url.Values{"v": {videoID}}.Encode()
+	}
+
+	return parsed.String()
 }
 
 // cleanHTMLBlock очищает HTML блок - извлекает дату, артиста и релизы в формате <event>
@@ -170,7 +168,7 @@ func IsSimpleCase(htmlStr string, logger *zap.Logger) bool {
 	hasTitleTrack := regexp.MustCompile(`(?i)title track:\s*[^\n]+`).MatchString(htmlStr)
 	hasAlbum := regexp.MustCompile(`(?i)album:\s*[^\n]+`).MatchString(htmlStr)
 	hasOST := regexp.MustCompile(`(?i)ost:\s*[^\n]+`).MatchString(htmlStr)
-	hasYouTube := regexp.MustCompile(`https://(?:youtu\.be/|youtube\.com/)[^\s"']+`).MatchString(htmlStr)
+	hasYouTube := regexp.MustCompile(`https://(?:youtu\.be/|(?:www\.|m\.)?youtube\.com/)[^\s"'<>]+`).MatchString(htmlStr)
 
 	logger.Debug("Simple case checks",
 		zap.Bool("has_title_track", hasTitleTrack),
@@ -285,16 +283,21 @@ func ExtractSimpleRelease(htmlStr, month, year string, logger *zap.Logger) (*Par
 	// 4. Извлекаем альбом
 	album := extractAlbum(htmlStr, logger)
 	logger.Info("Extracted album", zap.String("album", album))
+	releaseType := extractReleaseType(htmlStr, album)
 
 	// 5. Извлекаем YouTube ссылку
 	youtube := extractYouTubeLink(htmlStr, logger)
 	logger.Info("Extracted youtube", zap.String("youtube", youtube))
 
+	// 6. Извлекаем время релиза, если оно указано
+	releaseTime := extractReleaseTime(htmlStr)
+
 	logger.Debug("Extracted simple release",
 		zap.String("artist", artist),
 		zap.String("date", date),
 		zap.String("track", track),
 		zap.String("album", album),
+		zap.String("type", releaseType.String()),
 		zap.String("youtube", youtube))
 
 	return &ParseResult{
@@ -304,6 +307,8 @@ func ExtractSimpleRelease(htmlStr, month, year string, logger *zap.Logger) (*Par
 			Track:      track,
 			Album:      album,
 			YouTubeURL: youtube,
+			Type:       releaseType,
+			Time:       releaseTime,
 		}},
 		Success: true,
 	}, nil
@@ -445,11 +450,51 @@ func extractTrack(htmlStr string, logger *zap.Logger) string {
 	return ""
 }
 
+// Подписи альбома в строке расписания
+var (
+	albumLabelRegex = regexp.MustCompile(`(?i)album:\s*([^\n]+)`)
+	ostLabelRegex   = regexp.MustCompile(`(?i)ost:\s*([^\n]+)`)
+)
+
+// releaseTimeRegex находит время релиза по KST: "6 PM KST", "6:30 PM (KST)", "18:00 KST"
+var releaseTimeRegex = regexp.MustCompile(`(?i)\b(\d{1,2}(?::\d{2})?)\s*([AP]\.?M\.?)?\s*\(?KST\b`)
+
+// extractReleaseTime извлекает время релиза по KST в формате 15:04, пусто - время не указано
+func extractReleaseTime(htmlStr string) string {
+	match := releaseTimeRegex.FindStringSubmatch(htmlStr)
+	if match == nil {
+		return ""
+	}
+	return normalizeReleaseTime(match[1] + " " + strings.ReplaceAll(match[2], ".", ""))
+}
+
+// normalizeReleaseTime приводит время вида "6 PM", "6:30 pm" или "18:00" к формату 15:04, пусто - не удалось разобрать
+func normalizeReleaseTime(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	for _, layout := range []string{"15:04", "3 PM", "3:04 PM", "3PM", "3:04PM"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.Format("15:04")
+		}
+	}
+	return ""
+}
+
+// releaseTimeMSK переводит время релиза из KST в MSK, "N/A" - время не указано в источнике
+func releaseTimeMSK(utils *model.ReleaseUtils, kstTime string) string {
+	if kstTime == "" {
+		return "N/A"
+	}
+	mskTime, err := utils.ConvertKSTToMSKString(kstTime)
+	if err != nil {
+		return "N/A"
+	}
+	return mskTime
+}
+
 // extractAlbum извлекает альбом из HTML блока
 func extractAlbum(htmlStr string, logger *zap.Logger) string {
 	// 1. Ищем "Album:"
-	albumRegex := regexp.MustCompile(`(?i)album:\s*([^\n]+)`)
-	matches := albumRegex.FindStringSubmatch(htmlStr)
+	matches := albumLabelRegex.FindStringSubmatch(htmlStr)
 	if len(matches) > 1 {
 		album := strings.TrimSpace(matches[1])
 		logger.Debug("Found album", zap.String("album", album))
@@ -457,8 +502,7 @@ func extractAlbum(htmlStr string, logger *zap.Logger) string {
 	}
 
 	// 2. Ищем "OST:"
-	ostRegex := regexp.MustCompile(`(?i)ost:\s*([^\n]+)`)
-	matches = ostRegex.FindStringSubmatch(htmlStr)
+	matches = ostLabelRegex.FindStringSubmatch(htmlStr)
 	if len(matches) > 1 {
 		album := strings.TrimSpace(matches[1])
 		logger.Debug("Found OST", zap.String("album", album))
@@ -468,16 +512,25 @@ func extractAlbum(htmlStr string, logger *zap.Logger) string {
 	return ""
 }
 
+// extractReleaseType определяет тип релиза: подпись "OST:" без "Album:" - саундтрек,
+// иначе тип берется из названия альбома ("1st EP", "2nd Mini Album", "Digital Single")
+func extractReleaseType(htmlStr, album string) model.ReleaseType {
+	if !albumLabelRegex.MatchString(htmlStr) && ostLabelRegex.MatchString(htmlStr) {
+		return model.ReleaseTypeOST
+	}
+	return model.ClassifyReleaseType(album)
+}
+
 // extractYouTubeLink извлекает YouTube ссылку из HTML блока
 func extractYouTubeLink(htmlStr string, logger *zap.Logger) string {
 	// Ищем YouTube ссылки
-	youtubeRegex := regexp.MustCompile(`https://(?:youtu\.be/|youtube\.com/)[^\s"']+`)
+	youtubeRegex := regexp.MustCompile(`https://(?:youtu\.be/|(?:www\.|m\.)?youtube\.com/)[^\s"'<>]+`)
 	matches := youtubeRegex.FindStringSubmatch(htmlStr)
 	if len(matches) > 0 {
-		url := matches[0]
+		link := matches[0]
 		// Исключаем каналы
-		if !strings.Contains(url, "/@") {
-			cleanedURL := cleanYouTubeURL(url)
+		if !strings.Contains(link, "/@") {
+			cleanedURL := cleanYouTubeURL(link)
 			logger.Debug("Found YouTube link", zap.String("url", cleanedURL))
 			return cleanedURL
 		}
@@ -524,10 +577,30 @@ func TestExtractDate(htmlStr, month, year string) string {
 	return extractDate(htmlStr, month, year, logger)
 }
 
-// llmParseBlocksIndividually отправляет каждый блок в LLM отдельно с rate limiting
-func (f *fetcherImpl) llmParseBlocksIndividually(ctx context.Context, blocks []string, month, year string) ([]ParsedRelease, error) {
+// llmReleaseType возвращает тип релиза из ответа LLM, а при неизвестном значении - по названию альбома
+func llmReleaseType(release llm.MultiReleaseData) model.ReleaseType {
+	if releaseType, ok := model.ParseReleaseType(release.Type); ok {
+		return releaseType
+	}
+	return model.ClassifyReleaseType(release.Album)
+}
+
+// IncompleteParseError часть блоков страницы не удалось разобрать через LLM.
+// Возвращается вместе с релизами из остальных блоков
+type IncompleteParseError struct {
+	FailedBlocks int // Блоков, не разобранных LLM
+	TotalBlocks  int // Блоков, отправленных в LLM
+}
+
+func (e *IncompleteParseError) Error() string {
+	return fmt.Sprintf("failed to parse %d of %d blocks with LLM", e.FailedBlocks, e.TotalBlocks)
+}
+
+// llmParseBlocksIndividually отправляет каждый блок в LLM отдельно с rate limiting.
+// Возвращает релизы и количество блоков, которые не удалось разобрать
+func (f *fetcherImpl) llmParseBlocksIndividually(ctx context.Context, blocks []string, month, year string) ([]ParsedRelease, int, error) {
 	if len(blocks) == 0 {
-		return []ParsedRelease{}, nil
+		return []ParsedRelease{}, 0, nil
 	}
 
 	f.logger.Info("Starting individual block processing",
@@ -544,7 +617,7 @@ func (f *fetcherImpl) llmParseBlocksIndividually(ctx context.Context, blocks []s
 			f.logger.Info("Context cancelled during LLM processing",
 				zap.Int("processed_blocks", i),
 				zap.Int("total_blocks", len(blocks)))
-			return allReleases, ctx.Err()
+			return allReleases, len(errors), ctx.Err()
 		default:
 		}
 
@@ -571,6 +644,8 @@ func (f *fetcherImpl) llmParseBlocksIndividually(ctx context.Context, blocks []s
 				Track:      release.Track,
 				Album:      release.Album,
 				YouTubeURL: release.YouTubeURL,
+				Type:       llmReleaseType(release),
+				Time:       normalizeReleaseTime(release.Time),
 			})
 		}
 
@@ -595,72 +670,46 @@ func (f *fetcherImpl) llmParseBlocksIndividually(ctx context.Context, blocks []s
 		zap.Int("failed_blocks", len(errors)),
 		zap.Int("total_releases", len(allReleases)))
 
-	return allReleases, nil
+	return allReleases, len(errors), nil
 }
 
 // ParseMonthlyPage parses a monthly schedule page (новая LLM-основанная логика)
-func (f *fetcherImpl) ParseMonthlyPage(ctx context.Context, url, month, year string, artists map[string]bool) ([]Release, error) {
-	monthNum, ok := f.getMonthNumber(strings.ToLower(month))
-	if !ok {
+func (f *fetcherImpl) ParseMonthlyPage(ctx context.Context, url, month, year string, artists ArtistFilter) ([]Release, error) {
+	source := f.sources.Primary()
+	if source == nil {
+		return nil, fmt.Errorf("no release sources registered")
+	}
+	return f.ParseSourcePage(ctx, source, url, month, year, artists)
+}
+
+// ParseSourcePage загружает и парсит страницу расписания указанного источника
+func (f *fetcherImpl) ParseSourcePage(ctx context.Context, source Source, url, month, year string, artists ArtistFilter) ([]Release, error) {
+	if _, ok := f.getMonthNumber(strings.ToLower(month)); !ok {
 		f.logger.Error("Unknown month", zap.String("month", month))
 		return nil, fmt.Errorf("unknown month: %s", month)
 	}
 
-	// Собираем все блоки с артистами для LLM обработки
-	var artistBlocks []ArtistBlock
-	var mu sync.Mutex
-	rowCount := 0
-	var contextCancelled bool
-
-	collector := f.newCollector()
-	collector.OnHTML("table tbody tr", func(e *colly.HTMLElement) {
-		// Проверяем контекст только один раз в начале обработки
-		if contextCancelled {
-			return
-		}
-
-		select {
-		case <-ctx.Done():
-			if !contextCancelled {
-				contextCancelled = true
-				f.logger.Debug("HTML processing cancelled due to context cancellation",
-					zap.String("url", e.Request.URL.String()),
-					zap.Error(ctx.Err()))
-			}
-			return
-		default:
-			rowCount++
-			// Получаем HTML всей строки <tr>
-			rowHTML, _ := e.DOM.Html()
-			f.collectArtistBlock(rowHTML, artists, &artistBlocks, &mu, rowCount)
-		}
-	})
-
-	collector.OnError(func(r *colly.Response, err error) {
-		f.logger.Error("Failed to scrape page", zap.String("url", r.Request.URL.String()), zap.Error(err))
-	})
-
-	// Используем retry механизм для надежности
-	retryConfig := RetryConfig{
-		MaxRetries:        f.config.RetryConfig.MaxRetries,
-		InitialDelay:      f.config.RetryConfig.InitialDelay,
-		MaxDelay:          f.config.RetryConfig.MaxDelay,
-		BackoffMultiplier: f.config.RetryConfig.BackoffMultiplier,
+	snapshot, err := f.FetchSourcePage(ctx, source, url, nil)
+	if err != nil {
+		return nil, err
 	}
+	return f.ParseSnapshot(ctx, source, snapshot, month, year, artists)
+}
 
-	err := WithRetry(ctx, f.logger, retryConfig, func() error {
-		return collector.Visit(url)
-	})
+// ParseSnapshot парсит сохраненный снимок страницы расписания без обращения к сайту источника.
+// Если часть блоков не разобрана LLM, релизы остальных блоков возвращаются вместе с *IncompleteParseError
+func (f *fetcherImpl) ParseSnapshot(ctx context.Context, source Source, snapshot *PageSnapshot, month, year string, artists ArtistFilter) ([]Release, error) {
+	monthNum, ok := f.getMonthNumber(strings.ToLower(month))
+	if !ok {
+		f.logger.Error("Unknown month", zap.String("month", month))
+		return nil, fmt.Errorf("unknown month: %s", month)
+	}
 
+	// Собираем все блоки с артистами для LLM обработки
+	artistBlocks, err := f.collectSnapshotBlocks(ctx, source, snapshot, year, artists)
 	if err != nil {
-		if ctx.Err() != nil {
-			f.logger.Debug("ParseMonthlyPage cancelled due to context cancellation", zap.Error(ctx.Err()))
-			return nil, ctx.Err()
-		}
-		f.logger.Error("Failed to visit page after retries", zap.String("url", url), zap.Error(err))
-		return nil, fmt.Errorf("failed to visit page after retries: %w", err)
+		return nil, err
 	}
-	collector.Wait()
 
 	if len(artistBlocks) == 0 {
 		f.logger.Info("No artist blocks found for processing", zap.String("month", month), zap.String("year", year))
@@ -677,7 +726,7 @@ func (f *fetcherImpl) ParseMonthlyPage(ctx context.Context, url, month, year str
 		// 1. Собираем RAW блоки (уже есть в block.HTML)
 
 		// 2. Очищаем RAW блоки чистилкой
-		cleanedHTML := cleanHTMLBlock(block.HTML)
+		cleanedHTML := source.NormalizeRow(block.HTML)
 
 		// 3. Проверяем их на "простоту" (простые комбинации)
 		isSimple := IsSimpleCase(cleanedHTML, f.logger)
@@ -723,17 +772,19 @@ func (f *fetcherImpl) ParseMonthlyPage(ctx context.Context, url, month, year str
 
 	// Парсим оставшиеся блоки через LLM (по одному блоку)
 	var llmParsedReleases []ParsedRelease
+	failedBlocks := 0
 	if len(deduplicatedBlocks) > 0 && f.llmClient != nil {
 		f.logger.Info("Processing remaining blocks with LLM (one by one)",
 			zap.Int("original_blocks_count", len(llmBlocks)),
 			zap.Int("deduplicated_blocks_count", len(deduplicatedBlocks)))
 
-		llmReleases, err := f.llmParseBlocksIndividually(ctx, deduplicatedBlocks, month, year)
+		llmReleases, failed, err := f.llmParseBlocksIndividually(ctx, deduplicatedBlocks, month, year)
 		if err != nil {
 			f.logger.Error("Failed to parse blocks with LLM", zap.Error(err))
 			return nil, fmt.Errorf("failed to parse blocks with LLM: %w", err)
 		}
 		llmParsedReleases = llmReleases
+		failedBlocks = failed
 	} else if len(llmBlocks) > 0 {
 		f.logger.Warn("Blocks need LLM processing but LLM not available", zap.Int("blocks_count", len(llmBlocks)))
 		return nil, fmt.Errorf("LLM client not available for processing %d blocks", len(llmBlocks))
@@ -744,6 +795,7 @@ func (f *fetcherImpl) ParseMonthlyPage(ctx context.Context, url, month, year str
 
 	// Конвертируем в Release
 	var allReleases []Release
+	utils := model.NewReleaseUtils()
 	for _, parsedRelease := range allParsedReleases {
 		// Преобразуем дату в нужный формат
 		parsedDate, err := model.FormatDateWithYear(parsedRelease.Date, year, f.logger)
@@ -762,11 +814,13 @@ func (f *fetcherImpl) ParseMonthlyPage(ctx context.Context, url, month, year str
 		// Создаем релиз
 		release := Release{
 			Date:       parsedDate,
-			TimeMSK:    time.Now().Format("15:04"),
+			TimeMSK:    releaseTimeMSK(utils, parsedRelease.Time),
 			Artist:     parsedRelease.Artist,
 			AlbumName:  parsedRelease.Album,
 			TitleTrack: parsedRelease.Track,
 			MV:         parsedRelease.YouTubeURL,
+			Type:       parsedRelease.Type,
+			Source:     source.Name(),
 		}
 
 		allReleases = append(allReleases, release)
@@ -780,12 +834,17 @@ func (f *fetcherImpl) ParseMonthlyPage(ctx context.Context, url, month, year str
 	}
 
 	f.logger.Info("Successfully parsed releases",
+		zap.String("source", source.Name()),
 		zap.String("month", month),
 		zap.String("year", year),
 		zap.Int("smart_parsed", len(smartParsedReleases)),
 		zap.Int("llm_parsed", len(llmParsedReleases)),
+		zap.Int("llm_failed_blocks", failedBlocks),
 		zap.Int("total_releases", len(allReleases)))
 
+	if failedBlocks > 0 {
+		return allReleases, &IncompleteParseError{FailedBlocks: failedBlocks, TotalBlocks: len(deduplicatedBlocks)}
+	}
 	return allReleases, nil
 }
 
@@ -0,0 +1,251 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"gemfactory/internal/external/llm"
+	"gemfactory/internal/model"
+
+	"go.uber.org/zap"
+)
+
+// update перезаписывает golden файлы фактическим результатом:
+// go test ./internal/external/scraper -run TestParseMonthlyPageGolden -update
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// fixtureDir каталог с сохраненными страницами расписания
+const fixtureDir = "testdata/monthly"
+
+// fixtureCase описывает параметры разбора страницы (case.json)
+type fixtureCase struct {
+	Month   string   `json:"month"`
+	Year    string   `json:"year"`
+	Artists []string `json:"artists"`
+}
+
+// scriptedResponse заранее заданный ответ LLM для артиста (llm.json)
+type scriptedResponse struct {
+	Artist   string                 `json:"artist"`
+	Releases []llm.MultiReleaseData `json:"releases"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// fakeLLMClient отвечает на запросы по сценарию вместо обращения к API
+type fakeLLMClient struct {
+	mu       sync.Mutex
+	script   map[string]scriptedResponse
+	requests map[string]string
+	used     map[string]bool
+}
+
+var blockArtistPattern = regexp.MustCompile(`<artist>([^<]*)</artist>`)
+
+func newFakeLLMClient(script []scriptedResponse) *fakeLLMClient {
+	client := &fakeLLMClient{
+		script:   make(map[string]scriptedResponse),
+		requests: make(map[string]string),
+		used:     make(map[string]bool),
+	}
+	for _, response := range script {
+		client.script[strings.ToLower(response.Artist)] = response
+	}
+	return client
+}
+
+func (c *fakeLLMClient) ParseMultiRelease(ctx context.Context, htmlBlock string, month string) (*llm.MultiReleaseResponse, error) {
+	return c.ParseSingleBlock(ctx, htmlBlock, month)
+}
+
+func (c *fakeLLMClient) ParseSingleBlock(ctx context.Context, htmlBlock string, month string) (*llm.MultiReleaseResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	artist := ""
+	if match := blockArtistPattern.FindStringSubmatch(htmlBlock); len(match) > 1 {
+		artist = strings.TrimSpace(match[1])
+	}
+	key := strings.ToLower(artist)
+	c.requests[key] = htmlBlock
+
+	response, ok := c.script[key]
+	if !ok {
+		return nil, fmt.Errorf("unexpected LLM request for artist %q", artist)
+	}
+	c.used[key] = true
+
+	if response.Error != "" {
+		return nil, fmt.Errorf("%s", response.Error)
+	}
+	return &llm.MultiReleaseResponse{Releases: response.Releases}, nil
+}
+
+func (c *fakeLLMClient) GetMetrics() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+// unused возвращает артистов из сценария, для которых не было запроса
+func (c *fakeLLMClient) unused() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var artists []string
+	for key := range c.script {
+		if !c.used[key] {
+			artists = append(artists, key)
+		}
+	}
+	sort.Strings(artists)
+	return artists
+}
+
+// requestsDump возвращает отправленные в LLM блоки в детерминированном порядке
+func (c *fakeLLMClient) requestsDump() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.requests))
+	for key := range c.requests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var dump strings.Builder
+	for _, key := range keys {
+		dump.WriteString(c.requests[key])
+		dump.WriteString("\n\n")
+	}
+	return dump.String()
+}
+
+func TestParseMonthlyPageGolden(t *testing.T) {
+	entries, err := os.ReadDir(fixtureDir)
+	if err != nil {
+		t.Fatalf("failed to read fixtures: %v", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		t.Run(name, func(t *testing.T) {
+			runGoldenCase(t, filepath.Join(fixtureDir, name))
+		})
+	}
+}
+
+func runGoldenCase(t *testing.T, dir string) {
+	var params fixtureCase
+	readJSON(t, filepath.Join(dir, "case.json"), &params)
+
+	var script []scriptedResponse
+	if _, err := os.Stat(filepath.Join(dir, "llm.json")); err == nil {
+		readJSON(t, filepath.Join(dir, "llm.json"), &script)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "page.html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page)
+	}))
+	defer server.Close()
+
+	artists := make([]model.Artist, 0, len(params.Artists))
+	for i, name := range params.Artists {
+		artists = append(artists, model.Artist{ArtistID: i + 1, Name: name})
+	}
+
+	llmClient := newFakeLLMClient(script)
+	fetcher := NewFetcherWithLLMClient(Config{}, zap.NewNop(), llmClient)
+
+	releases, err := fetcher.ParseMonthlyPage(context.Background(), server.URL+"/schedule/", params.Month, params.Year, model.NewArtistMatcher(artists, nil))
+	if err != nil {
+		t.Fatalf("ParseMonthlyPage failed: %v", err)
+	}
+
+	if unused := llmClient.unused(); len(unused) > 0 {
+		t.Errorf("scripted LLM responses were not requested (block parsed locally?): %v", unused)
+	}
+
+	compareGolden(t, filepath.Join(dir, "expected.json"), marshalReleases(t, normalizeReleases(releases)))
+	compareGolden(t, filepath.Join(dir, "llm_requests.golden"), []byte(llmClient.requestsDump()))
+}
+
+// normalizeReleases убирает недетерминированные поля и сортирует релизы
+func normalizeReleases(releases []Release) []Release {
+	normalized := make([]Release, len(releases))
+	copy(normalized, releases)
+	// Порядок LLM блоков после дедупликации не определен
+	sort.SliceStable(normalized, func(i, j int) bool {
+		a, b := normalized[i], normalized[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Artist != b.Artist {
+			return a.Artist < b.Artist
+		}
+		return a.TitleTrack < b.TitleTrack
+	})
+	return normalized
+}
+
+func marshalReleases(t *testing.T, releases []Release) []byte {
+	t.Helper()
+	if releases == nil {
+		releases = []Release{}
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(releases); err != nil {
+		t.Fatalf("failed to marshal releases: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func compareGolden(t *testing.T, path string, actual []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			t.Fatalf("failed to update golden file %s: %v", path, err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s (run with -update to create): %v", path, err)
+	}
+	if string(expected) != string(actual) {
+		t.Errorf("%s mismatch\n--- expected\n%s\n--- actual\n%s", path, expected, actual)
+	}
+}
+
+func readJSON(t *testing.T, path string, target interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		t.Fatalf("failed to parse %s: %v", path, err)
+	}
+}
@@ -3,8 +3,10 @@ package scraper
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gemfactory/internal/external/llm"
+	"gemfactory/internal/metrics"
 
 	"github.com/gocolly/colly/v2"
 	"go.uber.org/zap"
@@ -16,28 +18,45 @@ type fetcherImpl struct {
 	logger     *zap.Logger
 	httpClient *HTTPClient
 	llmClient  LLMClientInterface
+	sources    *SourceRegistry
 }
 
 // NewFetcher создает новый экземпляр Fetcher
 func NewFetcher(config Config, logger *zap.Logger) Fetcher {
 	httpClient := NewHTTPClient(config.HTTPClientConfig, logger)
-	llmClient := llm.NewClient(llm.Config{
-		BaseURL: config.LLMConfig.BaseURL,
-		APIKey:  config.LLMConfig.APIKey,
-		Timeout: config.LLMConfig.Timeout,
-		Delay:   config.LLMConfig.Delay,
-	}, logger)
+	llmClient := NewLLMClient(config.LLMConfig, logger)
 
 	return &fetcherImpl{
 		config:     config,
 		logger:     logger,
 		httpClient: httpClient,
 		llmClient:  llmClient,
+		sources:    NewDefaultSourceRegistry(),
 	}
 }
 
+// NewLLMClient создает LLM клиент по конфигурации скрейпера
+func NewLLMClient(config LLMConfig, logger *zap.Logger) *llm.Client {
+	return llm.NewClient(llm.Config{
+		Provider:    config.Provider,
+		BaseURL:     config.BaseURL,
+		APIKey:      config.APIKey,
+		Model:       config.Model,
+		Temperature: config.Temperature,
+		TopP:        config.TopP,
+		MaxTokens:   config.MaxTokens,
+		Timeout:     config.Timeout,
+		Delay:       config.Delay,
+	}, logger)
+}
+
 // NewFetcherWithLLMClient создает новый экземпляр Fetcher с внешним LLM клиентом
 func NewFetcherWithLLMClient(config Config, logger *zap.Logger, llmClient LLMClientInterface) Fetcher {
+	return NewFetcherWithSources(config, logger, llmClient, NewDefaultSourceRegistry())
+}
+
+// NewFetcherWithSources создает новый экземпляр Fetcher с внешним LLM клиентом и реестром источников
+func NewFetcherWithSources(config Config, logger *zap.Logger, llmClient LLMClientInterface, sources *SourceRegistry) Fetcher {
 	httpClient := NewHTTPClient(config.HTTPClientConfig, logger)
 
 	return &fetcherImpl{
@@ -45,9 +64,20 @@ func NewFetcherWithLLMClient(config Config, logger *zap.Logger, llmClient LLMCli
 		logger:     logger,
 		httpClient: httpClient,
 		llmClient:  llmClient,
+		sources:    sources,
 	}
 }
 
+// NewDefaultSourceRegistry создает реестр со встроенными источниками релизов
+func NewDefaultSourceRegistry() *SourceRegistry {
+	return NewSourceRegistry(NewKpopOfficialSource())
+}
+
+// Sources возвращает источники релизов по убыванию приоритета
+func (f *fetcherImpl) Sources() []Source {
+	return f.sources.Sources()
+}
+
 // GetLLMMetrics возвращает метрики LLM клиента
 func (f *fetcherImpl) GetLLMMetrics() map[string]interface{} {
 	if f.llmClient == nil {
@@ -60,12 +90,22 @@ func (f *fetcherImpl) GetLLMMetrics() map[string]interface{} {
 
 // FetchMonthlyLinks получает ссылки на страницы с расписанием релизов за указанные месяцы
 func (f *fetcherImpl) FetchMonthlyLinks(ctx context.Context, months []string, year string) ([]string, error) {
+	source := f.sources.Primary()
+	if source == nil {
+		return nil, fmt.Errorf("no release sources registered")
+	}
+
 	links := make([]string, 0, len(months))
 
 	for _, month := range months {
-		url := fmt.Sprintf("https://kpopofficial.com/kpop-comeback-schedule-%s-%s/", month, year)
-		links = append(links, url)
-		f.logger.Info("Generated monthly link", zap.String("url", url))
+		monthLinks, err := source.MonthlyLinks(ctx, month, year)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get links from source %s: %w", source.Name(), err)
+		}
+		for _, url := range monthLinks {
+			links = append(links, url)
+			f.logger.Info("Generated monthly link", zap.String("source", source.Name()), zap.String("url", url))
+		}
 	}
 
 	return links, nil
@@ -92,19 +132,42 @@ func (f *fetcherImpl) newCollector() *colly.Collector {
 
 	// Добавляем middleware для логирования
 	collector.OnRequest(func(r *colly.Request) {
+		r.Ctx.Put(requestStartKey, time.Now())
 		f.logger.Debug("Making request", zap.String("url", r.URL.String()))
 	})
 
 	collector.OnResponse(func(r *colly.Response) {
+		observeScraperRequest(r, metrics.StatusSuccess)
 		f.logger.Debug("Received response",
 			zap.String("url", r.Request.URL.String()),
 			zap.Int("status", r.StatusCode),
 			zap.Int("size", len(r.Body)))
 	})
 
+	collector.OnError(func(r *colly.Response, err error) {
+		observeScraperRequest(r, metrics.StatusError)
+	})
+
 	return collector
 }
 
+// requestStartKey ключ контекста colly с временем начала запроса
+const requestStartKey = "request_start"
+
+// observeScraperRequest учитывает запрос скрейпера в метриках
+func observeScraperRequest(r *colly.Response, status string) {
+	if r == nil || r.Request == nil {
+		return
+	}
+
+	var duration time.Duration
+	if start, ok := r.Ctx.GetAny(requestStartKey).(time.Time); ok {
+		duration = time.Since(start)
+	}
+
+	metrics.ObserveScraperRequest(r.Request.URL.Host, status, duration)
+}
+
 // getMonthNumber возвращает номер месяца по его названию
 func (f *fetcherImpl) getMonthNumber(month string) (string, bool) {
 	months := map[string]string{
@@ -0,0 +1,157 @@
+// Package scraper содержит получение страниц расписания и их снимки.
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+	"go.uber.org/zap"
+)
+
+// ErrNotModified страница не изменилась с предыдущего снимка (ответ 304 на условный запрос)
+var ErrNotModified = errors.New("page not modified")
+
+// PageSnapshot снимок страницы расписания в том виде, в котором ее получил парсер
+type PageSnapshot struct {
+	Source       string    // Имя источника
+	URL          string    // Адрес страницы
+	FetchedAt    time.Time // Время получения
+	ETag         string    // Заголовок ETag ответа
+	LastModified string    // Заголовок Last-Modified ответа
+	ContentHash  string    // SHA-256 тела страницы
+	Body         []byte    // Тело страницы после перекодировки в UTF-8
+}
+
+// ContentHash возвращает SHA-256 тела страницы в hex
+func ContentHash(body []byte) string {
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:])
+}
+
+// FetchSourcePage загружает страницу расписания источника. Если передан предыдущий снимок,
+// запрос отправляется с If-None-Match / If-Modified-Since и при ответе 304 возвращается ErrNotModified
+func (f *fetcherImpl) FetchSourcePage(ctx context.Context, source Source, url string, previous *PageSnapshot) (*PageSnapshot, error) {
+	var snapshot *PageSnapshot
+	var notModified bool
+
+	retryConfig := RetryConfig{
+		MaxRetries:        f.config.RetryConfig.MaxRetries,
+		InitialDelay:      f.config.RetryConfig.InitialDelay,
+		MaxDelay:          f.config.RetryConfig.MaxDelay,
+		BackoffMultiplier: f.config.RetryConfig.BackoffMultiplier,
+	}
+
+	err := WithRetry(ctx, f.logger, retryConfig, func() error {
+		// Новый коллектор на каждую попытку: colly не посещает один адрес дважды
+		collector := f.newCollector()
+
+		collector.OnRequest(func(r *colly.Request) {
+			if previous == nil {
+				return
+			}
+			if previous.ETag != "" {
+				r.Headers.Set("If-None-Match", previous.ETag)
+			}
+			if previous.LastModified != "" {
+				r.Headers.Set("If-Modified-Since", previous.LastModified)
+			}
+		})
+
+		collector.OnResponse(func(r *colly.Response) {
+			snapshot = &PageSnapshot{
+				Source:       source.Name(),
+				URL:          url,
+				FetchedAt:    time.Now(),
+				ETag:         r.Headers.Get("ETag"),
+				LastModified: r.Headers.Get("Last-Modified"),
+				ContentHash:  ContentHash(r.Body),
+				Body:         r.Body,
+			}
+		})
+
+		collector.OnError(func(r *colly.Response, err error) {
+			if r != nil && r.StatusCode == http.StatusNotModified {
+				notModified = true
+				return
+			}
+			f.logger.Error("Failed to scrape page", zap.String("url", r.Request.URL.String()), zap.Error(err))
+		})
+
+		if err := collector.Visit(url); err != nil && !notModified {
+			return err
+		}
+		collector.Wait()
+		return nil
+	})
+
+	if err != nil {
+		if ctx.Err() != nil {
+			f.logger.Debug("Page fetch cancelled due to context cancellation", zap.Error(ctx.Err()))
+			return nil, ctx.Err()
+		}
+		f.logger.Error("Failed to visit page after retries", zap.String("url", url), zap.Error(err))
+		return nil, fmt.Errorf("failed to visit page after retries: %w", err)
+	}
+
+	if notModified {
+		f.logger.Info("Page not modified since previous snapshot",
+			zap.String("source", source.Name()),
+			zap.String("url", url))
+		return nil, ErrNotModified
+	}
+
+	if snapshot == nil {
+		return nil, fmt.Errorf("empty response for page %s", url)
+	}
+
+	f.logger.Info("Fetched schedule page",
+		zap.String("source", source.Name()),
+		zap.String("url", url),
+		zap.Int("size", len(snapshot.Body)),
+		zap.String("etag", snapshot.ETag),
+		zap.String("last_modified", snapshot.LastModified))
+
+	return snapshot, nil
+}
+
+// collectSnapshotBlocks разбирает строки расписания из снимка страницы без обращения к сети
+func (f *fetcherImpl) collectSnapshotBlocks(ctx context.Context, source Source, snapshot *PageSnapshot, year string, artists ArtistFilter) ([]ArtistBlock, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(snapshot.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page %s: %w", snapshot.URL, err)
+	}
+
+	var artistBlocks []ArtistBlock
+	var mu sync.Mutex
+	rowCount := 0
+
+	doc.Find(source.RowSelector()).EachWithBreak(func(_ int, row *goquery.Selection) bool {
+		if ctx.Err() != nil {
+			f.logger.Debug("HTML processing cancelled due to context cancellation",
+				zap.String("url", snapshot.URL),
+				zap.Error(ctx.Err()))
+			return false
+		}
+
+		rowCount++
+		// Получаем HTML всей строки <tr>
+		rowHTML, _ := row.Html()
+		f.collectArtistBlock(source, snapshot.URL, year, rowHTML, artists, &artistBlocks, &mu, rowCount)
+		return true
+	})
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return artistBlocks, nil
+}
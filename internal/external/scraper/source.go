@@ -0,0 +1,190 @@
+package scraper
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Source определяет источник расписания релизов.
+// Источник отвечает за поиск страниц и разбор строк своей разметки,
+// дальнейшая обработка (простой парсинг и LLM) общая для всех источников.
+type Source interface {
+	// Name возвращает уникальное имя источника
+	Name() string
+	// Priority возвращает приоритет источника при разрешении конфликтов (больше - важнее)
+	Priority() int
+	// MonthlyLinks возвращает ссылки на страницы расписания за месяц
+	MonthlyLinks(ctx context.Context, month, year string) ([]string, error)
+	// RowSelector возвращает CSS селектор строк расписания на странице
+	RowSelector() string
+	// MatchArtist возвращает артиста из строки, если он проходит фильтр
+	MatchArtist(rowHTML string, artists ArtistFilter) (string, bool)
+	// NormalizeRow преобразует строку в формат <event><date/><artist/><need_unparse/></event>
+	NormalizeRow(rowHTML string) string
+}
+
+// SourceResult содержит релизы, полученные из одного источника
+type SourceResult struct {
+	Source   Source
+	URL      string
+	Releases []Release
+}
+
+// SourceRegistry хранит зарегистрированные источники релизов
+type SourceRegistry struct {
+	mu      sync.RWMutex
+	sources map[string]Source
+}
+
+// NewSourceRegistry создает реестр источников
+func NewSourceRegistry(sources ...Source) *SourceRegistry {
+	registry := &SourceRegistry{
+		sources: make(map[string]Source),
+	}
+	for _, source := range sources {
+		registry.Register(source)
+	}
+	return registry
+}
+
+// Register регистрирует источник (источник с тем же именем заменяется)
+func (r *SourceRegistry) Register(source Source) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources[source.Name()] = source
+}
+
+// Get возвращает источник по имени
+func (r *SourceRegistry) Get(name string) (Source, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	source, ok := r.sources[name]
+	return source, ok
+}
+
+// Sources возвращает источники, отсортированные по убыванию приоритета
+func (r *SourceRegistry) Sources() []Source {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sources := make([]Source, 0, len(r.sources))
+	for _, source := range r.sources {
+		sources = append(sources, source)
+	}
+
+	sort.SliceStable(sources, func(i, j int) bool {
+		if sources[i].Priority() != sources[j].Priority() {
+			return sources[i].Priority() > sources[j].Priority()
+		}
+		return sources[i].Name() < sources[j].Name()
+	})
+
+	return sources
+}
+
+// Primary возвращает источник с наивысшим приоритетом
+func (r *SourceRegistry) Primary() Source {
+	sources := r.Sources()
+	if len(sources) == 0 {
+		return nil
+	}
+	return sources[0]
+}
+
+// MergeReleases объединяет релизы из нескольких источников.
+// Релизы одного артиста на одну дату считаются одним камбэком: их берем из источника
+// с наивысшим приоритетом, а источники ниже только дополняют пустые поля.
+func MergeReleases(results []SourceResult) []Release {
+	sorted := make([]SourceResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Source.Priority() > sorted[j].Source.Priority()
+	})
+
+	var order []string
+	merged := make(map[string][]Release)
+
+	for _, result := range sorted {
+		incoming := make(map[string][]Release)
+		var incomingOrder []string
+		for _, release := range result.Releases {
+			if release.Source == "" {
+				release.Source = result.Source.Name()
+			}
+			key := releaseMergeKey(release)
+			if _, ok := incoming[key]; !ok {
+				incomingOrder = append(incomingOrder, key)
+			}
+			incoming[key] = append(incoming[key], release)
+		}
+
+		for _, key := range incomingOrder {
+			existing, ok := merged[key]
+			if !ok {
+				merged[key] = incoming[key]
+				order = append(order, key)
+				continue
+			}
+			fillMissingFields(existing, incoming[key])
+		}
+	}
+
+	releases := make([]Release, 0, len(order))
+	for _, key := range order {
+		releases = append(releases, merged[key]...)
+	}
+
+	return releases
+}
+
+// releaseMergeKey возвращает ключ камбэка: артист и дата
+func releaseMergeKey(release Release) string {
+	return strings.ToLower(strings.TrimSpace(release.Artist)) + "|" + release.Date
+}
+
+// fillMissingFields дополняет пустые поля релизов данными из источника с меньшим приоритетом
+func fillMissingFields(winners, candidates []Release) {
+	for i := range winners {
+		candidate, ok := findMergeCandidate(winners[i], candidates, len(winners) == 1)
+		if !ok {
+			continue
+		}
+		if isEmptyField(winners[i].TitleTrack) {
+			winners[i].TitleTrack = candidate.TitleTrack
+		}
+		if isEmptyField(winners[i].AlbumName) {
+			winners[i].AlbumName = candidate.AlbumName
+		}
+		if winners[i].Type == "" {
+			winners[i].Type = candidate.Type
+		}
+		if isEmptyField(winners[i].MV) {
+			winners[i].MV = candidate.MV
+		}
+		if isEmptyField(winners[i].TimeMSK) {
+			winners[i].TimeMSK = candidate.TimeMSK
+		}
+	}
+}
+
+// findMergeCandidate ищет релиз с тем же треком, либо единственный релиз при однозначном соответствии
+func findMergeCandidate(winner Release, candidates []Release, single bool) (Release, bool) {
+	track := strings.ToLower(strings.TrimSpace(winner.TitleTrack))
+	for _, candidate := range candidates {
+		if track != "" && strings.ToLower(strings.TrimSpace(candidate.TitleTrack)) == track {
+			return candidate, true
+		}
+	}
+	if single && len(candidates) == 1 {
+		return candidates[0], true
+	}
+	return Release{}, false
+}
+
+// isEmptyField проверяет, что значение поля отсутствует
+func isEmptyField(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == "" || value == "N/A"
+}
@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// kpopOfficialArtistPattern находит артистов в разметке kpopofficial.com
+var kpopOfficialArtistPattern = regexp.MustCompile(`<strong><mark[^>]*class="[^"]*has-red-color[^"]*"[^>]*>([^<]+)</mark></strong>`)
+
+// KpopOfficialSource реализует источник kpopofficial.com
+type KpopOfficialSource struct {
+	linkTemplate string
+	priority     int
+}
+
+// NewKpopOfficialSource создает источник kpopofficial.com
+func NewKpopOfficialSource() *KpopOfficialSource {
+	return &KpopOfficialSource{
+		linkTemplate: "https://kpopofficial.com/kpop-comeback-schedule-%s-%s/",
+		priority:     100,
+	}
+}
+
+// Name возвращает имя источника
+func (s *KpopOfficialSource) Name() string {
+	return "kpopofficial"
+}
+
+// Priority возвращает приоритет источника
+func (s *KpopOfficialSource) Priority() int {
+	return s.priority
+}
+
+// MonthlyLinks возвращает ссылку на страницу расписания за месяц
+func (s *KpopOfficialSource) MonthlyLinks(ctx context.Context, month, year string) ([]string, error) {
+	return []string{fmt.Sprintf(s.linkTemplate, strings.ToLower(month), year)}, nil
+}
+
+// RowSelector возвращает селектор строк таблицы расписания
+func (s *KpopOfficialSource) RowSelector() string {
+	return "table tbody tr"
+}
+
+// MatchArtist ищет артистов в <strong><mark class="has-red-color"> и сверяет их с фильтром
+func (s *KpopOfficialSource) MatchArtist(rowHTML string, artists ArtistFilter) (string, bool) {
+	matches := kpopOfficialArtistPattern.FindAllStringSubmatch(rowHTML, -1)
+
+	firstArtist := ""
+	for _, match := range matches {
+		if len(match) < 2 {
+			continue
+		}
+
+		// Декодируем HTML-сущности: &amp;TEAM -> &TEAM
+		artist := html.UnescapeString(strings.TrimSpace(match[1]))
+		if firstArtist == "" {
+			firstArtist = artist
+		}
+
+		if artists.Allow(artist) {
+			return artist, true
+		}
+	}
+
+	return firstArtist, false
+}
+
+// NormalizeRow преобразует строку таблицы в формат <event>
+func (s *KpopOfficialSource) NormalizeRow(rowHTML string) string {
+	return cleanHTMLBlock(rowHTML)
+}
+
+var _ Source = (*KpopOfficialSource)(nil)
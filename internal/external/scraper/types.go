@@ -10,10 +10,36 @@ import (
 // Fetcher определяет интерфейс для получения данных о релизах
 type Fetcher interface {
 	FetchMonthlyLinks(ctx context.Context, months []string, year string) ([]string, error)
-	ParseMonthlyPage(ctx context.Context, url, month, year string, artists map[string]bool) ([]Release, error)
+	ParseMonthlyPage(ctx context.Context, url, month, year string, artists ArtistFilter) ([]Release, error)
+	ParseSourcePage(ctx context.Context, source Source, url, month, year string, artists ArtistFilter) ([]Release, error)
+	FetchSourcePage(ctx context.Context, source Source, url string, previous *PageSnapshot) (*PageSnapshot, error)
+	ParseSnapshot(ctx context.Context, source Source, snapshot *PageSnapshot, month, year string, artists ArtistFilter) ([]Release, error)
+	Sources() []Source
 	GetLLMMetrics() map[string]interface{}
 }
 
+// ArtistFilter определяет, какие артисты из строк расписания отправляются в разбор
+type ArtistFilter interface {
+	// Allow сообщает, что артист из строки есть в списке для фильтрации
+	Allow(artist string) bool
+}
+
+// UnmatchedArtistObserver дополнительно реализуется фильтром, которому нужны
+// строки расписания с артистами не из списка
+type UnmatchedArtistObserver interface {
+	// ObserveUnmatched получает строку, артист которой не прошел фильтр
+	ObserveUnmatched(row UnmatchedArtist)
+}
+
+// UnmatchedArtist строка расписания с артистом, которого нет в списке
+type UnmatchedArtist struct {
+	Artist  string // Имя артиста из источника
+	Date    string // Дата релиза в формате DD.MM.YY, пусто - не удалось определить
+	Release string // Альбом или титульный трек из строки
+	Source  string // Имя источника
+	URL     string // Страница расписания
+}
+
 // Config представляет конфигурацию скрейпера
 type Config struct {
 	HTTPClientConfig HTTPClientConfig
@@ -24,10 +50,15 @@ type Config struct {
 
 // LLMConfig представляет конфигурацию LLM клиента
 type LLMConfig struct {
-	BaseURL string
-	APIKey  string
-	Timeout time.Duration
-	Delay   time.Duration
+	Provider    string
+	BaseURL     string
+	APIKey      string
+	Model       string
+	Temperature float64
+	TopP        float64
+	MaxTokens   int
+	Timeout     time.Duration
+	Delay       time.Duration
 }
 
 // HTTPClientConfig представляет конфигурацию HTTP клиента
@@ -56,6 +87,8 @@ type Release struct {
 	AlbumName  string
 	TitleTrack string
 	MV         string
+	Type       model.ReleaseType // Тип релиза, пусто - не указан
+	Source     string            // Имя источника, из которого получен релиз
 }
 
 // ToModelRelease конвертирует scraper.Release в model.Release
@@ -68,6 +101,7 @@ func (r *Release) ToModelRelease() *model.Release {
 		Date:       r.Date,
 		TimeMSK:    r.TimeMSK,
 		MV:         r.MV,
+		Type:       r.Type,
 	}
 }
 
@@ -15,8 +15,12 @@ type BotAPI interface {
 	SendMessageWithReply(chatID int64, text string, replyToMessageID int) error
 	SendMessageWithReplyAndMarkup(chatID int64, text string, replyToMessageID int, markup any) error
 	EditMessageReplyMarkup(chatID int64, messageID int, markup any) error
+	EditMessageTextWithMarkup(chatID int64, messageID int, text string, markup any) error
 	SetBotCommands(commands []tgbotapi.BotCommand) error
 	GetFile(fileID string) (tgbotapi.File, error)
+	GetChatMember(chatID, userID int64) (tgbotapi.ChatMember, error)
+	AnswerInlineQuery(config tgbotapi.InlineConfig) error
+	BotUsername() string
 }
 
 // TelegramBotAPI wraps tgbotapi.BotAPI to implement the BotAPI interface
@@ -43,7 +47,7 @@ func (t *TelegramBotAPI) SendMessage(chatID int64, text string) error {
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "HTML"
 	msg.DisableWebPagePreview = true
-	_, err := t.api.Send(msg)
+	err := t.sendSplit(msg)
 	if err != nil {
 		t.logger.Error("Failed to send message", zap.Int64("chat_id", chatID), zap.Error(err))
 	}
@@ -56,7 +60,7 @@ func (t *TelegramBotAPI) SendMessageWithMarkup(chatID int64, text string, markup
 	msg.ReplyMarkup = markup
 	msg.ParseMode = "HTML"
 	msg.DisableWebPagePreview = true
-	_, err := t.api.Send(msg)
+	err := t.sendSplit(msg)
 	if err != nil {
 		t.logger.Error("Failed to send message with markup", zap.Int64("chat_id", chatID), zap.Error(err))
 	}
@@ -69,7 +73,7 @@ func (t *TelegramBotAPI) SendMessageWithReply(chatID int64, text string, replyTo
 	msg.ReplyToMessageID = replyToMessageID
 	msg.ParseMode = "HTML"
 	msg.DisableWebPagePreview = true
-	_, err := t.api.Send(msg)
+	err := t.sendSplit(msg)
 	if err != nil {
 		t.logger.Error("Failed to send message with reply", zap.Int64("chat_id", chatID), zap.Int("reply_to_message_id", replyToMessageID), zap.Error(err))
 	}
@@ -83,7 +87,7 @@ func (t *TelegramBotAPI) SendMessageWithReplyAndMarkup(chatID int64, text string
 	msg.ReplyMarkup = markup
 	msg.ParseMode = "HTML"
 	msg.DisableWebPagePreview = true
-	_, err := t.api.Send(msg)
+	err := t.sendSplit(msg)
 	if err != nil {
 		t.logger.Error("Failed to send message with reply and markup", zap.Int64("chat_id", chatID), zap.Int("reply_to_message_id", replyToMessageID), zap.Error(err))
 	}
@@ -104,6 +108,60 @@ func (t *TelegramBotAPI) EditMessageReplyMarkup(chatID int64, messageID int, mar
 	return err
 }
 
+// EditMessageTextWithMarkup edits the text and the inline markup of a message
+func (t *TelegramBotAPI) EditMessageTextWithMarkup(chatID int64, messageID int, text string, markup any) error {
+	inlineMarkup, ok := markup.(tgbotapi.InlineKeyboardMarkup)
+	if !ok {
+		return fmt.Errorf("markup must be of type tgbotapi.InlineKeyboardMarkup")
+	}
+	// Отредактировать сообщение можно только одним текстом, лишнее отбрасывается
+	if parts := SplitMessage(text, MaxMessageLength); len(parts) > 1 {
+		t.logger.Warn("Edited message text is too long, truncating",
+			zap.Int64("chat_id", chatID),
+			zap.Int("message_id", messageID),
+			zap.Int("parts", len(parts)))
+		text = parts[0]
+	}
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, inlineMarkup)
+	edit.ParseMode = "HTML"
+	edit.DisableWebPagePreview = true
+	_, err := t.api.Send(edit)
+	if err != nil {
+		t.logger.Error("Failed to edit message text", zap.Int64("chat_id", chatID), zap.Int("message_id", messageID), zap.Error(err))
+	}
+	return err
+}
+
+// sendSplit отправляет сообщение, разбивая длинный текст на несколько сообщений.
+// Ответ привязывается к первой части, клавиатура - к последней
+func (t *TelegramBotAPI) sendSplit(msg tgbotapi.MessageConfig) error {
+	parts := SplitMessage(msg.Text, MaxMessageLength)
+	if len(parts) == 1 {
+		_, err := t.api.Send(msg)
+		return err
+	}
+
+	t.logger.Debug("Splitting long message",
+		zap.Int64("chat_id", msg.ChatID),
+		zap.Int("length", MessageLength(msg.Text)),
+		zap.Int("parts", len(parts)))
+
+	for i, part := range parts {
+		chunk := msg
+		chunk.Text = part
+		if i > 0 {
+			chunk.ReplyToMessageID = 0
+		}
+		if i < len(parts)-1 {
+			chunk.ReplyMarkup = nil
+		}
+		if _, err := t.api.Send(chunk); err != nil {
+			return fmt.Errorf("failed to send message part %d/%d: %w", i+1, len(parts), err)
+		}
+	}
+	return nil
+}
+
 // SetBotCommands sets the bot's command menu
 func (t *TelegramBotAPI) SetBotCommands(commands []tgbotapi.BotCommand) error {
 	_, err := t.api.Request(tgbotapi.NewSetMyCommands(commands...))
@@ -119,4 +177,33 @@ func (t *TelegramBotAPI) GetFile(fileID string) (tgbotapi.File, error) {
 	return t.api.GetFile(file)
 }
 
+// GetChatMember gets information about a member of a chat
+func (t *TelegramBotAPI) GetChatMember(chatID, userID int64) (tgbotapi.ChatMember, error) {
+	config := tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{
+			ChatID: chatID,
+			UserID: userID,
+		},
+	}
+	member, err := t.api.GetChatMember(config)
+	if err != nil {
+		t.logger.Error("Failed to get chat member", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID), zap.Error(err))
+	}
+	return member, err
+}
+
+// AnswerInlineQuery sends results for an inline query
+func (t *TelegramBotAPI) AnswerInlineQuery(config tgbotapi.InlineConfig) error {
+	_, err := t.api.Request(config)
+	if err != nil {
+		t.logger.Error("Failed to answer inline query", zap.String("inline_query_id", config.InlineQueryID), zap.Error(err))
+	}
+	return err
+}
+
+// BotUsername returns the bot's username without @
+func (t *TelegramBotAPI) BotUsername() string {
+	return t.api.Self.UserName
+}
+
 var _ BotAPI = (*TelegramBotAPI)(nil)
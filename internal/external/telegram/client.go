@@ -4,6 +4,7 @@ package telegram
 import (
 	"context"
 	"fmt"
+	"gemfactory/internal/i18n"
 	"strings"
 	"time"
 
@@ -14,7 +15,7 @@ import (
 // RouterInterface определяет интерфейс для роутера
 type RouterInterface interface {
 	HandleUpdate(update tgbotapi.Update)
-	RegisterBotCommands() []tgbotapi.BotCommand
+	RegisterBotCommands(lang i18n.Lang) []tgbotapi.BotCommand
 }
 
 // Client представляет клиент Telegram Bot API
@@ -24,6 +25,9 @@ type Client struct {
 	router RouterInterface
 	logger *zap.Logger
 	config ConfigInterface
+
+	// webhook включает прием обновлений через webhook (nil - long polling)
+	webhook *WebhookConfig
 }
 
 // NewClient создает новый клиент Telegram
@@ -54,25 +58,48 @@ func (c *Client) Start(ctx context.Context, router RouterInterface) error {
 	// Инициализация бота
 	c.logger.Info("Bot started", zap.String("username", c.bot.Self.UserName))
 
-	// Удаляем webhook если есть
-	_, err := c.bot.Request(tgbotapi.DeleteWebhookConfig{DropPendingUpdates: true})
+	// Настраиваем команды бота: язык по умолчанию для всех клиентов и отдельные описания для остальных языков
+	commands := c.router.RegisterBotCommands(i18n.Default)
+	_, err := c.bot.Request(tgbotapi.NewSetMyCommands(commands...))
 	if err != nil {
-		c.logger.Error("Failed to delete webhook", zap.Error(err))
-		return fmt.Errorf("failed to delete webhook: %w", err)
+		c.logger.Error("Failed to set bot commands", zap.Error(err))
+		return fmt.Errorf("failed to set bot commands: %w", err)
+	}
+	for _, lang := range i18n.Supported() {
+		if lang == i18n.Default {
+			continue
+		}
+		config := tgbotapi.NewSetMyCommandsWithScopeAndLanguage(tgbotapi.NewBotCommandScopeDefault(), lang.String(), c.router.RegisterBotCommands(lang)...)
+		if _, err := c.bot.Request(config); err != nil {
+			c.logger.Error("Failed to set bot commands", zap.String("language", lang.String()), zap.Error(err))
+			return fmt.Errorf("failed to set bot commands for %s: %w", lang, err)
+		}
+	}
+
+	if c.webhook != nil {
+		err := c.runWebhook(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c.logger.Error("Webhook mode failed, falling back to long polling", zap.Error(err))
 	}
 
-	// Настраиваем команды бота
-	commands := c.router.RegisterBotCommands()
-	_, err = c.bot.Request(tgbotapi.NewSetMyCommands(commands...))
+	return c.runPolling(ctx)
+}
+
+// runPolling получает обновления через long polling
+func (c *Client) runPolling(ctx context.Context) error {
+	// Удаляем webhook если есть
+	_, err := c.bot.Request(tgbotapi.DeleteWebhookConfig{DropPendingUpdates: c.webhook == nil})
 	if err != nil {
-		c.logger.Error("Failed to set bot commands", zap.Error(err))
-		return fmt.Errorf("failed to set bot commands: %w", err)
+		c.logger.Error("Failed to delete webhook", zap.Error(err))
+		return fmt.Errorf("failed to delete webhook: %w", err)
 	}
 
 	// Настраиваем long polling
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
-	u.AllowedUpdates = []string{"message", "callback_query"}
+	u.AllowedUpdates = allowedUpdates
 
 	c.logger.Info("Starting to fetch updates")
 	updatesChan := c.bot.GetUpdatesChan(u)
@@ -146,9 +173,14 @@ func (c *Client) processUpdate(update tgbotapi.Update) {
 			zap.String("user", getUserIdentifier(update.CallbackQuery.From)))
 		c.logger.Debug("Callback details",
 			zap.Int("update_id", update.UpdateID))
+	} else if update.InlineQuery != nil {
+		c.logger.Debug("Received inline query",
+			zap.String("query", update.InlineQuery.Query),
+			zap.String("user", getUserIdentifier(update.InlineQuery.From)),
+			zap.Int("update_id", update.UpdateID))
 	}
 
-	if update.Message == nil && update.CallbackQuery == nil {
+	if update.Message == nil && update.CallbackQuery == nil && update.InlineQuery == nil {
 		return
 	}
 
@@ -261,6 +293,9 @@ func getUserID(update tgbotapi.Update) int64 {
 	if update.CallbackQuery != nil {
 		return update.CallbackQuery.From.ID
 	}
+	if update.InlineQuery != nil {
+		return update.InlineQuery.From.ID
+	}
 	return 0
 }
 
@@ -272,6 +307,9 @@ func extractCommand(update tgbotapi.Update) string {
 	if update.CallbackQuery != nil {
 		return "callback"
 	}
+	if update.InlineQuery != nil {
+		return "inline"
+	}
 	return ""
 }
 
@@ -286,6 +324,9 @@ func getUpdateType(update tgbotapi.Update) string {
 	if update.CallbackQuery != nil {
 		return "callback"
 	}
+	if update.InlineQuery != nil {
+		return "inline_query"
+	}
 	return "unknown"
 }
 
@@ -0,0 +1,172 @@
+package telegram
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf16"
+)
+
+// MaxMessageLength максимальная длина сообщения Telegram в UTF-16 символах
+const MaxMessageLength = 4096
+
+// maxWordLength длина, после которой слово без пробелов разбивается на части
+const maxWordLength = 256
+
+// htmlTagPattern находит открывающие и закрывающие HTML теги
+var htmlTagPattern = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9-]*)[^>]*>`)
+
+// htmlTokenPattern разбивает строку на теги, HTML сущности и слова с пробелами после них
+var htmlTokenPattern = regexp.MustCompile(`<[^>]*>|&[#a-zA-Z0-9]+;|[^<&\s]+\s*|\s+|[<&]`)
+
+// openTag открытый HTML тег, который нужно закрыть в конце части и открыть в начале следующей
+type openTag struct {
+	name string
+	raw  string
+}
+
+// MessageLength возвращает длину текста в UTF-16 символах, как ее считает Telegram.
+// Теги учитываются в длине, поэтому оценка консервативна
+func MessageLength(text string) int {
+	length := 0
+	for _, r := range text {
+		length += utf16.RuneLen(r)
+	}
+	return length
+}
+
+// SplitMessage разбивает HTML текст на части не длиннее limit по границам строк.
+// Теги, открытые на границе части, закрываются в ее конце и открываются заново в следующей
+func SplitMessage(text string, limit int) []string {
+	if limit <= 0 || MessageLength(text) <= limit {
+		return []string{text}
+	}
+
+	splitter := &messageSplitter{limit: limit}
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line == "" {
+			continue
+		}
+		if splitter.fits(line) {
+			splitter.add(line)
+			continue
+		}
+
+		splitter.flush()
+		if splitter.fits(line) {
+			splitter.add(line)
+			continue
+		}
+
+		// Строка не помещается целиком: переносим по словам, не разрывая теги и сущности
+		for _, token := range splitLongWords(htmlTokenPattern.FindAllString(line, -1)) {
+			if !splitter.fits(token) {
+				splitter.flush()
+			}
+			splitter.add(token)
+		}
+	}
+	splitter.flush()
+
+	if len(splitter.chunks) == 0 {
+		return []string{text}
+	}
+	return splitter.chunks
+}
+
+// messageSplitter собирает части сообщения
+type messageSplitter struct {
+	limit      int
+	chunks     []string
+	current    strings.Builder
+	length     int
+	hasContent bool
+	open       []openTag
+}
+
+// fits проверяет, что фрагмент помещается в текущую часть вместе с закрывающими тегами
+func (s *messageSplitter) fits(piece string) bool {
+	return s.length+MessageLength(piece)+closingLength(applyTags(s.open, piece)) <= s.limit
+}
+
+// add добавляет фрагмент в текущую часть
+func (s *messageSplitter) add(piece string) {
+	s.current.WriteString(piece)
+	s.length += MessageLength(piece)
+	s.open = applyTags(s.open, piece)
+	s.hasContent = true
+}
+
+// flush завершает текущую часть и начинает следующую с незакрытыми тегами
+func (s *messageSplitter) flush() {
+	if !s.hasContent {
+		return
+	}
+
+	chunk := strings.TrimRight(s.current.String(), "\n")
+	for i := len(s.open) - 1; i >= 0; i-- {
+		chunk += "</" + s.open[i].name + ">"
+	}
+	s.chunks = append(s.chunks, chunk)
+
+	s.current.Reset()
+	s.length = 0
+	s.hasContent = false
+	for _, tag := range s.open {
+		s.current.WriteString(tag.raw)
+		s.length += MessageLength(tag.raw)
+	}
+}
+
+// applyTags возвращает стек открытых тегов после фрагмента
+func applyTags(open []openTag, piece string) []openTag {
+	matches := htmlTagPattern.FindAllStringSubmatch(piece, -1)
+	if len(matches) == 0 {
+		return open
+	}
+
+	stack := make([]openTag, len(open), len(open)+len(matches))
+	copy(stack, open)
+	for _, match := range matches {
+		name := strings.ToLower(match[2])
+		if match[1] == "" {
+			if !strings.HasSuffix(match[0], "/>") {
+				stack = append(stack, openTag{name: name, raw: match[0]})
+			}
+			continue
+		}
+
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].name == name {
+				stack = stack[:i]
+				break
+			}
+		}
+	}
+	return stack
+}
+
+// closingLength возвращает длину закрывающих тегов для стека
+func closingLength(open []openTag) int {
+	length := 0
+	for _, tag := range open {
+		length += len(tag.name) + 3
+	}
+	return length
+}
+
+// splitLongWords разбивает слишком длинные слова на части по символам
+func splitLongWords(tokens []string) []string {
+	result := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		runes := []rune(token)
+		if len(runes) <= maxWordLength || strings.HasPrefix(token, "<") {
+			result = append(result, token)
+			continue
+		}
+		for start := 0; start < len(runes); start += maxWordLength {
+			end := min(start+maxWordLength, len(runes))
+			result = append(result, string(runes[start:end]))
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package telegram
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "fits in one message",
+			text:  "<b>short</b>\nline",
+			limit: 100,
+			want:  []string{"<b>short</b>\nline"},
+		},
+		{
+			name:  "boundary inside bold",
+			text:  "<b>first line\nsecond line</b>\nthird",
+			limit: 20,
+			want:  []string{"<b>first line</b>", "<b>second line</b>", "third"},
+		},
+		{
+			name:  "boundary inside link",
+			text:  `<a href="https://youtu.be/x?a=1&amp;b=2">watch the new video now</a>` + "\ntail",
+			limit: 60,
+			want: []string{
+				`<a href="https://youtu.be/x?a=1&amp;b=2">watch the new </a>`,
+				`<a href="https://youtu.be/x?a=1&amp;b=2">video now</a>` + "\ntail",
+			},
+		},
+		{
+			name:  "single line longer than limit",
+			text:  strings.Repeat("word ", 6),
+			limit: 12,
+			want:  []string{"word word ", "word word ", "word word "},
+		},
+		{
+			name:  "cyrillic counted in characters, not bytes",
+			text:  "Привет мир\nЕще строка\nТретья",
+			limit: 12,
+			want:  []string{"Привет мир", "Еще строка", "Третья"},
+		},
+		{
+			name:  "emoji counted as two UTF-16 units",
+			text:  "🎵🎵🎵\n🎵🎵🎵",
+			limit: 7,
+			want:  []string{"🎵🎵🎵", "🎵🎵🎵"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitMessage(tt.text, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("SplitMessage() = %q, want %q", got, tt.want)
+			}
+			for _, chunk := range got {
+				if MessageLength(chunk) > tt.limit {
+					t.Errorf("chunk %q is %d characters long, limit %d", chunk, MessageLength(chunk), tt.limit)
+				}
+				if open := applyTags(nil, chunk); len(open) != 0 {
+					t.Errorf("chunk %q leaves tags open: %v", chunk, open)
+				}
+			}
+		})
+	}
+}
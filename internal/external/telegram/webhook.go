@@ -0,0 +1,200 @@
+package telegram
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Режимы получения обновлений
+const (
+	UpdateModePolling = "polling"
+	UpdateModeWebhook = "webhook"
+)
+
+// secretTokenHeader заголовок, в котором Telegram передает секретный токен webhook
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// webhookQueueSize размер очереди обновлений, принятых через webhook
+const webhookQueueSize = 100
+
+// allowedUpdates типы обновлений, которые обрабатывает бот
+var allowedUpdates = []string{"message", "callback_query", "inline_query"}
+
+// secretTokenPattern допустимый формат секретного токена (ограничение Telegram)
+var secretTokenPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// WebhookConfig представляет настройки приема обновлений через webhook
+type WebhookConfig struct {
+	URL            string
+	ListenAddr     string
+	SecretToken    string
+	MaxConnections int
+}
+
+// SetWebhookConfig включает режим webhook вместо long polling
+func (c *Client) SetWebhookConfig(config WebhookConfig) {
+	c.webhook = &config
+}
+
+// runWebhook регистрирует webhook и обрабатывает обновления до отмены контекста.
+// Возвращает ошибку, если webhook не удалось запустить или сервер остановился
+func (c *Client) runWebhook(ctx context.Context) error {
+	webhookURL, err := url.Parse(c.webhook.URL)
+	if err != nil || webhookURL.Scheme != "https" || webhookURL.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q: HTTPS URL is required", c.webhook.URL)
+	}
+
+	secretToken, err := c.resolveSecretToken()
+	if err != nil {
+		return err
+	}
+
+	path := webhookURL.Path
+	if path == "" {
+		path = "/"
+	}
+
+	// Сначала занимаем порт, чтобы не регистрировать webhook, который некому принимать
+	listener, err := net.Listen("tcp", c.webhook.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", c.webhook.ListenAddr, err)
+	}
+
+	updates := make(chan tgbotapi.Update, webhookQueueSize)
+	mux := http.NewServeMux()
+	mux.Handle(path, c.webhookHandler(secretToken, updates))
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	if err := c.setWebhook(webhookURL.String(), secretToken); err != nil {
+		c.shutdownWebhookServer(server)
+		return err
+	}
+
+	c.logger.Info("Webhook mode started",
+		zap.String("url", webhookURL.Redacted()),
+		zap.String("listen_addr", c.webhook.ListenAddr),
+		zap.String("path", path))
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("Update loop cancelled by context")
+			// Webhook не удаляем: Telegram накопит обновления до следующего запуска
+			c.shutdownWebhookServer(server)
+			return ctx.Err()
+		case err, ok := <-serverErr:
+			if !ok {
+				return fmt.Errorf("webhook server stopped")
+			}
+			return fmt.Errorf("webhook server failed: %w", err)
+		case update := <-updates:
+			c.processUpdate(update)
+		}
+	}
+}
+
+// webhookHandler принимает обновления от Telegram и передает их в очередь обработки
+func (c *Client) webhookHandler(secretToken string, updates chan<- tgbotapi.Update) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(secretTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secretToken)) != 1 {
+			c.logger.Warn("Rejected webhook request with invalid secret token",
+				zap.String("remote_addr", r.RemoteAddr))
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		update, err := c.bot.HandleUpdate(r)
+		if err != nil {
+			c.logger.Warn("Failed to decode webhook update", zap.Error(err))
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case updates <- *update:
+			w.WriteHeader(http.StatusOK)
+		default:
+			// Telegram повторит доставку позже
+			c.logger.Warn("Webhook update queue is full", zap.Int("update_id", update.UpdateID))
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+		}
+	})
+}
+
+// setWebhook регистрирует webhook в Telegram.
+// tgbotapi не поддерживает secret_token, поэтому параметры передаются напрямую
+func (c *Client) setWebhook(webhookURL, secretToken string) error {
+	allowed, err := json.Marshal(allowedUpdates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal allowed updates: %w", err)
+	}
+
+	params := tgbotapi.Params{
+		"url":             webhookURL,
+		"secret_token":    secretToken,
+		"allowed_updates": string(allowed),
+	}
+	params.AddNonZero("max_connections", c.webhook.MaxConnections)
+
+	if _, err := c.bot.MakeRequest("setWebhook", params); err != nil {
+		return fmt.Errorf("failed to set webhook: %w", err)
+	}
+
+	return nil
+}
+
+// resolveSecretToken возвращает секретный токен из конфигурации или генерирует новый
+func (c *Client) resolveSecretToken() (string, error) {
+	if c.webhook.SecretToken != "" {
+		if !secretTokenPattern.MatchString(c.webhook.SecretToken) {
+			return "", fmt.Errorf("invalid webhook secret: only A-Z, a-z, 0-9, _ and - are allowed (1-256 chars)")
+		}
+		return c.webhook.SecretToken, nil
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	c.logger.Warn("WEBHOOK_SECRET is not set, using a generated secret token for this run")
+	return hex.EncodeToString(buf), nil
+}
+
+// shutdownWebhookServer останавливает HTTP сервер webhook
+func (c *Client) shutdownWebhookServer(server *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		c.logger.Warn("Failed to shutdown webhook server", zap.Error(err))
+	}
+}
@@ -4,6 +4,11 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/service"
+	"html"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,15 +19,18 @@ import (
 
 // AddArtist добавляет артистов в whitelist
 func (h *Handlers) AddArtist(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "add_artist") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
 	args := strings.Fields(message.CommandArguments())
 	if len(args) < 2 {
-		h.sendMessage(message.Chat.ID, "Использование: /add_artist <artist_names> [-f|-m]\nПример: /add_artist ITZY -f\nПример множественных: /add_artist ablume, aespa, apink -f")
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("add_artist.usage"))
 		return
 	}
 
@@ -37,54 +45,67 @@ func (h *Handlers) AddArtist(message *tgbotapi.Message) {
 	switch flag {
 	case "-f":
 		isFemale = true
-		genderFlag = "женский"
+		genderFlag = lang.T("add_artist.gender_female")
 	case "-m":
 		isFemale = false
-		genderFlag = "мужской"
+		genderFlag = lang.T("add_artist.gender_male")
 	default:
-		h.sendMessage(message.Chat.ID, "Флаг должен быть -f (женский) или -m (мужской). Пример: /add_artist ITZY -f")
+		h.auditRecord(message).Invalid("invalid gender flag")
+		h.sendMessage(message.Chat.ID, lang.T("add_artist.invalid_flag"))
 		return
 	}
 
 	// Парсим имена артистов (разделенные запятыми)
 	artistNames := h.parseArtists(artistNamesStr)
 	if len(artistNames) == 0 {
-		h.sendMessage(message.Chat.ID, "Не найдено ни одного имени артиста")
+		h.auditRecord(message).Invalid("no artist names")
+		h.sendMessage(message.Chat.ID, lang.T("add_artist.no_names"))
 		return
 	}
 
+	audit := h.auditRecord(message)
+	if before, err := h.services.Artist.AuditState(artistNames); err == nil {
+		audit.SetBefore(before)
+	}
+
 	addedCount, err := h.services.Artist.AddArtists(artistNames, isFemale)
 	if err != nil {
+		audit.Fail(err)
 		h.logger.Error("Failed to add artists", zap.Error(err))
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("Ошибка при добавлении артистов: %v", err))
+		h.sendMessage(message.Chat.ID, lang.T("add_artist.error", err))
 		return
 	}
 
+	audit.SetAfter(fmt.Sprintf("added: %d", addedCount))
+
 	if addedCount == 0 {
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("Все артисты уже существуют в списке: %s", strings.Join(artistNames, ", ")))
+		h.sendMessage(message.Chat.ID, lang.T("add_artist.exists", strings.Join(artistNames, ", ")))
 		return
 	}
 
 	// Формируем сообщение о результате
 	if len(artistNames) == 1 {
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Добавлен %s артист: %s", genderFlag, artistNames[0]))
+		h.sendMessage(message.Chat.ID, lang.T("add_artist.added_one", genderFlag, artistNames[0]))
 	} else {
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Добавлено %d %s артистов из %d: %s",
+		h.sendMessage(message.Chat.ID, lang.T("add_artist.added_many",
 			addedCount, genderFlag, len(artistNames), strings.Join(artistNames, ", ")))
 	}
 }
 
 // RemoveArtist деактивирует артистов (снимает флаг is_active)
 func (h *Handlers) RemoveArtist(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "remove_artist") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
 	args := strings.Fields(message.CommandArguments())
 	if len(args) < 1 {
-		h.sendMessage(message.Chat.ID, "Использование: /remove_artist <artist_names>\nПример: /remove_artist ITZY\nПример множественных: /remove_artist ablume, aespa, apink")
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("remove_artist.usage"))
 		return
 	}
 
@@ -94,44 +115,58 @@ func (h *Handlers) RemoveArtist(message *tgbotapi.Message) {
 	// Парсим имена артистов (разделенные запятыми)
 	artistNames := h.parseArtists(artistNamesStr)
 	if len(artistNames) == 0 {
-		h.sendMessage(message.Chat.ID, "Не найдено ни одного имени артиста")
+		h.auditRecord(message).Invalid("no artist names")
+		h.sendMessage(message.Chat.ID, lang.T("add_artist.no_names"))
 		return
 	}
 
+	audit := h.auditRecord(message)
+	if before, err := h.services.Artist.AuditState(artistNames); err == nil {
+		audit.SetBefore(before)
+	}
+
 	deactivatedCount, err := h.services.Artist.DeactivateArtists(artistNames)
 	if err != nil {
+		audit.Fail(err)
 		h.logger.Error("Failed to deactivate artists", zap.Error(err))
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("Ошибка при деактивации артистов: %v", err))
+		h.sendMessage(message.Chat.ID, lang.T("remove_artist.error", err))
 		return
 	}
 
+	audit.SetAfter(fmt.Sprintf("deactivated: %d", deactivatedCount))
+
 	if deactivatedCount == 0 {
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("Артисты не найдены или уже деактивированы: %s", strings.Join(artistNames, ", ")))
+		h.sendMessage(message.Chat.ID, lang.T("remove_artist.not_found", strings.Join(artistNames, ", ")))
 		return
 	}
 
 	// Формируем сообщение о результате
 	if len(artistNames) == 1 {
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Артист %s деактивирован (исключен из парсинга и отображения)", artistNames[0]))
+		h.sendMessage(message.Chat.ID, lang.T("remove_artist.done_one", artistNames[0]))
 	} else {
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Деактивировано %d артистов из %d: %s",
+		h.sendMessage(message.Chat.ID, lang.T("remove_artist.done_many",
 			deactivatedCount, len(artistNames), strings.Join(artistNames, ", ")))
 	}
 }
 
 // ClearWhitelists очищает все whitelist
 func (h *Handlers) ClearWhitelists(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "clearwhitelists") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
+	audit := h.auditRecord(message)
+
 	// Получаем всех артистов и удаляем их
 	artists, err := h.services.Artist.GetAll()
 	if err != nil {
+		audit.Fail(err)
 		h.logger.Error("Failed to get artists", zap.Error(err))
-		h.sendMessage(message.Chat.ID, "❌ Ошибка при получении списка артистов.")
+		h.sendMessage(message.Chat.ID, lang.T("clearwhitelists.get_error"))
 		return
 	}
 
@@ -140,34 +175,44 @@ func (h *Handlers) ClearWhitelists(message *tgbotapi.Message) {
 		artistNames = append(artistNames, artist.Name)
 	}
 
+	// В журнал попадает полный список с полом и активностью, чтобы удаленных артистов можно было восстановить
+	audit.SetBefore(service.ArtistsAuditState(artists))
+
+	removedCount := 0
 	if len(artistNames) > 0 {
-		_, err = h.services.Artist.RemoveArtists(artistNames)
+		removedCount, err = h.services.Artist.RemoveArtists(artistNames)
 		if err != nil {
+			audit.Fail(err)
 			h.logger.Error("Failed to remove artists", zap.Error(err))
-			h.sendMessage(message.Chat.ID, "❌ Ошибка при удалении артистов.")
+			h.sendMessage(message.Chat.ID, lang.T("clearwhitelists.remove_error"))
 			return
 		}
 	}
+	audit.SetAfter(fmt.Sprintf("removed: %d", removedCount))
 
-	h.sendMessage(message.Chat.ID, "✅ Все артисты удалены.")
+	h.sendMessage(message.Chat.ID, lang.T("clearwhitelists.done"))
 }
 
 // ClearCache очищает кэш релизов
 func (h *Handlers) ClearCache(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "clearcache") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
-	h.sendMessage(message.Chat.ID, "✅ Кэш очищен, обновление запущено")
+	h.sendMessage(message.Chat.ID, lang.T("clearcache.done"))
 }
 
 // Export экспортирует данные
 func (h *Handlers) Export(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "export") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
@@ -175,51 +220,63 @@ func (h *Handlers) Export(message *tgbotapi.Message) {
 	response, err := h.services.Artist.Export()
 	if err != nil {
 		h.logger.Error("Failed to export artists", zap.Error(err))
-		h.sendMessage(message.Chat.ID, "❌ Ошибка при экспорте данных.")
+		h.sendMessage(message.Chat.ID, lang.T("export.error"))
 		return
 	}
-	h.sendMessageWithMarkup(message.Chat.ID, response, h.getMainKeyboard())
+	h.sendMessageWithMarkup(message.Chat.ID, response, h.mainKeyboard(lang))
 }
 
 // Config устанавливает конфигурацию
 func (h *Handlers) Config(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "config") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
 	args := strings.Fields(message.CommandArguments())
 	if len(args) != 2 {
-		h.sendMessage(message.Chat.ID, "Использование: /config [key] [value]")
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("config.usage"))
 		return
 	}
 
 	key := args[0]
 	value := args[1]
 
+	audit := h.auditRecord(message)
+	if previous, err := h.services.Config.Get(key); err == nil {
+		audit.SetBefore(service.AuditConfigValue(key, previous))
+	}
+
 	err := h.services.Config.Set(key, value)
 	if err != nil {
+		audit.Fail(err)
 		h.logger.Error("Failed to set config", zap.Error(err))
-		h.sendMessage(message.Chat.ID, "Ошибка при установке конфигурации")
+		h.sendMessage(message.Chat.ID, lang.T("config.error"))
 		return
 	}
+	audit.SetAfter(service.AuditConfigValue(key, value))
 
-	h.sendMessage(message.Chat.ID, fmt.Sprintf("Конфигурация %s установлена в %s", key, value))
+	h.sendMessage(message.Chat.ID, lang.T("config.set", key, value))
 }
 
 // ConfigList показывает текущую конфигурацию
 func (h *Handlers) ConfigList(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "config_list") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
 	config, err := h.services.Config.GetAll()
 	if err != nil {
 		h.logger.Error("Failed to get config", zap.Error(err))
-		h.sendMessage(message.Chat.ID, "Ошибка при получении конфигурации")
+		h.sendMessage(message.Chat.ID, lang.T("config.list_error"))
 		return
 	}
 
@@ -228,32 +285,53 @@ func (h *Handlers) ConfigList(message *tgbotapi.Message) {
 
 // ConfigReset сбрасывает конфигурацию
 func (h *Handlers) ConfigReset(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "config_reset") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
+	audit := h.auditRecord(message)
+	if before, err := h.services.Config.GetAllConfig(); err == nil {
+		audit.SetBefore(service.ConfigSnapshot(before))
+	}
+
 	err := h.services.Config.Reset()
 	if err != nil {
+		audit.Fail(err)
 		h.logger.Error("Failed to reset config", zap.Error(err))
-		h.sendMessage(message.Chat.ID, "Ошибка при сбросе конфигурации")
+		h.sendMessage(message.Chat.ID, lang.T("config.reset_error"))
 		return
 	}
 
-	h.sendMessage(message.Chat.ID, "Конфигурация сброшена к значениям по умолчанию")
+	if after, err := h.services.Config.GetAllConfig(); err == nil {
+		audit.SetAfter(service.ConfigSnapshot(after))
+	}
+
+	h.sendMessage(message.Chat.ID, lang.T("config.reset_done"))
 }
 
 // ParseReleases парсит релизы за указанный период
 func (h *Handlers) ParseReleases(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "parse") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
 	args := strings.Fields(message.CommandArguments())
 
+	// force разбирает страницы заново, даже если они не изменились с прошлого парсинга
+	force := false
+	if len(args) > 0 && strings.EqualFold(args[len(args)-1], "force") {
+		force = true
+		args = args[:len(args)-1]
+	}
+
 	// Если аргументы не указаны, парсим текущий месяц
 	if len(args) == 0 {
 		currentMonth := strings.ToLower(time.Now().Format("January"))
@@ -263,95 +341,110 @@ func (h *Handlers) ParseReleases(message *tgbotapi.Message) {
 			zap.Int("year", currentYear))
 
 		// Отправляем сообщение о начале парсинга
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("🔄 Начинаю парсинг релизов за %s %d...", currentMonth, currentYear))
+		h.sendMessage(message.Chat.ID, lang.T("parse.started_month", lang.MonthName(currentMonth), strconv.Itoa(currentYear)))
 
-		// Запускаем парсинг в горутине
+		// Запускаем парсинг в горутине, запись аудита сохраняется после его завершения
+		audit := h.auditRecord(message)
+		audit.Detach()
 		go func() {
 			ctx := context.Background()
-			totalCount, err := h.parseMonth(ctx, currentMonth, currentYear)
+			report, err := h.parseMonth(ctx, currentMonth, currentYear, force)
+			h.finishParseAudit(audit, report, err)
 
 			if err != nil {
 				h.logger.Error("Failed to parse releases", zap.Error(err))
-				h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Ошибка при парсинге релизов: %v", err))
+				h.sendMessage(message.Chat.ID, lang.T("parse.error", err))
 				return
 			}
 
-			h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Парсинг завершен! Сохранено %d релизов за %s %d", totalCount, currentMonth, currentYear))
+			h.sendMessage(message.Chat.ID, lang.T("parse.done_month", report.Saved, lang.MonthName(currentMonth), strconv.Itoa(currentYear))+
+				formatUnchanged(lang, report)+formatNearMisses(lang, report.NearMisses)+formatDiscovered(lang, report.Discovered))
 		}()
 		return
 	}
 
+	// Аргументы проверяются до запуска, чтобы ошибка сразу попала в ответ и журнал аудита
+	if len(args) > 2 {
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("parse.usage"))
+		return
+	}
+	if len(args) == 2 {
+		if _, err := strconv.Atoi(args[1]); err != nil {
+			h.auditRecord(message).Invalid("invalid year")
+			h.sendMessage(message.Chat.ID, lang.T("parse.invalid_year"))
+			return
+		}
+	}
+
 	// Отправляем сообщение о начале парсинга
-	h.sendMessage(message.Chat.ID, "🔄 Начинаю парсинг релизов...")
+	h.sendMessage(message.Chat.ID, lang.T("parse.started"))
 
-	// Запускаем парсинг в горутине
+	// Запускаем парсинг в горутине, запись аудита сохраняется после его завершения
+	audit := h.auditRecord(message)
+	audit.Detach()
 	go func() {
 		ctx := context.Background()
-		var totalCount int
+		var report *service.ParseReport
 		var err error
 
 		if len(args) == 1 {
 			// Проверяем, является ли аргумент годом (4 цифры)
 			if year, parseErr := strconv.Atoi(args[0]); parseErr == nil && year >= 2000 && year <= 2100 {
 				// Парсинг всего года
-				totalCount, err = h.parseYear(ctx, year)
+				report, err = h.parseYear(ctx, year, force)
 			} else {
 				// Парсинг месяца текущего года
 				month := strings.ToLower(args[0])
 				currentYear := time.Now().Year()
-				totalCount, err = h.parseMonth(ctx, month, currentYear)
+				report, err = h.parseMonth(ctx, month, currentYear, force)
 			}
-		} else if len(args) == 2 {
-			// Парсинг конкретного месяца и года
-			month := strings.ToLower(args[0])
-			year, parseErr := strconv.Atoi(args[1])
-			if parseErr != nil {
-				h.sendMessage(message.Chat.ID, "❌ Неверный формат года. Используйте 4 цифры (например: 2025)")
-				return
-			}
-			totalCount, err = h.parseMonth(ctx, month, year)
 		} else {
-			h.sendMessage(message.Chat.ID, "❌ Слишком много аргументов.\n\n"+
-				"Использование:\n"+
-				"• /parse - парсинг текущего месяца\n"+
-				"• /parse <месяц> - парсинг месяца текущего года\n"+
-				"• /parse <месяц> <год> - парсинг конкретного месяца и года\n"+
-				"• /parse <год> - парсинг всего года\n\n"+
-				"Примеры:\n"+
-				"• /parse\n"+
-				"• /parse september\n"+
-				"• /parse september 2025\n"+
-				"• /parse 2025")
-			return
+			// Парсинг конкретного месяца и года, год проверен до запуска
+			month := strings.ToLower(args[0])
+			year, _ := strconv.Atoi(args[1])
+			report, err = h.parseMonth(ctx, month, year, force)
 		}
+		h.finishParseAudit(audit, report, err)
 
 		if err != nil {
 			h.logger.Error("Failed to parse releases", zap.Error(err))
-			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Ошибка при парсинге релизов: %v", err))
+			h.sendMessage(message.Chat.ID, lang.T("parse.error", err))
 			return
 		}
 
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Парсинг завершен! Сохранено %d релизов", totalCount))
+		h.sendMessage(message.Chat.ID, lang.T("parse.done", report.Saved)+
+			formatUnchanged(lang, report)+formatNearMisses(lang, report.NearMisses)+formatDiscovered(lang, report.Discovered))
 	}()
 }
 
+// finishParseAudit сохраняет запись аудита /parse с результатом фонового парсинга
+func (h *Handlers) finishParseAudit(audit *service.AuditRecord, report *service.ParseReport, err error) {
+	if err != nil {
+		audit.Fail(err)
+	} else {
+		audit.SetAfter(fmt.Sprintf("saved: %d", report.Saved))
+	}
+	h.services.Audit.FinishDetached(audit)
+}
+
 // parseMonth парсит релизы за конкретный месяц и год
-func (h *Handlers) parseMonth(ctx context.Context, month string, year int) (int, error) {
+func (h *Handlers) parseMonth(ctx context.Context, month string, year int, force bool) (*service.ParseReport, error) {
 	h.logger.Info("Parsing month", zap.String("month", month), zap.Int("year", year))
 
 	// Формируем строку месяца с годом для скрейпера
 	monthWithYear := fmt.Sprintf("%s-%d", month, year)
 
-	count, err := h.services.Release.ParseReleasesForMonth(ctx, monthWithYear)
+	report, err := h.services.Release.ParseReleasesForMonth(ctx, monthWithYear, force)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse month %s %d: %w", month, year, err)
+		return nil, fmt.Errorf("failed to parse month %s %d: %w", month, year, err)
 	}
 
-	return count, nil
+	return report, nil
 }
 
 // parseYear парсит релизы за весь год
-func (h *Handlers) parseYear(ctx context.Context, year int) (int, error) {
+func (h *Handlers) parseYear(ctx context.Context, year int, force bool) (*service.ParseReport, error) {
 	h.logger.Info("Parsing year", zap.Int("year", year))
 
 	months := []string{
@@ -359,11 +452,11 @@ func (h *Handlers) parseYear(ctx context.Context, year int) (int, error) {
 		"july", "august", "september", "october", "november", "december",
 	}
 
-	totalCount := 0
+	total := &service.ParseReport{}
 	for _, month := range months {
 		monthWithYear := fmt.Sprintf("%s-%d", month, year)
 
-		count, err := h.services.Release.ParseReleasesForMonth(ctx, monthWithYear)
+		report, err := h.services.Release.ParseReleasesForMonth(ctx, monthWithYear, force)
 		if err != nil {
 			h.logger.Warn("Failed to parse month",
 				zap.String("month", month),
@@ -372,14 +465,22 @@ func (h *Handlers) parseYear(ctx context.Context, year int) (int, error) {
 			continue
 		}
 
-		totalCount += count
+		total.Merge(report)
 		h.logger.Info("Parsed month",
 			zap.String("month", month),
 			zap.Int("year", year),
-			zap.Int("count", count))
+			zap.Int("count", report.Saved))
 	}
 
-	return totalCount, nil
+	return total, nil
+}
+
+// formatUnchanged форматирует для отчета парсинга количество пропущенных неизменных страниц
+func formatUnchanged(lang i18n.Lang, report *service.ParseReport) string {
+	if report.Unchanged == 0 {
+		return ""
+	}
+	return lang.T("parse.unchanged", report.Unchanged)
 }
 
 // parseArtists парсит список артистов из строки
@@ -408,121 +509,236 @@ func (h *Handlers) sendMessageWithMarkup(chatID int64, text string, markup inter
 	}
 }
 
-// getMainKeyboard возвращает основную клавиатуру
-func (h *Handlers) getMainKeyboard() tgbotapi.InlineKeyboardMarkup {
-	return h.keyboard.GetMainKeyboard()
+// mainKeyboard возвращает основную клавиатуру на языке пользователя
+func (h *Handlers) mainKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return h.keyboard.GetMainKeyboard(lang)
 }
 
 // TasksList показывает список всех задач
 func (h *Handlers) TasksList(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "tasks_list") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
 	tasks, err := h.services.Task.GetAllTasks()
 	if err != nil {
 		h.logger.Error("Failed to get tasks", zap.Error(err))
-		h.sendMessage(message.Chat.ID, "Ошибка при получении списка задач")
+		h.sendMessage(message.Chat.ID, lang.T("tasks.error"))
 		return
 	}
 
 	if len(tasks) == 0 {
-		h.sendMessage(message.Chat.ID, "📋 Задачи не найдены")
+		h.sendMessage(message.Chat.ID, lang.T("tasks.empty"))
 		return
 	}
 
 	var result strings.Builder
-	result.WriteString("📋 Список задач:\n\n")
+	result.WriteString(lang.T("tasks.title"))
 
 	for _, task := range tasks {
 		// Статус активности
-		status := "🔴 Неактивна"
+		status := lang.T("tasks.inactive")
 		if task.IsActive {
-			status = "🟢 Активна"
+			status = lang.T("tasks.active")
 		}
 
 		result.WriteString(fmt.Sprintf("🔧 <b>%s</b> (%s)\n", task.Name, status))
 		result.WriteString(fmt.Sprintf("   📝 %s\n", task.Description))
 		result.WriteString(fmt.Sprintf("   ⏰ Cron: %s\n", task.CronExpression))
-		result.WriteString(fmt.Sprintf("   📊 Запусков: %d (успешно: %d, ошибок: %d)\n",
+		result.WriteString(lang.T("tasks.runs",
 			task.RunCount, task.SuccessCount, task.ErrorCount))
 
 		if task.LastRun != nil {
-			result.WriteString(fmt.Sprintf("   🕐 Последний запуск: %s\n",
+			result.WriteString(lang.T("tasks.last_run",
 				task.LastRun.Format("02.01.2006 15:04:05")))
 		}
 
 		if task.NextRun != nil {
-			result.WriteString(fmt.Sprintf("   ⏭️ Следующий запуск: %s\n",
+			result.WriteString(lang.T("tasks.next_run",
 				task.NextRun.Format("02.01.2006 15:04:05")))
 		}
 
 		if task.LastError != "" {
-			result.WriteString(fmt.Sprintf("   ❌ Последняя ошибка: %s\n", task.LastError))
+			result.WriteString(lang.T("tasks.last_error", task.LastError))
+		}
+
+		result.WriteString("\n")
+	}
+
+	h.sendMessage(message.Chat.ID, result.String())
+}
+
+// TaskHistory показывает последние запуски задачи
+func (h *Handlers) TaskHistory(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "task_history") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	usage := lang.T("task_history.usage")
+
+	args := strings.Fields(message.CommandArguments())
+	if len(args) == 0 || len(args) > 2 {
+		h.sendMessage(message.Chat.ID, usage)
+		return
+	}
+
+	limit := 10
+	if len(args) == 2 {
+		parsed, err := strconv.Atoi(args[1])
+		if err != nil || parsed < 1 || parsed > 50 {
+			h.sendMessage(message.Chat.ID, usage)
+			return
 		}
+		limit = parsed
+	}
+
+	task, runs, err := h.services.Task.GetTaskHistory(args[0], limit)
+	if err != nil {
+		h.logger.Error("Failed to get task history", zap.String("task_name", args[0]), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("task_history.error"))
+		return
+	}
+
+	if task == nil {
+		h.sendMessage(message.Chat.ID, lang.T("task_history.not_found", html.EscapeString(args[0])))
+		return
+	}
+
+	var result strings.Builder
+	result.WriteString(lang.T("task_history.title", html.EscapeString(task.Name)))
 
+	if len(runs) == 0 {
+		result.WriteString(lang.T("task_history.empty"))
+		h.sendMessage(message.Chat.ID, result.String())
+		return
+	}
+
+	for _, run := range runs {
+		result.WriteString(formatTaskRun(run))
 		result.WriteString("\n")
 	}
 
 	h.sendMessage(message.Chat.ID, result.String())
 }
 
+// formatTaskRun форматирует один запуск задачи
+func formatTaskRun(run model.TaskRun) string {
+	icons := map[model.TaskRunStatus]string{
+		model.TaskRunStatusRunning:     "⏳",
+		model.TaskRunStatusSuccess:     "✅",
+		model.TaskRunStatusError:       "❌",
+		model.TaskRunStatusInterrupted: "⚠️",
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("%s %s", icons[run.Status], run.StartedAt.Format("02.01.2006 15:04:05")))
+	if run.FinishedAt != nil {
+		text.WriteString(fmt.Sprintf(" (%s)", run.Duration().Round(time.Second)))
+	}
+	text.WriteString("\n")
+
+	if len(run.Result) > 0 {
+		keys := make([]string, 0, len(run.Result))
+		for key := range run.Result {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			text.WriteString(fmt.Sprintf("   • %s: %s\n", html.EscapeString(key), html.EscapeString(fmt.Sprint(run.Result[key]))))
+		}
+	}
+
+	if run.Error != "" {
+		errorText := run.Error
+		if len([]rune(errorText)) > 200 {
+			errorText = string([]rune(errorText)[:200]) + "…"
+		}
+		text.WriteString(fmt.Sprintf("   ❌ %s\n", html.EscapeString(errorText)))
+	}
+
+	return text.String()
+}
+
 // ReloadPlaylist перезагружает плейлист из Spotify
 func (h *Handlers) ReloadPlaylist(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "reload_playlist") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
-	h.sendMessage(message.Chat.ID, "🔄 Начинаю перезагрузку плейлиста...")
+	h.sendMessage(message.Chat.ID, lang.T("reload_playlist.started"))
 
 	err := h.services.Playlist.ReloadPlaylist()
 	if err != nil {
 		h.logger.Error("Failed to reload playlist", zap.Error(err))
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Ошибка при перезагрузке плейлиста: %v", err))
+		h.sendMessage(message.Chat.ID, lang.T("reload_playlist.error", err))
 		return
 	}
 
-	h.sendMessage(message.Chat.ID, "✅ Плейлист успешно перезагружен!")
+	h.sendMessage(message.Chat.ID, lang.T("reload_playlist.done"))
 }
 
 // Admin обрабатывает команду /admin
 func (h *Handlers) Admin(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
-		return
-	}
-
-	text := "🔧 <b>Команды администратора:</b>\n\n" +
-		"/add_artist [имена] [-f|-m] - Добавить артиста(ов)\n" +
-		"/remove_artist [имена] - Деактивировать артиста(ов)\n" +
-		"/export - Экспорт всех артистов\n" +
-		"/config [ключ] [значение] - Установить конфигурацию\n" +
-		"/config_list - Показать конфигурацию\n" +
-		"/config_reset - Сбросить конфигурацию\n" +
-		"/tasks_list - Показать список задач\n" +
-		"/reload_playlist - Перезагрузить плейлист\n" +
-		"/parse [год] - Парсинг релизов\n" +
-		"/llm_metrics - Показать метрики LLM\n" +
-		"/parse [месяц] [год] - Парсинг конкретного месяца\n" +
-		"/parse [месяц] - Парсинг месяца текущего года\n" +
-		"/parse - Парсинг текущего месяца\n\n" +
-		"<b>Примеры множественных артистов:</b>\n" +
-		"/add_artist ablume, aespa, apink -f\n" +
-		"/remove_artist ablume, aespa, apink"
-
-	h.sendMessage(message.Chat.ID, text)
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "admin") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	h.sendMessage(message.Chat.ID, lang.T("admin.help"))
+}
+
+// Changes показывает сводку изменений релизов за последние дни
+func (h *Handlers) Changes(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "changes") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	days := 7
+	if arg := strings.TrimSpace(message.CommandArguments()); arg != "" {
+		parsed, err := strconv.Atoi(arg)
+		if err != nil || parsed < 1 || parsed > 365 {
+			h.sendMessage(message.Chat.ID, lang.T("changes.usage"))
+			return
+		}
+		days = parsed
+	}
+
+	summary, err := h.services.Release.GetChangesSummary(days)
+	if err != nil {
+		h.logger.Error("Failed to get release changes", zap.Int("days", days), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("changes.error", err))
+		return
+	}
+
+	h.sendMessage(message.Chat.ID, summary)
 }
 
 // LLMMetrics показывает метрики LLM
 func (h *Handlers) LLMMetrics(message *tgbotapi.Message) {
-	// Проверка прав администратора
-	if !h.isAdmin(message.From) {
-		h.sendMessage(message.Chat.ID, "У вас нет прав для выполнения этой команды")
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "llm_metrics") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
 		return
 	}
 
@@ -530,25 +746,28 @@ func (h *Handlers) LLMMetrics(message *tgbotapi.Message) {
 	metrics := h.services.Release.GetLLMMetrics()
 
 	var text strings.Builder
-	text.WriteString("📊 *Метрики LLM*\n\n")
+	text.WriteString(lang.T("llm_metrics.title"))
 
 	if errorMsg, ok := metrics["error"]; ok {
-		text.WriteString(fmt.Sprintf("❌ Ошибка: %v\n", errorMsg))
+		text.WriteString(lang.T("llm_metrics.error", errorMsg))
 	} else {
-		text.WriteString(fmt.Sprintf("📈 Всего запросов: %v\n", metrics["total_requests"]))
-		text.WriteString(fmt.Sprintf("✅ Успешных: %v\n", metrics["successful_requests"]))
-		text.WriteString(fmt.Sprintf("❌ Неудачных: %v\n", metrics["failed_requests"]))
+		text.WriteString(lang.T("llm_metrics.requests",
+			metrics["total_requests"], metrics["successful_requests"], metrics["failed_requests"]))
 
 		if lastRequest, ok := metrics["last_request_time"]; ok {
 			if lastTime, ok := lastRequest.(time.Time); ok && !lastTime.IsZero() {
-				text.WriteString(fmt.Sprintf("🕐 Последний запрос: %s\n", lastTime.Format("15:04:05")))
+				text.WriteString(lang.T("llm_metrics.last", lastTime.Format("15:04:05")))
 			} else {
-				text.WriteString("🕐 Последний запрос: никогда\n")
+				text.WriteString(lang.T("llm_metrics.never"))
 			}
 		}
 
 		if delay, ok := metrics["delay_ms"]; ok {
-			text.WriteString(fmt.Sprintf("⏱️ Задержка: %v мс\n", delay))
+			text.WriteString(lang.T("llm_metrics.delay", delay))
+		}
+
+		if hits, ok := metrics["cache_hits"]; ok {
+			text.WriteString(lang.T("llm_metrics.cache", hits, metrics["cache_misses"]))
 		}
 	}
 
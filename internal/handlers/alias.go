@@ -0,0 +1,146 @@
+// Package handlers содержит обработчик псевдонимов артистов.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/service"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// maxNearMissLines ограничивает количество похожих имен в отчете парсинга
+const maxNearMissLines = 15
+
+// Alias управляет псевдонимами артистов, по которым сопоставляются имена из источников и /search
+func (h *Handlers) Alias(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "alias") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	arguments := strings.TrimSpace(message.CommandArguments())
+	action, rest, _ := strings.Cut(arguments, " ")
+	action = strings.ToLower(action)
+
+	if action != "add" && action != "remove" {
+		h.listAliases(message.Chat.ID, lang, arguments)
+		return
+	}
+
+	artistName, aliasList, ok := strings.Cut(rest, "=")
+	artistName = strings.TrimSpace(artistName)
+	aliases := h.parseArtists(aliasList)
+	if !ok || artistName == "" || len(aliases) == 0 {
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("alias.usage"))
+		return
+	}
+
+	audit := h.auditRecord(message)
+	audit.SetBefore(fmt.Sprintf("%s: %s", artistName, strings.Join(aliases, ", ")))
+
+	var artist *model.Artist
+	var changed int
+	var err error
+	if action == "add" {
+		artist, changed, err = h.services.Artist.AddAliases(artistName, aliases)
+	} else {
+		artist, changed, err = h.services.Artist.RemoveAliases(artistName, aliases)
+	}
+
+	switch {
+	case err == nil && artist == nil:
+		audit.Invalid("artist not found")
+		h.sendMessage(message.Chat.ID, lang.T("admin.artist_not_found", html.EscapeString(artistName)))
+		return
+	case errors.Is(err, service.ErrAliasConflict):
+		audit.Invalid(err.Error())
+		h.sendMessage(message.Chat.ID, lang.T("alias.conflict", html.EscapeString(err.Error())))
+		return
+	case err != nil:
+		audit.Fail(err)
+		h.logger.Error("Failed to change artist aliases", zap.String("artist", artistName), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("alias.error", err))
+		return
+	}
+
+	audit.SetAfter(fmt.Sprintf("%s: %d", action, changed))
+
+	if action == "add" {
+		h.sendMessage(message.Chat.ID, lang.T("alias.added",
+			html.EscapeString(artist.Name), changed, len(aliases)))
+		return
+	}
+	h.sendMessage(message.Chat.ID, lang.T("alias.removed",
+		html.EscapeString(artist.Name), changed, len(aliases)))
+}
+
+// listAliases показывает псевдонимы артиста или все псевдонимы
+func (h *Handlers) listAliases(chatID int64, lang i18n.Lang, artistName string) {
+	aliases, err := h.services.Artist.GetAliases(artistName)
+	if err != nil {
+		h.logger.Error("Failed to get artist aliases", zap.String("artist", artistName), zap.Error(err))
+		h.sendMessage(chatID, lang.T("alias.list_error"))
+		return
+	}
+
+	if len(aliases) == 0 {
+		h.sendMessage(chatID, lang.T("alias.empty")+lang.T("alias.usage"))
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(lang.T("alias.title"))
+
+	current := ""
+	for _, alias := range aliases {
+		name := lang.T("admin.artist_id", strconv.Itoa(alias.ArtistID))
+		if alias.Artist != nil {
+			name = alias.Artist.Name
+		}
+		if name != current {
+			current = name
+			text.WriteString(fmt.Sprintf("\n<b>%s</b>: ", html.EscapeString(name)))
+		} else {
+			text.WriteString(", ")
+		}
+		text.WriteString(fmt.Sprintf("<code>%s</code>", html.EscapeString(alias.Alias)))
+	}
+
+	h.sendMessage(chatID, text.String())
+}
+
+// formatNearMisses форматирует для отчета парсинга имена из источников, похожие на артистов из списка
+func formatNearMisses(lang i18n.Lang, nearMisses []service.NearMiss) string {
+	if len(nearMisses) == 0 {
+		return ""
+	}
+
+	var text strings.Builder
+	text.WriteString(lang.T("near_miss.title"))
+	for i, nearMiss := range nearMisses {
+		if i == maxNearMissLines {
+			text.WriteString(lang.T("admin.more", len(nearMisses)-i))
+			break
+		}
+
+		candidates := make([]string, 0, len(nearMiss.Candidates))
+		for _, candidate := range nearMiss.Candidates {
+			candidates = append(candidates, fmt.Sprintf("%s (%.0f%%)", html.EscapeString(candidate.Artist.Name), candidate.Score*100))
+		}
+		text.WriteString(fmt.Sprintf("• <code>%s</code> ~ %s\n", html.EscapeString(nearMiss.Name), strings.Join(candidates, ", ")))
+	}
+	text.WriteString(lang.T("near_miss.hint"))
+
+	return text.String()
+}
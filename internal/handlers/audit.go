@@ -0,0 +1,125 @@
+// Package handlers содержит обработчик журнала аудита.
+package handlers
+
+import (
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAuditLimit = 20
+	maxAuditLimit     = 100
+
+	// maxAuditValueLength ограничивает длину аргументов и состояний в выводе
+	maxAuditValueLength = 200
+
+	// maxAuditMessageLength оставляет запас до лимита Telegram в 4096 символов
+	maxAuditMessageLength = 3800
+)
+
+// auditOutcomeIcons значки результатов выполнения команд
+var auditOutcomeIcons = map[string]string{
+	model.AuditOutcomeSuccess: "✅",
+	model.AuditOutcomeError:   "❌",
+	model.AuditOutcomeInvalid: "⚠️",
+	model.AuditOutcomeDenied:  "⛔",
+}
+
+// Audit показывает последние записи журнала действий администраторов
+func (h *Handlers) Audit(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "audit") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	limit := defaultAuditLimit
+	command := ""
+	for _, arg := range strings.Fields(message.CommandArguments()) {
+		if parsed, err := strconv.Atoi(arg); err == nil {
+			if parsed < 1 || parsed > maxAuditLimit {
+				h.sendMessage(message.Chat.ID, lang.T("audit.limit", maxAuditLimit))
+				return
+			}
+			limit = parsed
+			continue
+		}
+		command = arg
+	}
+
+	entries, err := h.services.Audit.GetRecent(limit, command)
+	if err != nil {
+		h.logger.Error("Failed to get audit log", zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("audit.error"))
+		return
+	}
+
+	if len(entries) == 0 {
+		h.sendMessage(message.Chat.ID, lang.T("audit.empty"))
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(lang.T("audit.title", len(entries)))
+	for i, entry := range entries {
+		line := formatAuditEntry(lang, entry)
+		if text.Len()+len(line) > maxAuditMessageLength {
+			text.WriteString(lang.T("audit.more", len(entries)-i))
+			break
+		}
+		text.WriteString("\n")
+		text.WriteString(line)
+	}
+
+	h.sendMessage(message.Chat.ID, text.String())
+}
+
+// formatAuditEntry форматирует запись журнала для вывода
+func formatAuditEntry(lang i18n.Lang, entry model.AuditEntry) string {
+	icon, ok := auditOutcomeIcons[entry.Outcome]
+	if !ok {
+		icon = "•"
+	}
+
+	actor := strconv.FormatInt(entry.ActorID, 10)
+	if entry.ActorUsername != "" {
+		actor = fmt.Sprintf("@%s (%d)", entry.ActorUsername, entry.ActorID)
+	}
+
+	var line strings.Builder
+	line.WriteString(fmt.Sprintf("%s %s <b>/%s</b> — %s\n", icon,
+		entry.CreatedAt.Format("02.01 15:04"), html.EscapeString(entry.Command), html.EscapeString(actor)))
+
+	if entry.Arguments != "" {
+		line.WriteString(lang.T("audit.arguments", auditValue(entry.Arguments)))
+	}
+	if entry.Before != "" {
+		line.WriteString(lang.T("audit.before", auditValue(entry.Before)))
+	}
+	if entry.After != "" {
+		line.WriteString(lang.T("audit.after", auditValue(entry.After)))
+	}
+	if entry.Error != "" {
+		line.WriteString(lang.T("audit.failure", auditValue(entry.Error)))
+	}
+
+	return line.String()
+}
+
+// auditValue обрезает длинное значение и экранирует его для HTML
+func auditValue(value string) string {
+	runes := []rune(value)
+	if len(runes) > maxAuditValueLength {
+		value = string(runes[:maxAuditValueLength]) + "…"
+	}
+	return html.EscapeString(value)
+}
@@ -0,0 +1,89 @@
+// Package handlers содержит обработчик ленты релизов iCalendar.
+package handlers
+
+import (
+	"errors"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/service"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Calendar обрабатывает команду /calendar: возвращает ссылку на ленту релизов для календаря
+func (h *Handlers) Calendar(message *tgbotapi.Message) {
+	if message.From == nil {
+		return
+	}
+
+	lang := h.lang(message)
+	filter, description, ok := h.calendarFilter(message, lang)
+	if !ok {
+		return
+	}
+
+	feedURL, err := h.services.Calendar.FeedURL(filter)
+	if errors.Is(err, service.ErrCalendarDisabled) {
+		h.sendMessage(message.Chat.ID, lang.T("calendar.disabled"))
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to build calendar URL", zap.Int64("user_id", message.From.ID), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("calendar.error"))
+		return
+	}
+
+	text := lang.T("calendar.text", description, html.EscapeString(feedURL))
+	if filter.UserID != 0 {
+		text += lang.T("calendar.personal")
+	}
+
+	h.sendMessage(message.Chat.ID, text)
+}
+
+// calendarFilter разбирает аргументы /calendar: без аргументов - подписки пользователя,
+// all - все релизы, -f/-m - по полу, иначе список артистов через запятую
+func (h *Handlers) calendarFilter(message *tgbotapi.Message, lang i18n.Lang) (service.CalendarFilter, string, bool) {
+	var filter service.CalendarFilter
+	args := strings.Fields(message.CommandArguments())
+
+	if len(args) == 0 {
+		subscriptions, err := h.services.Subscription.GetUserSubscriptions(message.From.ID)
+		if err != nil {
+			h.logger.Error("Failed to get subscriptions", zap.Int64("user_id", message.From.ID), zap.Error(err))
+			h.sendMessage(message.Chat.ID, lang.T("subscriptions.error"))
+			return filter, "", false
+		}
+		if len(subscriptions) == 0 {
+			h.sendMessage(message.Chat.ID, lang.T("calendar.no_subscriptions"))
+			return filter, "", false
+		}
+
+		filter.UserID = message.From.ID
+		return filter, lang.T("calendar.mine", len(subscriptions)), true
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "all":
+		return filter, lang.T("calendar.all"), true
+	case "-f":
+		filter.Genders = []model.Gender{model.GenderFemale}
+		return filter, lang.T("calendar.female"), true
+	case "-m":
+		filter.Genders = []model.Gender{model.GenderMale}
+		return filter, lang.T("calendar.male"), true
+	}
+
+	for _, artist := range h.parseArtists(strings.Join(args, " ")) {
+		filter.Artists = append(filter.Artists, strings.ToLower(artist))
+	}
+	if len(filter.Artists) == 0 {
+		h.sendMessage(message.Chat.ID, lang.T("calendar.usage"))
+		return filter, "", false
+	}
+
+	return filter, html.EscapeString(strings.Join(filter.Artists, ", ")), true
+}
@@ -0,0 +1,123 @@
+// Package handlers содержит обработчик настроек групповых чатов.
+package handlers
+
+import (
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Chat обрабатывает команду /chat: показывает настройки группы, администраторы группы меняют их аргументами
+// lang, filter, digest, quiet и commands
+func (h *Handlers) Chat(message *tgbotapi.Message) {
+	if message.From == nil {
+		return
+	}
+
+	lang := h.lang(message)
+	if message.Chat.IsPrivate() {
+		h.sendMessage(message.Chat.ID, lang.T("chat.group_only"))
+		return
+	}
+
+	chatID := message.Chat.ID
+	args := strings.Fields(message.CommandArguments())
+	if len(args) == 0 {
+		h.sendMessage(chatID, h.services.ChatSettings.FormatSettings(h.services.ChatSettings.Get(chatID), lang))
+		return
+	}
+
+	if !h.isChatAdmin(message) {
+		h.sendMessage(chatID, lang.T("chat.admin_only"))
+		return
+	}
+
+	if len(args) < 2 {
+		h.sendMessage(chatID, lang.T("chat.usage"))
+		return
+	}
+
+	var err error
+	value := strings.ToLower(args[1])
+	switch strings.ToLower(args[0]) {
+	case "lang":
+		// auto сбрасывает язык группы: каждому участнику бот отвечает на его языке
+		chatLang, ok := i18n.Parse(value)
+		if !ok && value != "auto" {
+			h.sendMessage(chatID, lang.T("chat.usage"))
+			return
+		}
+		if !ok {
+			chatLang = ""
+		}
+		err = h.services.ChatSettings.SetLanguage(chatID, chatLang)
+		if ok {
+			lang = chatLang
+		} else {
+			lang = h.userLang(message.From)
+		}
+	case "filter":
+		var gender model.Gender
+		switch value {
+		case "female", "-f":
+			gender = model.GenderFemale
+		case "male", "-m":
+			gender = model.GenderMale
+		case "off":
+		default:
+			h.sendMessage(chatID, lang.T("chat.usage"))
+			return
+		}
+		err = h.services.ChatSettings.SetGenderFilter(chatID, gender)
+	case "digest", "quiet":
+		if value != "on" && value != "off" {
+			h.sendMessage(chatID, lang.T("chat.usage"))
+			return
+		}
+		if strings.ToLower(args[0]) == "digest" {
+			err = h.services.ChatSettings.SetDigest(chatID, value == "on")
+		} else {
+			err = h.services.ChatSettings.SetQuietMode(chatID, value == "on")
+		}
+	case "commands":
+		commands := args[1:]
+		if value == "all" {
+			commands = nil
+		}
+		err = h.services.ChatSettings.SetAllowedCommands(chatID, commands)
+	default:
+		h.sendMessage(chatID, lang.T("chat.usage"))
+		return
+	}
+
+	if err != nil {
+		h.logger.Error("Failed to update chat settings", zap.Int64("chat_id", chatID), zap.Error(err))
+		h.sendMessage(chatID, lang.T("settings.error"))
+		return
+	}
+
+	h.sendMessage(chatID, lang.T("chat.saved")+h.services.ChatSettings.FormatSettings(h.services.ChatSettings.Get(chatID), lang))
+}
+
+// isChatAdmin проверяет, что автор сообщения администратор группы или администратор бота
+func (h *Handlers) isChatAdmin(message *tgbotapi.Message) bool {
+	if h.services.Access.RoleOf(message.From.ID, message.From.UserName).AtLeast(model.RoleAdmin) {
+		return true
+	}
+	if h.botAPI == nil {
+		return false
+	}
+
+	member, err := h.botAPI.GetChatMember(message.Chat.ID, message.From.ID)
+	if err != nil {
+		h.logger.Warn("Failed to check chat admin",
+			zap.Int64("chat_id", message.Chat.ID),
+			zap.Int64("user_id", message.From.ID),
+			zap.Error(err))
+		return false
+	}
+	return member.IsCreator() || member.IsAdministrator()
+}
@@ -0,0 +1,52 @@
+// Package handlers содержит обработчик подписки на дайджесты релизов.
+package handlers
+
+import (
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Digest обрабатывает команду /digest: без аргументов показывает статус, /digest on|off включает или отключает рассылку
+func (h *Handlers) Digest(message *tgbotapi.Message) {
+	if message.From == nil {
+		return
+	}
+
+	lang := h.lang(message)
+	userID := message.From.ID
+	args := strings.Fields(message.CommandArguments())
+
+	if len(args) == 0 {
+		if h.services.Settings.Get(userID).Digest {
+			h.sendMessage(message.Chat.ID, lang.T("digest.status_on"))
+		} else {
+			h.sendMessage(message.Chat.ID, lang.T("digest.status_off"))
+		}
+		return
+	}
+
+	var enabled bool
+	switch strings.ToLower(args[0]) {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		h.sendMessage(message.Chat.ID, lang.T("digest.usage"))
+		return
+	}
+
+	if err := h.services.Settings.SetDigest(userID, enabled); err != nil {
+		h.logger.Error("Failed to update digest subscription", zap.Int64("user_id", userID), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("settings.error"))
+		return
+	}
+
+	if enabled {
+		h.sendMessage(message.Chat.ID, lang.T("digest.enabled"))
+	} else {
+		h.sendMessage(message.Chat.ID, lang.T("digest.disabled"))
+	}
+}
@@ -0,0 +1,62 @@
+// Package handlers содержит обработчик отчета о новых артистах.
+package handlers
+
+import (
+	"gemfactory/internal/i18n"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Ограничения списка /discover
+const (
+	defaultDiscoverLimit = 10
+	maxDiscoverLimit     = 20
+)
+
+// Discover показывает артистов из расписания, которых нет в списке, с кнопками добавления
+func (h *Handlers) Discover(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "discover") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	limit := defaultDiscoverLimit
+	if arguments := strings.TrimSpace(message.CommandArguments()); arguments != "" {
+		parsed, err := strconv.Atoi(arguments)
+		if err != nil || parsed < 1 || parsed > maxDiscoverLimit {
+			h.auditRecord(message).Invalid("usage")
+			h.sendMessage(message.Chat.ID, lang.T("discover.usage", maxDiscoverLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	artists, total, err := h.services.Discovery.GetPending(limit)
+	if err != nil {
+		h.logger.Error("Failed to get discovered artists", zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("discover.error"))
+		return
+	}
+
+	text := h.services.Discovery.FormatPending(artists, total)
+	if len(artists) == 0 {
+		h.sendMessage(message.Chat.ID, text)
+		return
+	}
+
+	h.sendMessageWithMarkup(message.Chat.ID, text, h.keyboard.GetDiscoveryKeyboard(artists))
+}
+
+// formatDiscovered форматирует для отчета парсинга количество новых артистов не из списка
+func formatDiscovered(lang i18n.Lang, discovered []string) string {
+	if len(discovered) == 0 {
+		return ""
+	}
+	return lang.T("discover.found", len(discovered))
+}
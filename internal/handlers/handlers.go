@@ -4,6 +4,7 @@ package handlers
 import (
 	"gemfactory/internal/config"
 	"gemfactory/internal/external/telegram"
+	"gemfactory/internal/i18n"
 	"gemfactory/internal/keyboard"
 	"gemfactory/internal/service"
 
@@ -30,20 +31,43 @@ func New(services *service.Services, config *config.Config, keyboard keyboard.Ma
 	}
 }
 
-// isAdmin проверяет, является ли пользователь администратором
-func (h *Handlers) isAdmin(user *tgbotapi.User) bool {
-	// Получаем username администратора из конфигурации (уже загружена через загрузчик)
-	adminUsername := h.config.AdminUsername
-	if adminUsername == "" {
-		h.logger.Warn("ADMIN_USERNAME not configured")
+// canExecute проверяет права пользователя на команду через единую проверку ролей
+func (h *Handlers) canExecute(user *tgbotapi.User, command string) bool {
+	if user == nil {
 		return false
 	}
+	return h.services.Access.CanExecute(user.ID, user.UserName, command)
+}
 
-	// Проверяем username пользователя
-	if user.UserName == "" {
-		h.logger.Warn("User has no username", zap.Int64("user_id", user.ID))
-		return false
+// auditRecord возвращает запись журнала аудита для обрабатываемой команды или nil
+func (h *Handlers) auditRecord(message *tgbotapi.Message) *service.AuditRecord {
+	return h.services.Audit.Record(message.Chat.ID, message.MessageID)
+}
+
+// lang возвращает язык ответа на сообщение: язык группы, если он задан в /chat, иначе язык пользователя
+func (h *Handlers) lang(message *tgbotapi.Message) i18n.Lang {
+	if !message.Chat.IsPrivate() {
+		if lang, ok := h.services.ChatSettings.Language(message.Chat.ID); ok {
+			return lang
+		}
 	}
+	return h.userLang(message.From)
+}
 
-	return user.UserName == adminUsername
+// defaultGenderFilter возвращает фильтр /month по умолчанию: фильтр группы, если он задан в /chat, иначе фильтр пользователя
+func (h *Handlers) defaultGenderFilter(message *tgbotapi.Message) (femaleOnly, maleOnly bool) {
+	if !message.Chat.IsPrivate() {
+		if femaleOnly, maleOnly, ok := h.services.ChatSettings.DefaultGenderFilter(message.Chat.ID); ok {
+			return femaleOnly, maleOnly
+		}
+	}
+	return h.services.Settings.DefaultGenderFilter(message.From.ID)
+}
+
+// userLang возвращает язык интерфейса пользователя
+func (h *Handlers) userLang(user *tgbotapi.User) i18n.Lang {
+	if user == nil {
+		return i18n.Default
+	}
+	return h.services.Locale.Resolve(user.ID, user.LanguageCode)
 }
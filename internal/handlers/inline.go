@@ -0,0 +1,49 @@
+// Package handlers содержит обработчик inline режима.
+package handlers
+
+import (
+	"fmt"
+	"gemfactory/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// inlineCacheSeconds время кэширования ответа на inline запрос на стороне Telegram
+const inlineCacheSeconds = 300
+
+// InlineQuery отвечает на inline запрос @bot артист карточками релизов, которые можно отправить в любой чат
+func (h *Handlers) InlineQuery(query *tgbotapi.InlineQuery) error {
+	if h.botAPI == nil || query.From == nil {
+		return nil
+	}
+
+	lang := h.userLang(query.From)
+	releases, err := h.services.Release.SearchInline(query.Query)
+	if err != nil {
+		h.logger.Error("Failed to search releases for inline query",
+			zap.String("query", query.Query),
+			zap.Error(err))
+		return err
+	}
+
+	results := make([]any, 0, min(len(releases), service.MaxInlineResults))
+	for _, release := range releases {
+		card := h.services.Release.FormatReleaseCard(release, query.From.ID, lang)
+		article := tgbotapi.NewInlineQueryResultArticleHTML(fmt.Sprintf("release_%d", release.ReleaseID), card.Title, card.Text)
+		article.Description = card.Description
+		results = append(results, article)
+	}
+
+	// Время релиза показывается в часовом поясе пользователя, поэтому ответ персональный
+	err = h.botAPI.AnswerInlineQuery(tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		Results:       results,
+		CacheTime:     inlineCacheSeconds,
+		IsPersonal:    true,
+	})
+	if err != nil {
+		h.logger.Warn("Failed to answer inline query", zap.String("query", query.Query), zap.Error(err))
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+// Package handlers содержит обработчик выбора языка интерфейса.
+package handlers
+
+import (
+	"gemfactory/internal/i18n"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Lang обрабатывает команду /lang: без аргументов показывает текущий язык, /lang ru|en меняет его
+func (h *Handlers) Lang(message *tgbotapi.Message) {
+	if message.From == nil {
+		return
+	}
+
+	current := h.userLang(message.From)
+	args := strings.Fields(message.CommandArguments())
+	if len(args) == 0 {
+		h.sendMessage(message.Chat.ID, current.T("lang.current", current.Name()))
+		return
+	}
+
+	lang, ok := i18n.Parse(args[0])
+	if !ok {
+		h.sendMessage(message.Chat.ID, current.T("lang.usage"))
+		return
+	}
+
+	if err := h.services.Locale.SetLanguage(message.From.ID, message.From.UserName, lang); err != nil {
+		h.logger.Error("Failed to set language", zap.Int64("user_id", message.From.ID), zap.Error(err))
+		h.sendMessage(message.Chat.ID, current.T("lang.error"))
+		return
+	}
+
+	h.sendMessageWithMarkup(message.Chat.ID, lang.T("lang.changed", lang.Name()), h.mainKeyboard(lang))
+}
@@ -4,6 +4,7 @@ package handlers
 import (
 	"gemfactory/internal/config"
 	"gemfactory/internal/external/telegram"
+	"gemfactory/internal/i18n"
 	"gemfactory/internal/keyboard"
 	"gemfactory/internal/service"
 
@@ -37,16 +38,17 @@ func RegisterRoutesWithBotAPI(services *service.Services, config *config.Config,
 	return handlers
 }
 
-// RegisterBotCommands регистрирует команды бота
-func (h *Handlers) RegisterBotCommands() []tgbotapi.BotCommand {
-	return []tgbotapi.BotCommand{
-		{Command: "start", Description: "Начать работу с ботом"},
-		{Command: "help", Description: "Показать справку"},
-		{Command: "month", Description: "Получить релизы за месяц"},
-		{Command: "search", Description: "Поиск релизов по артисту"},
-		{Command: "artists", Description: "Показать списки артистов"},
-		{Command: "metrics", Description: "Показать метрики системы"},
-		{Command: "homework", Description: "Получить случайное домашнее задание"},
-		{Command: "playlist", Description: "Информация о плейлисте"},
+// botCommands команды меню бота, описания берутся из каталога по ключу command.<команда>
+var botCommands = []string{
+	"start", "help", "month", "search", "artists", "metrics", "homework", "playlist",
+	"subscribe", "unsubscribe", "subscriptions", "calendar", "lang", "settings", "digest", "remind", "chat",
+}
+
+// RegisterBotCommands возвращает команды бота с описаниями на указанном языке
+func (h *Handlers) RegisterBotCommands(lang i18n.Lang) []tgbotapi.BotCommand {
+	commands := make([]tgbotapi.BotCommand, 0, len(botCommands))
+	for _, command := range botCommands {
+		commands = append(commands, tgbotapi.BotCommand{Command: command, Description: lang.T("command." + command)})
 	}
+	return commands
 }
@@ -0,0 +1,192 @@
+// Package handlers содержит обработчик связей артистов.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/service"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Relation управляет связями артистов: солистами, саб-юнитами и совместными проектами
+func (h *Handlers) Relation(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "relation") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	arguments := strings.TrimSpace(message.CommandArguments())
+	action, rest, _ := strings.Cut(arguments, " ")
+
+	switch strings.ToLower(action) {
+	case "add":
+		h.addRelation(message, lang, rest)
+	case "remove":
+		h.removeRelation(message, lang, rest)
+	case "include":
+		h.includeRelated(message, lang, rest)
+	default:
+		h.listRelations(message.Chat.ID, lang, arguments)
+	}
+}
+
+// addRelation связывает артиста с группой
+func (h *Handlers) addRelation(message *tgbotapi.Message, lang i18n.Lang, arguments string) {
+	typeName, rest, _ := strings.Cut(strings.TrimSpace(arguments), " ")
+	relationType := model.RelationType(strings.ToLower(typeName))
+	artistName, parentName, ok := strings.Cut(rest, "=")
+	artistName, parentName = strings.TrimSpace(artistName), strings.TrimSpace(parentName)
+	if !relationType.IsValid() || !ok || artistName == "" || parentName == "" {
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("relation.usage"))
+		return
+	}
+
+	audit := h.auditRecord(message)
+	audit.SetBefore(fmt.Sprintf("%s %s = %s", relationType, artistName, parentName))
+
+	relation, created, err := h.services.Artist.AddRelation(artistName, parentName, relationType)
+	switch {
+	case err == nil && relation == nil:
+		audit.Invalid("artist not found")
+		h.sendMessage(message.Chat.ID, lang.T("admin.artist_not_found", html.EscapeString(parentName)))
+		return
+	case errors.Is(err, service.ErrSelfRelation):
+		audit.Invalid(err.Error())
+		h.sendMessage(message.Chat.ID, lang.T("relation.self"))
+		return
+	case err != nil:
+		audit.Fail(err)
+		h.logger.Error("Failed to add artist relation", zap.String("artist", artistName), zap.String("parent", parentName), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("relation.add_error", err))
+		return
+	}
+
+	audit.SetAfter(fmt.Sprintf("relation %d, artist created: %t", relation.RelationID, created))
+
+	text := lang.T("relation.added", html.EscapeString(relation.Artist.Name),
+		lang.T("relation."+string(relation.Type)), html.EscapeString(relation.Parent.Name))
+	if created {
+		text += lang.T("relation.created_inactive", html.EscapeString(relation.Parent.Name))
+	}
+	h.sendMessage(message.Chat.ID, text)
+}
+
+// removeRelation удаляет связь артиста с группой
+func (h *Handlers) removeRelation(message *tgbotapi.Message, lang i18n.Lang, arguments string) {
+	artistName, parentName, ok := strings.Cut(arguments, "=")
+	artistName, parentName = strings.TrimSpace(artistName), strings.TrimSpace(parentName)
+	if !ok || artistName == "" || parentName == "" {
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("relation.usage"))
+		return
+	}
+
+	audit := h.auditRecord(message)
+	audit.SetBefore(fmt.Sprintf("%s = %s", artistName, parentName))
+
+	removed, err := h.services.Artist.RemoveRelation(artistName, parentName)
+	if err != nil {
+		audit.Fail(err)
+		h.logger.Error("Failed to remove artist relation", zap.String("artist", artistName), zap.String("parent", parentName), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("relation.remove_error", err))
+		return
+	}
+
+	if !removed {
+		audit.Invalid("relation not found")
+		h.sendMessage(message.Chat.ID, lang.T("relation.not_found", html.EscapeString(artistName), html.EscapeString(parentName)))
+		return
+	}
+
+	audit.SetAfter("removed")
+	h.sendMessage(message.Chat.ID, lang.T("relation.removed", html.EscapeString(artistName), html.EscapeString(parentName)))
+}
+
+// includeRelated включает или выключает для группы список солистов, юнитов и совместных проектов
+func (h *Handlers) includeRelated(message *tgbotapi.Message, lang i18n.Lang, arguments string) {
+	arguments = strings.TrimSpace(arguments)
+	separator := strings.LastIndex(arguments, " ")
+	if separator < 0 {
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("relation.usage"))
+		return
+	}
+
+	artistName := strings.TrimSpace(arguments[:separator])
+	var include bool
+	switch strings.ToLower(arguments[separator+1:]) {
+	case "on":
+		include = true
+	case "off":
+		include = false
+	default:
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("relation.usage"))
+		return
+	}
+
+	audit := h.auditRecord(message)
+	audit.SetBefore(artistName)
+
+	changed, err := h.services.Artist.SetIncludeRelated([]string{artistName}, include)
+	if err != nil {
+		audit.Fail(err)
+		h.logger.Error("Failed to change related artists inclusion", zap.String("artist", artistName), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("relation.include_error", err))
+		return
+	}
+
+	audit.SetAfter(fmt.Sprintf("include_related: %t, changed: %d", include, changed))
+
+	if include {
+		h.sendMessage(message.Chat.ID, lang.T("relation.included", html.EscapeString(artistName)))
+		return
+	}
+	h.sendMessage(message.Chat.ID, lang.T("relation.excluded", html.EscapeString(artistName)))
+}
+
+// listRelations показывает связи артиста или все связи, сгруппированные по родителю
+func (h *Handlers) listRelations(chatID int64, lang i18n.Lang, artistName string) {
+	relations, err := h.services.Artist.GetRelations(artistName)
+	if err != nil {
+		h.logger.Error("Failed to get artist relations", zap.String("artist", artistName), zap.Error(err))
+		h.sendMessage(chatID, lang.T("relation.list_error"))
+		return
+	}
+
+	if len(relations) == 0 {
+		h.sendMessage(chatID, lang.T("relation.empty")+lang.T("relation.usage"))
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(lang.T("relation.title"))
+
+	currentParent := 0
+	for _, relation := range relations {
+		if relation.Artist == nil || relation.Parent == nil {
+			continue
+		}
+		if relation.ParentID != currentParent {
+			currentParent = relation.ParentID
+			parent := html.EscapeString(relation.Parent.Name)
+			if relation.Parent.IncludeRelated {
+				parent += lang.T("relation.with_related")
+			}
+			text.WriteString(fmt.Sprintf("\n<b>%s</b>\n", parent))
+		}
+		text.WriteString(fmt.Sprintf("• %s - %s\n", html.EscapeString(relation.Artist.Name), lang.T("relation."+string(relation.Type))))
+	}
+
+	h.sendMessage(chatID, text.String())
+}
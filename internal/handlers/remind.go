@@ -0,0 +1,57 @@
+// Package handlers содержит обработчик напоминаний о релизах.
+package handlers
+
+import (
+	"gemfactory/internal/model"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Remind обрабатывает команду /remind: без аргументов показывает статус, /remind <минуты> включает напоминания, /remind off отключает
+func (h *Handlers) Remind(message *tgbotapi.Message) {
+	if message.From == nil {
+		return
+	}
+
+	lang := h.lang(message)
+	userID := message.From.ID
+	args := strings.Fields(message.CommandArguments())
+
+	if len(args) == 0 {
+		if minutes := h.services.Settings.Get(userID).ReminderMinutes; minutes > 0 {
+			h.sendMessage(message.Chat.ID, lang.T("remind.status_on", minutes))
+		} else {
+			h.sendMessage(message.Chat.ID, lang.T("remind.status_off"))
+		}
+		return
+	}
+
+	minutes := 0
+	if strings.ToLower(args[0]) != "off" {
+		value, err := strconv.Atoi(args[0])
+		if err != nil || value <= 0 || value > model.MaxReminderMinutes {
+			h.sendMessage(message.Chat.ID, lang.T("remind.usage", model.MaxReminderMinutes))
+			return
+		}
+		minutes = value
+	}
+
+	if err := h.services.Settings.SetReminderMinutes(userID, minutes); err != nil {
+		h.logger.Error("Failed to update reminder settings", zap.Int64("user_id", userID), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("settings.error"))
+		return
+	}
+
+	if err := h.services.Reminder.SyncUser(userID); err != nil {
+		h.logger.Error("Failed to reschedule reminders", zap.Int64("user_id", userID), zap.Error(err))
+	}
+
+	if minutes > 0 {
+		h.sendMessage(message.Chat.ID, lang.T("remind.enabled", minutes))
+	} else {
+		h.sendMessage(message.Chat.ID, lang.T("remind.disabled"))
+	}
+}
@@ -0,0 +1,120 @@
+// Package handlers содержит обработчики команд управления ролями.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/service"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Grant обрабатывает команду /grant
+func (h *Handlers) Grant(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "grant") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	args := strings.Fields(message.CommandArguments())
+	if len(args) != 2 {
+		h.sendMessage(message.Chat.ID, lang.T("grant.usage")+h.formatStaff(lang))
+		return
+	}
+
+	role := model.Role(strings.ToLower(args[1]))
+	audit := h.auditRecord(message)
+	user, err := h.services.Access.Grant(message.From.ID, message.From.UserName, args[0], role)
+	if err != nil {
+		audit.Fail(err)
+		h.sendMessage(message.Chat.ID, h.roleChangeError(lang, args[0], err))
+		return
+	}
+
+	audit.SetAfter(fmt.Sprintf("%d: %s", user.UserID, user.Role))
+
+	h.sendMessage(message.Chat.ID, lang.T("grant.done", html.EscapeString(user.DisplayName()), roleTitle(lang, user.Role)))
+}
+
+// Revoke обрабатывает команду /revoke
+func (h *Handlers) Revoke(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "revoke") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	args := strings.Fields(message.CommandArguments())
+	if len(args) != 1 {
+		h.sendMessage(message.Chat.ID, lang.T("revoke.usage"))
+		return
+	}
+
+	audit := h.auditRecord(message)
+	user, err := h.services.Access.Revoke(message.From.ID, message.From.UserName, args[0])
+	if err != nil {
+		audit.Fail(err)
+		h.sendMessage(message.Chat.ID, h.roleChangeError(lang, args[0], err))
+		return
+	}
+
+	audit.SetAfter(fmt.Sprintf("%d: %s", user.UserID, user.Role))
+
+	h.sendMessage(message.Chat.ID, lang.T("revoke.done", html.EscapeString(user.DisplayName()), roleTitle(lang, user.Role)))
+}
+
+// roleChangeError возвращает текст ошибки изменения роли
+func (h *Handlers) roleChangeError(lang i18n.Lang, target string, err error) string {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return lang.T("roles.user_not_found", html.EscapeString(target))
+	case errors.Is(err, service.ErrRoleNotGrantable):
+		return lang.T("roles.not_grantable")
+	case errors.Is(err, service.ErrInsufficientRole):
+		return lang.T("roles.insufficient")
+	case errors.Is(err, service.ErrCannotChangeSelf):
+		return lang.T("roles.self")
+	case errors.Is(err, service.ErrRoleAlreadyActive):
+		return lang.T("roles.unchanged")
+	default:
+		h.logger.Error("Failed to change user role", zap.String("target", target), zap.Error(err))
+		return lang.T("roles.error", err)
+	}
+}
+
+// roleTitle возвращает название роли для сообщений
+func roleTitle(lang i18n.Lang, role model.Role) string {
+	return lang.T("role." + string(role))
+}
+
+// formatStaff возвращает список пользователей с ролями
+func (h *Handlers) formatStaff(lang i18n.Lang) string {
+	users, err := h.services.Access.GetStaff()
+	if err != nil {
+		h.logger.Error("Failed to get staff", zap.Error(err))
+		return lang.T("roles.staff_error")
+	}
+
+	if len(users) == 0 {
+		return lang.T("roles.staff_empty")
+	}
+
+	var text strings.Builder
+	text.WriteString(lang.T("roles.staff_title"))
+	for _, user := range users {
+		text.WriteString(lang.T("roles.staff_entry",
+			html.EscapeString(user.DisplayName()), strconv.FormatInt(user.UserID, 10), roleTitle(lang, user.Role)))
+	}
+	return text.String()
+}
@@ -0,0 +1,46 @@
+// Package handlers содержит обработчик пользовательских настроек.
+package handlers
+
+import (
+	"errors"
+	"gemfactory/internal/service"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Settings обрабатывает команду /settings: показывает меню настроек,
+// /settings tz <часовой пояс> задает произвольный часовой пояс IANA
+func (h *Handlers) Settings(message *tgbotapi.Message) {
+	if message.From == nil {
+		return
+	}
+
+	lang := h.lang(message)
+	userID := message.From.ID
+	args := strings.Fields(message.CommandArguments())
+
+	if len(args) > 0 {
+		if strings.ToLower(args[0]) != "tz" || len(args) != 2 {
+			h.sendMessage(message.Chat.ID, lang.T("settings.usage"))
+			return
+		}
+
+		err := h.services.Settings.SetTimezone(userID, args[1])
+		if errors.Is(err, service.ErrInvalidTimezone) {
+			h.sendMessage(message.Chat.ID, lang.T("settings.tz_invalid", html.EscapeString(args[1])))
+			return
+		}
+		if err != nil {
+			h.logger.Error("Failed to set timezone", zap.Int64("user_id", userID), zap.Error(err))
+			h.sendMessage(message.Chat.ID, lang.T("settings.error"))
+			return
+		}
+	}
+
+	settings := h.services.Settings.Get(userID)
+	text := h.services.Settings.FormatSettings(settings, lang)
+	h.sendMessageWithMarkup(message.Chat.ID, text, h.keyboard.GetSettingsKeyboard(settings, lang))
+}
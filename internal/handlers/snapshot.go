@@ -0,0 +1,86 @@
+// Package handlers содержит обработчики снимков страниц расписания.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"gemfactory/internal/service"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Ограничения списка /snapshots
+const (
+	defaultSnapshotsLimit = 10
+	maxSnapshotsLimit     = 30
+)
+
+// Snapshots показывает последние сохраненные снимки страниц расписания
+func (h *Handlers) Snapshots(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "snapshots") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	limit := defaultSnapshotsLimit
+	if arguments := strings.TrimSpace(message.CommandArguments()); arguments != "" {
+		parsed, err := strconv.Atoi(arguments)
+		if err != nil || parsed < 1 || parsed > maxSnapshotsLimit {
+			h.auditRecord(message).Invalid("usage")
+			h.sendMessage(message.Chat.ID, lang.T("snapshots.usage", maxSnapshotsLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	snapshots, err := h.services.Snapshot.GetRecent(limit)
+	if err != nil {
+		h.logger.Error("Failed to get page snapshots", zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("snapshots.error"))
+		return
+	}
+
+	h.sendMessage(message.Chat.ID, h.services.Snapshot.FormatRecent(snapshots))
+}
+
+// Replay повторно разбирает сохраненный снимок страницы без обращения к сайту, релизы не сохраняются
+func (h *Handlers) Replay(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
+	// Проверка прав доступа
+	if !h.canExecute(message.From, "replay") {
+		h.sendMessage(message.Chat.ID, lang.T("admin.forbidden"))
+		return
+	}
+
+	snapshotID, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(message.CommandArguments()), "#"))
+	if err != nil || snapshotID < 1 {
+		h.auditRecord(message).Invalid("usage")
+		h.sendMessage(message.Chat.ID, lang.T("replay.usage"))
+		return
+	}
+
+	h.sendMessage(message.Chat.ID, lang.T("replay.started", strconv.Itoa(snapshotID)))
+
+	// Блоки, которых нет в кэше LLM, разбираются заново, поэтому запускаем в горутине
+	go func() {
+		result, err := h.services.Release.ReplaySnapshot(context.Background(), snapshotID)
+		switch {
+		case errors.Is(err, service.ErrSnapshotsDisabled):
+			h.sendMessage(message.Chat.ID, lang.T("replay.disabled"))
+		case err != nil:
+			h.logger.Error("Failed to replay page snapshot", zap.Int("snapshot_id", snapshotID), zap.Error(err))
+			h.sendMessage(message.Chat.ID, lang.T("replay.error", err))
+		case result == nil:
+			h.sendMessage(message.Chat.ID, lang.T("replay.not_found", strconv.Itoa(snapshotID)))
+		default:
+			h.sendMessage(message.Chat.ID, h.services.Release.FormatReplay(result)+formatNearMisses(lang, result.NearMisses))
+		}
+	}()
+}
@@ -0,0 +1,92 @@
+// Package handlers содержит обработчики команд подписок.
+package handlers
+
+import (
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Subscribe обрабатывает команду /subscribe
+func (h *Handlers) Subscribe(message *tgbotapi.Message) {
+	lang := h.lang(message)
+	artistName := strings.TrimSpace(message.CommandArguments())
+
+	// Флаг -all подписывает также на солистов, юниты и совместные проекты
+	includeRelated := false
+	if name, ok := strings.CutSuffix(artistName, " -all"); ok {
+		artistName, includeRelated = strings.TrimSpace(name), true
+	}
+	if artistName == "" || artistName == "-all" {
+		h.sendMessage(message.Chat.ID, lang.T("subscribe.usage"))
+		return
+	}
+
+	artist, created, err := h.services.Subscription.Subscribe(message.From.ID, message.Chat.ID, artistName, includeRelated)
+	if err != nil {
+		h.logger.Error("Failed to subscribe", zap.String("artist", artistName), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("subscribe.error", err))
+		return
+	}
+
+	if artist == nil {
+		h.sendMessage(message.Chat.ID, lang.T("subscribe.not_found", html.EscapeString(artistName)))
+		return
+	}
+
+	if !created {
+		h.sendMessage(message.Chat.ID, lang.T("subscribe.already", html.EscapeString(artist.Name)))
+		return
+	}
+
+	if includeRelated {
+		h.sendMessage(message.Chat.ID, lang.T("subscribe.done_related", html.EscapeString(artist.Name)))
+		return
+	}
+	h.sendMessage(message.Chat.ID, lang.T("subscribe.done", html.EscapeString(artist.Name)))
+}
+
+// Unsubscribe обрабатывает команду /unsubscribe
+func (h *Handlers) Unsubscribe(message *tgbotapi.Message) {
+	lang := h.lang(message)
+	artistName := strings.TrimSpace(message.CommandArguments())
+	if artistName == "" {
+		h.sendMessage(message.Chat.ID, lang.T("unsubscribe.usage"))
+		return
+	}
+
+	artist, removed, err := h.services.Subscription.Unsubscribe(message.From.ID, artistName)
+	if err != nil {
+		h.logger.Error("Failed to unsubscribe", zap.String("artist", artistName), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("unsubscribe.error", err))
+		return
+	}
+
+	if artist == nil || !removed {
+		h.sendMessage(message.Chat.ID, lang.T("unsubscribe.not_subscribed", html.EscapeString(artistName)))
+		return
+	}
+
+	h.sendMessage(message.Chat.ID, lang.T("unsubscribe.done", html.EscapeString(artist.Name)))
+}
+
+// Subscriptions показывает подписки пользователя с кнопками отписки
+func (h *Handlers) Subscriptions(message *tgbotapi.Message) {
+	lang := h.lang(message)
+	subscriptions, err := h.services.Subscription.GetUserSubscriptions(message.From.ID)
+	if err != nil {
+		h.logger.Error("Failed to get subscriptions", zap.Int64("user_id", message.From.ID), zap.Error(err))
+		h.sendMessage(message.Chat.ID, lang.T("subscriptions.error"))
+		return
+	}
+
+	text := h.services.Subscription.FormatSubscriptions(subscriptions, lang)
+	if len(subscriptions) == 0 {
+		h.sendMessage(message.Chat.ID, text)
+		return
+	}
+
+	h.sendMessageWithMarkup(message.Chat.ID, text, h.keyboard.GetSubscriptionsKeyboard(subscriptions))
+}
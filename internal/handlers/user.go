@@ -3,6 +3,7 @@ package handlers
 
 import (
 	"fmt"
+	"gemfactory/internal/model"
 	"math/rand"
 	"strings"
 	"time"
@@ -13,40 +14,39 @@ import (
 
 // Start обрабатывает команду /start
 func (h *Handlers) Start(message *tgbotapi.Message) {
-	text := "Добро пожаловать! Выберите месяц:"
-	h.sendMessageWithMarkup(message.Chat.ID, text, h.getMainKeyboard())
+	lang := h.lang(message)
+	h.sendMessageWithMarkup(message.Chat.ID, lang.T("start.text"), h.mainKeyboard(lang))
 }
 
 // Help обрабатывает команду /help
 func (h *Handlers) Help(message *tgbotapi.Message) {
-	text := "Доступные команды:\n" +
-		"\n/start - Начать работу с ботом\n" +
-		"/help - Показать это сообщение\n" +
-		"/month [месяц] - Получить релизы за указанный месяц текущего года\n" +
-		"/month [месяц] [год] - Получить релизы за указанный месяц и год\n" +
-		"/month [месяц] -f - Релизы только женских групп за текущий год\n" +
-		"/month [месяц] -m - Релизы только мужских групп за текущий год\n" +
-		"/search [артист] - Поиск релизов по артисту\n" +
-		"/artists - Показать списки артистов\n" +
-		"/metrics - Показать метрики системы\n" +
-		"/homework - Получить случайное домашнее задание\n" +
-		"/playlist - Информация о плейлисте\n" +
-		"\n" +
-		fmt.Sprintf("По вопросам вайтлистов: @%s", h.getAdminUsername())
-	h.sendMessageWithMarkup(message.Chat.ID, text, h.getMainKeyboard())
+	lang := h.lang(message)
+	h.sendMessageWithMarkup(message.Chat.ID, lang.T("help.text", h.getAdminUsername()), h.mainKeyboard(lang))
 }
 
 // Month обрабатывает команду /month
 func (h *Handlers) Month(message *tgbotapi.Message) {
+	lang := h.lang(message)
 	args := strings.Fields(message.CommandArguments())
 	if len(args) == 0 {
-		h.sendMessageWithMarkup(message.Chat.ID, "Пожалуйста, выберите месяц:", h.getMainKeyboard())
+		h.sendMessageWithMarkup(message.Chat.ID, lang.T("month.choose"), h.mainKeyboard(lang))
+		return
+	}
+
+	args, releaseType, ok := extractReleaseTypeFlag(args)
+	if !ok {
+		h.sendMessage(message.Chat.ID, lang.T("releases.type_usage"))
+		return
+	}
+	if len(args) == 0 {
+		h.sendMessageWithMarkup(message.Chat.ID, lang.T("month.choose"), h.mainKeyboard(lang))
 		return
 	}
 
 	month := strings.ToLower(args[0])
 	femaleOnly := false
 	maleOnly := false
+	allArtists := false
 	year := ""
 
 	// Обрабатываем аргументы
@@ -56,6 +56,8 @@ func (h *Handlers) Month(message *tgbotapi.Message) {
 			femaleOnly = true
 		case "-m":
 			maleOnly = true
+		case "-a":
+			allArtists = true
 		default:
 			// Если это не флаг, то это может быть год
 			if year == "" && i == 0 {
@@ -74,106 +76,126 @@ func (h *Handlers) Month(message *tgbotapi.Message) {
 		monthQuery = fmt.Sprintf("%s-%d", month, currentYear)
 	}
 
-	response, err := h.services.Release.GetReleasesForMonth(monthQuery, femaleOnly, maleOnly)
-	if err != nil {
+	// Без флагов применяется фильтр группы из /chat или пользователя из /settings, -a показывает всех артистов
+	if !femaleOnly && !maleOnly && !allArtists {
+		femaleOnly, maleOnly = h.defaultGenderFilter(message)
+	}
+
+	// Длинный список релизов разбивается на страницы с кнопками листания
+	if err := h.keyboard.SendMonthReleases(message.Chat.ID, message.From.ID, monthQuery, femaleOnly, maleOnly, releaseType, lang); err != nil {
 		h.logger.Error("Failed to get releases", zap.Error(err))
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("Ошибка: %v", err))
-		return
+		h.sendMessage(message.Chat.ID, lang.T("common.error", err))
 	}
+}
 
-	h.sendMessageWithMarkup(message.Chat.ID, response, h.getMainKeyboard())
+// extractReleaseTypeFlag извлекает из аргументов фильтр "-t тип" (/month october -t album).
+// Возвращает остальные аргументы; ok = false, если тип не указан или неизвестен
+func extractReleaseTypeFlag(args []string) (rest []string, releaseType model.ReleaseType, ok bool) {
+	for i := 0; i < len(args); i++ {
+		if args[i] != "-t" {
+			rest = append(rest, args[i])
+			continue
+		}
+		if i+1 >= len(args) {
+			return nil, "", false
+		}
+		i++
+		if releaseType, ok = model.ParseReleaseType(args[i]); !ok {
+			return nil, "", false
+		}
+	}
+	return rest, releaseType, true
 }
 
 // Artists показывает списки артистов
 func (h *Handlers) Artists(message *tgbotapi.Message) {
-	response := h.services.Artist.FormatArtists()
-	h.sendMessageWithMarkup(message.Chat.ID, response, h.getMainKeyboard())
+	lang := h.lang(message)
+	response := h.services.Artist.FormatArtists(lang)
+	h.sendMessageWithMarkup(message.Chat.ID, response, h.mainKeyboard(lang))
 }
 
 // Metrics показывает метрики системы
 func (h *Handlers) Metrics(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
 	var text strings.Builder
-	text.WriteString("📊 *Метрики системы*\n\n")
+	text.WriteString(lang.T("metrics.title"))
 
 	// Получаем реальные данные об артистах
 	femaleCount, maleCount, totalCount, err := h.services.Artist.GetArtistCounts()
+	text.WriteString(lang.T("metrics.artists"))
 	if err != nil {
 		h.logger.Error("Failed to get artist counts", zap.Error(err))
-		text.WriteString("🎤 *Артисты в фильтрах:*\n")
-		text.WriteString("  • Ошибка получения данных\n\n")
+		text.WriteString(lang.T("metrics.data_error"))
 	} else {
-		text.WriteString("🎤 *Артисты в фильтрах:*\n")
-		text.WriteString(fmt.Sprintf("  • Женские группы: %d\n", femaleCount))
-		text.WriteString(fmt.Sprintf("  • Мужские группы: %d\n", maleCount))
-		text.WriteString(fmt.Sprintf("  • Всего артистов: %d\n\n", totalCount))
+		text.WriteString(lang.T("metrics.artist_counts", femaleCount, maleCount, totalCount))
 	}
 
 	// Получаем данные о релизах
 	releaseCount, err := h.services.Release.GetTotalReleaseCount()
+	text.WriteString(lang.T("metrics.releases"))
 	if err != nil {
 		h.logger.Error("Failed to get release count", zap.Error(err))
-		text.WriteString("💿 *Релизы в базе:*\n")
-		text.WriteString("  • Ошибка получения данных\n\n")
+		text.WriteString(lang.T("metrics.data_error"))
 	} else {
-		text.WriteString("💿 *Релизы в базе:*\n")
-		text.WriteString(fmt.Sprintf("  • Количество релизов: %d\n\n", releaseCount))
+		text.WriteString(lang.T("metrics.release_count", releaseCount))
 	}
 
 	// Получаем метрики LLM
 	llmMetrics := h.services.Release.GetLLMMetrics()
-	text.WriteString("🤖 *LLM метрики:*\n")
+	text.WriteString(lang.T("metrics.llm"))
 	if errorMsg, ok := llmMetrics["error"]; ok {
-		text.WriteString(fmt.Sprintf("  • Ошибка: %v\n\n", errorMsg))
+		text.WriteString(lang.T("metrics.llm_error", errorMsg))
 	} else {
-		text.WriteString(fmt.Sprintf("  • Всего запросов: %v\n", llmMetrics["total_requests"]))
-		text.WriteString(fmt.Sprintf("  • Успешных: %v\n", llmMetrics["successful_requests"]))
-		text.WriteString(fmt.Sprintf("  • Неудачных: %v\n", llmMetrics["failed_requests"]))
+		text.WriteString(lang.T("metrics.llm_requests",
+			fmt.Sprint(llmMetrics["total_requests"]),
+			fmt.Sprint(llmMetrics["successful_requests"]),
+			fmt.Sprint(llmMetrics["failed_requests"])))
 
 		if lastRequest, ok := llmMetrics["last_request_time"]; ok {
 			if lastTime, ok := lastRequest.(time.Time); ok && !lastTime.IsZero() {
-				text.WriteString(fmt.Sprintf("  • Последний запрос: %s\n", lastTime.Format("15:04:05")))
+				text.WriteString(lang.T("metrics.llm_last", lastTime.Format("15:04:05")))
 			} else {
-				text.WriteString("  • Последний запрос: никогда\n")
+				text.WriteString(lang.T("metrics.llm_never"))
 			}
 		}
 
 		if delay, ok := llmMetrics["delay_ms"]; ok {
-			text.WriteString(fmt.Sprintf("  • Задержка: %v мс\n", delay))
+			text.WriteString(lang.T("metrics.llm_delay", fmt.Sprint(delay)))
+		}
+		if hits, ok := llmMetrics["cache_hits"]; ok {
+			text.WriteString(lang.T("metrics.llm_cache", fmt.Sprint(hits), fmt.Sprint(llmMetrics["cache_misses"])))
 		}
 		text.WriteString("\n")
 	}
 
 	// Получаем данные о домашних заданиях
 	homeworkStats, err := h.services.Homework.GetHomeworkStats()
+	text.WriteString(lang.T("metrics.homework"))
 	if err != nil {
 		h.logger.Error("Failed to get homework stats", zap.Error(err))
-		text.WriteString("📚 *Домашние задания:*\n")
-		text.WriteString("  • Ошибка получения данных\n\n")
+		text.WriteString(lang.T("metrics.data_error"))
 	} else {
-		text.WriteString("📚 *Домашние задания:*\n")
-		text.WriteString(fmt.Sprintf("  • Всего выдано: %d\n", homeworkStats.TotalAssigned))
-		text.WriteString(fmt.Sprintf("  • Уникальных пользователей: %d\n\n", homeworkStats.UniqueUsers))
+		text.WriteString(lang.T("metrics.homework_counts", homeworkStats.TotalAssigned, homeworkStats.UniqueUsers))
 	}
 
 	// Статус планировщика
 	if h.services.Scheduler != nil {
 		schedulerStatus := h.services.Scheduler.GetStatus()
-		text.WriteString("🔄 *Планировщик:*\n")
+		text.WriteString(lang.T("metrics.scheduler"))
 		if isRunning, ok := schedulerStatus["running"].(bool); ok && isRunning {
-			text.WriteString("  • Статус: Активен\n")
+			text.WriteString(lang.T("metrics.scheduler_active"))
 			if taskCount, ok := schedulerStatus["tasks_count"].(int); ok {
-				text.WriteString(fmt.Sprintf("  • Активных задач: %d\n", taskCount))
+				text.WriteString(lang.T("metrics.scheduler_tasks", taskCount))
 			}
 		} else {
-			text.WriteString("  • Статус: Неактивен\n")
+			text.WriteString(lang.T("metrics.scheduler_inactive"))
 		}
 		text.WriteString("\n")
 	}
 
 	// Время работы системы (примерное)
-	text.WriteString("⚡ *Система:*\n")
-	text.WriteString("  • Статус: Работает\n")
-	text.WriteString(fmt.Sprintf("  • Время: %s\n", time.Now().Format("15:04:05")))
+	text.WriteString(lang.T("metrics.system", time.Now().Format("15:04:05")))
 
 	h.sendMessage(message.Chat.ID, text.String())
 }
@@ -181,11 +203,12 @@ func (h *Handlers) Metrics(message *tgbotapi.Message) {
 // Homework выдает случайное домашнее задание
 func (h *Handlers) Homework(message *tgbotapi.Message) {
 	userID := message.From.ID
+	lang := h.lang(message)
 
 	canRequest, err := h.services.Homework.CanRequestHomework(userID)
 	if err != nil {
 		h.logger.Error("Failed to check homework request", zap.Error(err))
-		h.sendMessage(message.Chat.ID, "❌ Ошибка при проверке возможности запроса домашнего задания.")
+		h.sendMessage(message.Chat.ID, lang.T("homework.check_error"))
 		return
 	}
 	if !canRequest {
@@ -195,9 +218,9 @@ func (h *Handlers) Homework(message *tgbotapi.Message) {
 
 		var timeMessage string
 		if hours > 0 {
-			timeMessage = fmt.Sprintf("%d ч %d мин", hours, minutes)
+			timeMessage = lang.T("homework.wait_hours", hours, minutes)
 		} else {
-			timeMessage = fmt.Sprintf("%d мин", minutes)
+			timeMessage = lang.T("homework.wait_minutes", minutes)
 		}
 
 		// Получаем информацию о текущем домашнем задании
@@ -207,33 +230,22 @@ func (h *Handlers) Homework(message *tgbotapi.Message) {
 		}
 		var currentHomework string
 		if homeworkInfo != nil {
-			// Формируем правильное склонение для "раз/раза"
-			var timesWord string
-			switch {
-			case homeworkInfo.PlayCount == 1:
-				timesWord = "раз"
-			case homeworkInfo.PlayCount >= 2 && homeworkInfo.PlayCount <= 4:
-				timesWord = "раза"
-			default:
-				timesWord = "раз"
-			}
-
 			// Создаем ссылку на Spotify для текущего задания
 			spotifyLink := fmt.Sprintf("https://open.spotify.com/track/%s", homeworkInfo.TrackID)
 
-			currentHomework = fmt.Sprintf("\n\n📚 Ваше текущее задание:\n🎵 \"%s - %s\" (<a href=\"%s\">Spotify</a>) %d %s",
-				homeworkInfo.Artist, homeworkInfo.Title, spotifyLink, homeworkInfo.PlayCount, timesWord)
+			// Склонение "раз/раза" выбирается каталогом по правилам языка
+			currentHomework = lang.T("homework.current",
+				homeworkInfo.Artist, homeworkInfo.Title, spotifyLink, lang.T("homework.times", homeworkInfo.PlayCount))
 		}
 
-		h.sendMessageWithReply(message.Chat.ID,
-			fmt.Sprintf("⏰ Вы уже получили домашнее задание сегодня! Следующее задание будет доступно через %s.%s", timeMessage, currentHomework), message.MessageID)
+		h.sendMessageWithReply(message.Chat.ID, lang.T("homework.already", timeMessage, currentHomework), message.MessageID)
 		return
 	}
 
 	homework, err := h.services.Homework.GetRandomHomework(userID)
 	if err != nil {
 		h.logger.Error("Failed to get homework", zap.Error(err))
-		h.sendMessageWithReply(message.Chat.ID, "❌ Ошибка при получении домашнего задания. Попробуйте позже.", message.MessageID)
+		h.sendMessageWithReply(message.Chat.ID, lang.T("homework.error"), message.MessageID)
 		return
 	}
 
@@ -244,30 +256,21 @@ func (h *Handlers) Homework(message *tgbotapi.Message) {
 	// Создаем ссылку на Spotify для встраивания в текст
 	spotifyLink := fmt.Sprintf("https://open.spotify.com/track/%s", homework.TrackID)
 
-	// Правильное склонение для "раз/раза/раз"
-	var timesWord string
-	switch {
-	case homework.PlayCount == 1:
-		timesWord = "раз"
-	case homework.PlayCount >= 2 && homework.PlayCount <= 4:
-		timesWord = "раза"
-	default:
-		timesWord = "раз"
-	}
-
 	// Формируем сообщение с кликабельным Spotify в скобках
-	messageText := fmt.Sprintf("🎲 Ваше домашнее задание: послушать \"%s - %s\" (<a href=\"%s\">Spotify</a>) %s %d %s",
-		homework.Artist, homework.Title, spotifyLink, selectedMusicEmoji, homework.PlayCount, timesWord)
+	messageText := lang.T("homework.assigned",
+		homework.Artist, homework.Title, spotifyLink, selectedMusicEmoji, lang.T("homework.times", homework.PlayCount))
 
 	// Отправляем сообщение с reply к исходному сообщению
-	h.sendMessageWithReplyAndMarkup(message.Chat.ID, messageText, message.MessageID, h.getMainKeyboard())
+	h.sendMessageWithReplyAndMarkup(message.Chat.ID, messageText, message.MessageID, h.mainKeyboard(lang))
 }
 
 // Playlist показывает информацию о плейлисте
 func (h *Handlers) Playlist(message *tgbotapi.Message) {
+	lang := h.lang(message)
+
 	// Проверяем, что сервис плейлиста доступен
 	if h.services.Playlist == nil {
-		h.sendMessageWithReply(message.Chat.ID, "❌ Сервис плейлиста недоступен. Проверьте настройки Spotify.", message.MessageID)
+		h.sendMessageWithReply(message.Chat.ID, lang.T("playlist.unavailable"), message.MessageID)
 		return
 	}
 
@@ -277,9 +280,9 @@ func (h *Handlers) Playlist(message *tgbotapi.Message) {
 
 		// Проверяем тип ошибки для более информативного сообщения
 		if strings.Contains(err.Error(), "Resource not found") {
-			h.sendMessageWithReply(message.Chat.ID, "❌ Плейлист не найден. Проверьте:\n• Существует ли плейлист\n• Доступен ли он публично\n• Правильный ли URL в конфигурации", message.MessageID)
+			h.sendMessageWithReply(message.Chat.ID, lang.T("playlist.not_found"), message.MessageID)
 		} else {
-			h.sendMessageWithReply(message.Chat.ID, "❌ Ошибка при получении информации о плейлисте. Попробуйте позже.", message.MessageID)
+			h.sendMessageWithReply(message.Chat.ID, lang.T("playlist.error"), message.MessageID)
 		}
 		return
 	}
@@ -288,28 +291,29 @@ func (h *Handlers) Playlist(message *tgbotapi.Message) {
 	spotifyPlaylistLink := fmt.Sprintf("https://open.spotify.com/playlist/%s", info.SpotifyID)
 
 	// Формируем сообщение с информацией о плейлисте
-	messageText := fmt.Sprintf("📚 Информация о плейлисте:\n\n"+
-		"🎵 Название: %s\n"+
-		"📊 Количество треков: %d\n"+
-		"👤 Владелец: %s\n"+
-		"📝 Описание: %s\n\n"+
-		"🔗 Ссылка: (<a href=\"%s\">Открыть в Spotify</a>)",
+	messageText := lang.T("playlist.info",
 		info.Name, info.TrackCount, info.Owner, info.Description, spotifyPlaylistLink)
 
-	h.sendMessageWithReplyAndMarkup(message.Chat.ID, messageText, message.MessageID, h.getMainKeyboard())
+	h.sendMessageWithReplyAndMarkup(message.Chat.ID, messageText, message.MessageID, h.mainKeyboard(lang))
 }
 
 // CallbackQuery обрабатывает callback query
-func (h *Handlers) CallbackQuery(query *tgbotapi.CallbackQuery) {
+func (h *Handlers) CallbackQuery(query *tgbotapi.CallbackQuery) error {
 	err := h.keyboard.HandleCallbackQuery(query)
 	if err != nil {
 		h.logger.Error("Failed to handle callback query", zap.Error(err), zap.String("data", query.Data))
 	}
+	return err
 }
 
 // Unknown обрабатывает неизвестные команды
 func (h *Handlers) Unknown(message *tgbotapi.Message) {
-	h.sendMessage(message.Chat.ID, "Неизвестная команда. Используйте /help для получения справки.")
+	h.sendMessage(message.Chat.ID, h.lang(message).T("unknown.command"))
+}
+
+// CommandDisabled сообщает, что команда отключена администраторами группы
+func (h *Handlers) CommandDisabled(message *tgbotapi.Message) {
+	h.sendMessage(message.Chat.ID, h.lang(message).T("chat.command_disabled"))
 }
 
 // sendMessageWithReply отправляет сообщение с reply
@@ -349,9 +353,14 @@ func (h *Handlers) getAdminUsername() string {
 
 // Search обрабатывает команду /search
 func (h *Handlers) Search(message *tgbotapi.Message) {
-	args := strings.Fields(message.CommandArguments())
+	lang := h.lang(message)
+	args, releaseType, ok := extractReleaseTypeFlag(strings.Fields(message.CommandArguments()))
+	if !ok {
+		h.sendMessage(message.Chat.ID, lang.T("releases.type_usage"))
+		return
+	}
 	if len(args) == 0 {
-		h.sendMessage(message.Chat.ID, "Использование: /search имя_артиста\nПример: /search ITZY")
+		h.sendMessage(message.Chat.ID, lang.T("search.usage"))
 		return
 	}
 
@@ -359,10 +368,10 @@ func (h *Handlers) Search(message *tgbotapi.Message) {
 	artistName := strings.Join(args, " ")
 
 	// Получаем релизы по артисту
-	response, err := h.services.Release.GetReleasesByArtistName(artistName)
+	response, err := h.services.Release.GetReleasesByArtistName(artistName, releaseType, message.From.ID, lang)
 	if err != nil {
 		h.logger.Error("Failed to get releases by artist", zap.Error(err))
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("Ошибка при поиске релизов: %v", err))
+		h.sendMessage(message.Chat.ID, lang.T("search.error", err))
 		return
 	}
 
@@ -0,0 +1,62 @@
+package health
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"gemfactory/internal/service"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// SetCalendarProvider регистрирует ленту релизов iCalendar по указанному пути
+func (s *Server) SetCalendarProvider(path string, provider CalendarProvider) {
+	s.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		s.calendarHandler(w, r, provider)
+	})
+	s.logger.Info("Calendar feed registered", zap.String("path", path))
+}
+
+// calendarHandler обрабатывает запросы ленты релизов
+func (s *Server) calendarHandler(w http.ResponseWriter, r *http.Request, provider CalendarProvider) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	feed, err := provider.CalendarFeed(r.URL.Query())
+	switch {
+	case errors.Is(err, service.ErrInvalidCalendarToken):
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	case errors.Is(err, service.ErrInvalidCalendarFilter):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		s.logger.Error("Failed to render calendar feed", zap.Error(err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	// Календарные клиенты опрашивают ленту часто, ETag позволяет не передавать ее без изменений
+	sum := sha256.Sum256(feed)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="gemfactory.ics"`)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := w.Write(feed); err != nil {
+		s.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
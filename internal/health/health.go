@@ -4,6 +4,7 @@ package health
 import (
 	"context"
 	"fmt"
+	"gemfactory/internal/metrics"
 	"net/http"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 // Server представляет health check сервер
 type Server struct {
 	server *http.Server
+	mux    *http.ServeMux
 	db     DatabaseInterface
 	logger *zap.Logger
 }
@@ -28,6 +30,7 @@ func NewServer(port string, logger *zap.Logger, db DatabaseInterface) *Server {
 
 	healthServer := &Server{
 		server: server,
+		mux:    mux,
 		db:     db,
 		logger: logger,
 	}
@@ -36,6 +39,7 @@ func NewServer(port string, logger *zap.Logger, db DatabaseInterface) *Server {
 	mux.HandleFunc("/health", healthServer.healthHandler)
 	mux.HandleFunc("/ready", healthServer.readyHandler)
 	mux.HandleFunc("/live", healthServer.liveHandler)
+	mux.Handle("/metrics", metrics.Handler())
 
 	return healthServer
 }
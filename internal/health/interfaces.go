@@ -1,6 +1,9 @@
 package health
 
-import "database/sql"
+import (
+	"database/sql"
+	"net/url"
+)
 
 // DatabaseInterface определяет интерфейс для проверки здоровья базы данных
 type DatabaseInterface interface {
@@ -8,3 +11,8 @@ type DatabaseInterface interface {
 	Close() error
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 }
+
+// CalendarProvider определяет интерфейс для формирования iCalendar ленты релизов
+type CalendarProvider interface {
+	CalendarFeed(query url.Values) ([]byte, error)
+}
@@ -0,0 +1,120 @@
+// Package i18n содержит каталог пользовательских сообщений бота на русском и английском языках.
+package i18n
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// Lang представляет язык интерфейса бота
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	// Default язык по умолчанию, если язык клиента не определен
+	Default = RU
+)
+
+// tags языковые теги поддерживаемых языков
+var tags = map[Lang]language.Tag{
+	RU: language.Russian,
+	EN: language.English,
+}
+
+// russianSpeaking языки Telegram клиента, для которых понятнее русский интерфейс
+var russianSpeaking = map[string]bool{
+	"ru": true,
+	"uk": true,
+	"be": true,
+	"kk": true,
+}
+
+// printers форматируют сообщения из каталога для каждого языка
+var printers = make(map[Lang]*message.Printer, len(tags))
+
+func init() {
+	builder := catalog.NewBuilder(catalog.Fallback(tags[Default]))
+	register(builder, RU, ruMessages, ruPlurals)
+	register(builder, EN, enMessages, enPlurals)
+
+	for lang, tag := range tags {
+		printers[lang] = message.NewPrinter(tag, message.Catalog(builder))
+	}
+}
+
+// register добавляет сообщения языка в каталог
+func register(builder *catalog.Builder, lang Lang, messages map[string]string, plurals map[string]catalog.Message) {
+	tag := tags[lang]
+	for key, text := range messages {
+		if err := builder.SetString(tag, key, text); err != nil {
+			panic("i18n: failed to register " + string(lang) + " message " + key + ": " + err.Error())
+		}
+	}
+	for key, msg := range plurals {
+		if err := builder.Set(tag, key, msg); err != nil {
+			panic("i18n: failed to register " + string(lang) + " message " + key + ": " + err.Error())
+		}
+	}
+}
+
+// Supported возвращает поддерживаемые языки
+func Supported() []Lang {
+	return []Lang{RU, EN}
+}
+
+// Parse возвращает язык по коду (ru, en) или признак, что язык не поддерживается
+func Parse(code string) (Lang, bool) {
+	lang := Lang(strings.ToLower(strings.TrimSpace(code)))
+	_, ok := tags[lang]
+	return lang, ok
+}
+
+// Detect определяет язык по language_code Telegram клиента (IETF тег, например en-US)
+func Detect(languageCode string) Lang {
+	if languageCode == "" {
+		return Default
+	}
+
+	base := strings.ToLower(strings.SplitN(strings.ReplaceAll(languageCode, "_", "-"), "-", 2)[0])
+	if lang, ok := Parse(base); ok {
+		return lang
+	}
+	if russianSpeaking[base] {
+		return RU
+	}
+	return EN
+}
+
+// T возвращает сообщение каталога по ключу, подставляя аргументы.
+// Числа форматируются по правилам языка, поэтому годы и ID передаются строками
+func (l Lang) T(key string, args ...any) string {
+	printer, ok := printers[l]
+	if !ok {
+		printer = printers[Default]
+	}
+	return printer.Sprintf(key, args...)
+}
+
+// Name возвращает название языка на нем самом
+func (l Lang) Name() string {
+	return l.T("lang.name")
+}
+
+// String возвращает код языка
+func (l Lang) String() string {
+	return string(l)
+}
+
+// MonthName возвращает название месяца по английскому названию (january, february, ...)
+func (l Lang) MonthName(month string) string {
+	key := "month." + strings.ToLower(month)
+	if _, ok := ruMessages[key]; !ok {
+		return month
+	}
+	return l.T(key)
+}
@@ -0,0 +1,450 @@
+package i18n
+
+import (
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/message/catalog"
+)
+
+// enMessages английские сообщения бота
+var enMessages = map[string]string{
+	"lang.name": "English",
+
+	"month.january":   "January",
+	"month.february":  "February",
+	"month.march":     "March",
+	"month.april":     "April",
+	"month.may":       "May",
+	"month.june":      "June",
+	"month.july":      "July",
+	"month.august":    "August",
+	"month.september": "September",
+	"month.october":   "October",
+	"month.november":  "November",
+	"month.december":  "December",
+
+	"start.text": "Welcome! Choose a month:",
+	"help.text": "Available commands:\n" +
+		"\n/start - Start using the bot\n" +
+		"/help - Show this message\n" +
+		"/month [month] - Releases for the month of the current year\n" +
+		"/month [month] [year] - Releases for the month and year\n" +
+		"/month [month] -f - Girl group releases only, current year\n" +
+		"/month [month] -m - Boy group releases only, current year\n" +
+		"/month [month] -a - All groups, ignoring the /settings filter\n" +
+		"/month [month] -t [type] - Releases of one type: single, album, ep, ost, digital\n" +
+		"/search [artist] - Search releases by artist\n" +
+		"/search [artist] -t [type] - Artist releases of one type\n" +
+		"/artists - Show artist lists\n" +
+		"/metrics - Show system metrics\n" +
+		"/homework - Get a random homework track\n" +
+		"/playlist - Playlist info\n" +
+		"/subscribe [artist] - Subscribe to an artist's new releases\n" +
+		"/unsubscribe [artist] - Unsubscribe from an artist\n" +
+		"/subscriptions - My subscriptions\n" +
+		"/calendar [all|-f|-m|artists] - Release feed link for your calendar\n" +
+		"/lang [ru|en] - Interface language\n" +
+		"/settings - Time zone, /month filter and release layout\n" +
+		"/digest on|off - Today's and this week's release digest\n" +
+		"/remind [minutes|off] - Reminder before releases of your subscriptions\n" +
+		"/chat - Group chat settings\n" +
+		"\n" +
+		"Whitelist questions: @%s",
+	"month.choose":    "Please choose a month:",
+	"common.error":    "Error: %v",
+	"unknown.command": "Unknown command. Use /help to see the list of commands.",
+	"keyboard.back":   "Back",
+
+	"releases.month_title":    "🎵 Releases for %s %s:\n\n",
+	"releases.artist_title":   "🎵 Releases by %s:\n\n",
+	"releases.not_found":      "No releases found",
+	"releases.did_you_mean":   "\nDid you mean: %s",
+	"releases.related_title":  "\n👥 <b>%s</b> (%s):\n\n",
+	"releases.month_empty":    "No releases found for %s.",
+	"releases.entry_date":     "📅 %s\n",
+	"releases.entry_datetime": "📅 %s at %s\n",
+	"releases.entry_album":    "💿 %s\n",
+	"releases.entry_track":    "🎵 %s\n",
+	"releases.type_filter":    "🏷 Type: %s\n\n",
+	"releases.type_usage":     "Unknown release type. Put the type after -t: single, album, ep, ost or digital\nExample: /month october -t album",
+
+	"release_type.single":  "singles",
+	"release_type.album":   "albums",
+	"release_type.ep":      "EPs and mini albums",
+	"release_type.ost":     "OSTs",
+	"release_type.digital": "digital singles",
+
+	"relation.member":  "solo project",
+	"relation.subunit": "sub-unit",
+	"relation.collab":  "collaboration",
+
+	"artists.female":  "<b>Female artists:</b>\n",
+	"artists.male":    "<b>Male artists:</b>\n",
+	"artists.empty":   "empty\n",
+	"artists.summary": "\n📊 Total artists: %d\n💃 Female: %d\n🤦‍♂️ Male: %d",
+
+	"search.usage": "Usage: /search artist_name [-t type]\nExample: /search ITZY -t ep",
+	"search.error": "Failed to search releases: %v",
+
+	"metrics.title":              "📊 *System metrics*\n\n",
+	"metrics.artists":            "🎤 *Artists in filters:*\n",
+	"metrics.artist_counts":      "  • Girl groups: %d\n  • Boy groups: %d\n  • Total artists: %d\n\n",
+	"metrics.releases":           "💿 *Releases in database:*\n",
+	"metrics.release_count":      "  • Releases: %d\n\n",
+	"metrics.data_error":         "  • Failed to load data\n\n",
+	"metrics.llm":                "🤖 *LLM metrics:*\n",
+	"metrics.llm_error":          "  • Error: %v\n\n",
+	"metrics.llm_requests":       "  • Total requests: %s\n  • Successful: %s\n  • Failed: %s\n",
+	"metrics.llm_last":           "  • Last request: %s\n",
+	"metrics.llm_never":          "  • Last request: never\n",
+	"metrics.llm_delay":          "  • Delay: %s ms\n",
+	"metrics.llm_cache":          "  • Cache: %s hits, %s misses\n",
+	"metrics.homework":           "📚 *Homework:*\n",
+	"metrics.homework_counts":    "  • Assigned: %d\n  • Unique users: %d\n\n",
+	"metrics.scheduler":          "🔄 *Scheduler:*\n",
+	"metrics.scheduler_active":   "  • Status: Active\n",
+	"metrics.scheduler_tasks":    "  • Active tasks: %d\n",
+	"metrics.scheduler_inactive": "  • Status: Inactive\n",
+	"metrics.system":             "⚡ *System:*\n  • Status: Running\n  • Time: %s\n",
+
+	"homework.check_error":  "❌ Failed to check whether you can request homework.",
+	"homework.wait_hours":   "%d h %d min",
+	"homework.wait_minutes": "%d min",
+	"homework.current":      "\n\n📚 Your current homework:\n🎵 \"%s - %s\" (<a href=\"%s\">Spotify</a>) %s",
+	"homework.already":      "⏰ You have already got homework today! The next one will be available in %s.%s",
+	"homework.error":        "❌ Failed to get homework. Please try again later.",
+	"homework.assigned":     "🎲 Your homework: listen to \"%s - %s\" (<a href=\"%s\">Spotify</a>) %s %s",
+
+	"playlist.unavailable": "❌ Playlist service is unavailable. Check the Spotify settings.",
+	"playlist.not_found":   "❌ Playlist not found. Check that:\n• The playlist exists\n• It is public\n• The URL in the configuration is correct",
+	"playlist.error":       "❌ Failed to get playlist info. Please try again later.",
+	"playlist.info": "📚 Playlist info:\n\n" +
+		"🎵 Name: %s\n" +
+		"📊 Tracks: %d\n" +
+		"👤 Owner: %s\n" +
+		"📝 Description: %s\n\n" +
+		"🔗 Link: (<a href=\"%s\">Open in Spotify</a>)",
+
+	"subscribe.usage":        "Usage: /subscribe artist_name [-all]\n-all - also solo projects, sub-units and collaborations\nExample: /subscribe ITZY",
+	"subscribe.error":        "Failed to subscribe: %v",
+	"subscribe.not_found":    "Artist %s is not in the lists. See the lists: /artists",
+	"subscribe.already":      "You are already subscribed to <b>%s</b>",
+	"subscribe.done":         "🔔 You subscribed to <b>%s</b>. I will message you when a new release appears.",
+	"subscribe.done_related": "🔔 You subscribed to <b>%s</b> including solo projects, sub-units and collaborations. I will message you when a new release appears.",
+
+	"unsubscribe.usage":          "Usage: /unsubscribe artist_name\nYour subscriptions: /subscriptions",
+	"unsubscribe.error":          "Failed to unsubscribe: %v",
+	"unsubscribe.not_subscribed": "You are not subscribed to %s",
+	"unsubscribe.done":           "🔕 You unsubscribed from <b>%s</b>",
+
+	"subscriptions.error":        "❌ Failed to get subscriptions. Please try again later.",
+	"subscriptions.empty":        "You have no subscriptions.\nSubscribe: /subscribe artist_name",
+	"subscriptions.title":        "🔔 Your subscriptions:\n\n",
+	"subscriptions.hint":         "\nTap an artist to unsubscribe.",
+	"subscriptions.with_related": " + solo projects and sub-units",
+
+	"notification.title": "🔔 New release: <b>%s</b>\n\n",
+	"notification.date":  "📅 Date: %s\n",
+	"notification.album": "💿 Album: %s\n",
+	"notification.track": "🎵 Track: %s\n",
+
+	"calendar.disabled": "📅 The calendar release feed is not configured",
+	"calendar.error":    "❌ Failed to build the link. Please try again later.",
+	"calendar.text": "📅 <b>Release feed: %s</b>\n\n<code>%s</code>\n\n" +
+		"Add the link to Google Calendar (\"Other calendars\" → \"From URL\") " +
+		"or Apple Calendar (\"File\" → \"New Calendar Subscription\").",
+	"calendar.personal": "\n\n🔒 This link is personal: the feed follows your subscriptions, do not share it.",
+	"calendar.no_subscriptions": "You have no subscriptions yet. Subscribe with /subscribe or pick a filter:\n" +
+		"/calendar all - all releases\n" +
+		"/calendar -f - girl groups\n" +
+		"/calendar -m - boy groups\n" +
+		"/calendar [artists] - comma-separated artists",
+	"calendar.usage":  "Usage: /calendar [all|-f|-m|artists]",
+	"calendar.mine":   "my subscriptions (%d)",
+	"calendar.all":    "all releases",
+	"calendar.female": "girl groups",
+	"calendar.male":   "boy groups",
+
+	"settings.text":            "⚙️ <b>Settings</b>\n\n🕒 Time zone: %s (now %s)\n👥 Default /month filter: %s\n📋 Release layout: %s\n\nOther time zone: /settings tz Europe/Paris",
+	"settings.timezone_button": "🕒 Time zone: %s",
+	"settings.gender_all":      "All",
+	"settings.gender_female":   "Girl groups",
+	"settings.gender_male":     "Boy groups",
+	"settings.layout_compact":  "Compact",
+	"settings.layout_verbose":  "Verbose",
+	"settings.usage":           "Usage: /settings or /settings tz Europe/Moscow",
+	"settings.tz_invalid":      "❌ Unknown time zone %s. Use an IANA zone such as Europe/Moscow or Asia/Seoul",
+	"settings.error":           "❌ Failed to save settings. Please try again later.",
+
+	"digest.today":      "📅 <b>Releases today, %s</b>\n\n",
+	"digest.week":       "🗓 <b>Releases this week: %s – %s</b>\n\n",
+	"digest.days":       "🗓 <b>Releases for %d days: %s – %s</b>\n\n",
+	"digest.footer":     "\nTurn off the digest: /digest off",
+	"digest.status_on":  "📬 The release digest is on: today's releases every morning and the week ahead on Mondays.\nTurn off: /digest off",
+	"digest.status_off": "📭 The release digest is off.\nTurn on: /digest on",
+	"digest.enabled":    "📬 Digest is on. I will send today's and this week's releases.",
+	"digest.disabled":   "📭 Digest is off.",
+	"digest.usage":      "Usage: /digest on|off",
+
+	"remind.status_on":  "⏰ Reminders are on: %d min before releases of artists you follow.\nTurn off: /remind off",
+	"remind.status_off": "🔕 Release reminders are off.\nTurn on: /remind 30",
+	"remind.enabled":    "⏰ I will remind you %d min before releases of artists you follow.",
+	"remind.disabled":   "🔕 Release reminders are off.",
+	"remind.usage":      "Usage: /remind N, where N is minutes from 1 to %d, or /remind off",
+	"reminder.title":    "⏰ <b>%s</b>: release in %d min (%s)\n\n",
+
+	"chat.text":             "👥 <b>Group settings</b>\n\n🌐 Language: %s\n🔍 Default /month filter: %s\n📬 Digest: %s\n🤫 Quiet mode: %s\n⌨️ Commands: %s\n\nChange (group admins): /chat lang|filter|digest|quiet|commands",
+	"chat.language_user":    "member's language",
+	"chat.gender_user":      "member's filter",
+	"chat.commands_all":     "all",
+	"chat.on":               "on",
+	"chat.off":              "off",
+	"chat.saved":            "✅ Group settings saved.\n\n",
+	"chat.group_only":       "/chat configures a group chat. Personal settings: /settings",
+	"chat.admin_only":       "Only group admins can change group settings.",
+	"chat.command_disabled": "This command is disabled by the group admins.",
+	"chat.usage": "Usage:\n" +
+		"/chat lang ru|en|auto - reply language in the group\n" +
+		"/chat filter female|male|off - default /month filter\n" +
+		"/chat digest on|off - release digest in the group\n" +
+		"/chat quiet on|off - reply only to commands with @botname or replies to the bot\n" +
+		"/chat commands all|command... - allowed commands",
+
+	"lang.current": "🌐 Interface language: %s\n\nChange it: /lang ru or /lang en",
+	"lang.usage":   "Usage: /lang [ru|en]",
+	"lang.changed": "🌐 Interface language: %s",
+	"lang.error":   "❌ Failed to save the language. Please try again later.",
+
+	"command.start":         "Start using the bot",
+	"command.help":          "Show help",
+	"command.month":         "Releases for a month",
+	"command.search":        "Search releases by artist",
+	"command.artists":       "Show artist lists",
+	"command.metrics":       "Show system metrics",
+	"command.homework":      "Get a random homework track",
+	"command.playlist":      "Playlist info",
+	"command.subscribe":     "Subscribe to an artist's releases",
+	"command.unsubscribe":   "Unsubscribe from an artist",
+	"command.subscriptions": "My subscriptions",
+	"command.calendar":      "Release feed for your calendar",
+	"command.lang":          "Interface language / Язык интерфейса",
+	"command.settings":      "Time zone, filter and release format",
+	"command.digest":        "Daily and weekly release digest",
+	"command.remind":        "Reminders before subscribed releases",
+	"command.chat":          "Group chat settings",
+
+	"admin.forbidden":        "You don't have permission to run this command",
+	"admin.artist_not_found": "Artist %s not found. See the lists: /artists",
+	"admin.artist_id":        "artist #%s",
+	"admin.more":             "… and %d more\n",
+	"admin.help": "🔧 <b>Admin commands:</b>\n\n" +
+		"/add_artist [names] [-f|-m] - Add artist(s)\n" +
+		"/remove_artist [names] - Deactivate artist(s)\n" +
+		"/alias [artist] - Artist aliases\n" +
+		"/alias add|remove [artist] = [aliases] - Add or remove aliases\n" +
+		"/discover [N] - Scheduled artists missing from the lists\n" +
+		"/relation [artist] - Soloists, sub-units and collaborations\n" +
+		"/relation add|remove|include ... - Manage artist relations\n" +
+		"/export - Export all artists\n" +
+		"/config [key] [value] - Set a config value\n" +
+		"/config_list - Show the config\n" +
+		"/config_reset - Reset the config\n" +
+		"/tasks_list - Show scheduled tasks\n" +
+		"/task_history [task] [N] - Recent task runs\n" +
+		"/reload_playlist - Reload the playlist\n" +
+		"/parse [year] - Parse releases\n" +
+		"/llm_metrics - Show LLM metrics\n" +
+		"/changes [days] - Release changes (reschedules, MVs, tracks)\n" +
+		"/snapshots [N] - Saved schedule page snapshots\n" +
+		"/replay [number] - Re-parse a snapshot without fetching the site\n" +
+		"/grant [id|@username] [admin|editor] - Grant a role\n" +
+		"/revoke [id|@username] - Revoke a role\n" +
+		"/audit [N] [command] - Admin action log\n" +
+		"/parse [month] [year] - Parse a specific month\n" +
+		"/parse [month] - Parse a month of the current year\n" +
+		"/parse - Parse the current month\n" +
+		"/parse ... force - Parse pages even if they have not changed\n\n" +
+		"<b>Multiple artists examples:</b>\n" +
+		"/add_artist ablume, aespa, apink -f\n" +
+		"/remove_artist ablume, aespa, apink",
+
+	"add_artist.usage":         "Usage: /add_artist [names] [-f|-m]\nExample: /add_artist ITZY -f\nMultiple artists: /add_artist ablume, aespa, apink -f",
+	"add_artist.invalid_flag":  "The flag must be -f (female) or -m (male). Example: /add_artist ITZY -f",
+	"add_artist.no_names":      "No artist names found",
+	"add_artist.gender_female": "female",
+	"add_artist.gender_male":   "male",
+	"add_artist.error":         "Failed to add artists: %v",
+	"add_artist.exists":        "All artists are already in the list: %s",
+	"add_artist.added_one":     "✅ Added %s artist: %s",
+	"add_artist.added_many":    "✅ Added %d %s artists out of %d: %s",
+
+	"remove_artist.usage":     "Usage: /remove_artist [names]\nExample: /remove_artist ITZY\nMultiple artists: /remove_artist ablume, aespa, apink",
+	"remove_artist.error":     "Failed to deactivate artists: %v",
+	"remove_artist.not_found": "Artists not found or already deactivated: %s",
+	"remove_artist.done_one":  "✅ Artist %s deactivated (excluded from parsing and listings)",
+	"remove_artist.done_many": "✅ Deactivated %d artists out of %d: %s",
+
+	"clearwhitelists.get_error":    "❌ Failed to get the artist list.",
+	"clearwhitelists.remove_error": "❌ Failed to remove artists.",
+	"clearwhitelists.done":         "✅ All artists removed.",
+	"clearcache.done":              "✅ Cache cleared, refresh started",
+	"export.error":                 "❌ Failed to export data.",
+
+	"config.usage":       "Usage: /config [key] [value]",
+	"config.error":       "Failed to set the config value",
+	"config.set":         "Config %s set to %s",
+	"config.list_error":  "Failed to get the config",
+	"config.reset_error": "Failed to reset the config",
+	"config.reset_done":  "Config reset to defaults",
+
+	"parse.started_month": "🔄 Parsing releases for %s %s...",
+	"parse.started":       "🔄 Parsing releases...",
+	"parse.error":         "❌ Failed to parse releases: %v",
+	"parse.done_month":    "✅ Parsing finished! Saved %d releases for %s %s",
+	"parse.done":          "✅ Parsing finished! Saved %d releases",
+	"parse.invalid_year":  "❌ Invalid year. Use 4 digits (for example: 2025)",
+	"parse.unchanged":     "\n\n♻️ Unchanged pages skipped: %d. Parse them again: /parse ... force",
+	"parse.usage": "❌ Too many arguments.\n\n" +
+		"Usage:\n" +
+		"• /parse - parse the current month\n" +
+		"• /parse [month] - parse a month of the current year\n" +
+		"• /parse [month] [year] - parse a specific month and year\n" +
+		"• /parse [year] - parse the whole year\n" +
+		"• force at the end - parse pages even if they have not changed\n\n" +
+		"Examples:\n" +
+		"• /parse\n" +
+		"• /parse september\n" +
+		"• /parse september 2025\n" +
+		"• /parse 2025\n" +
+		"• /parse september force",
+
+	"tasks.error":      "Failed to get the task list",
+	"tasks.empty":      "📋 No tasks found",
+	"tasks.title":      "📋 Tasks:\n\n",
+	"tasks.active":     "🟢 Active",
+	"tasks.inactive":   "🔴 Inactive",
+	"tasks.runs":       "   📊 Runs: %d (succeeded: %d, failed: %d)\n",
+	"tasks.last_run":   "   🕐 Last run: %s\n",
+	"tasks.next_run":   "   ⏭️ Next run: %s\n",
+	"tasks.last_error": "   ❌ Last error: %s\n",
+
+	"task_history.usage":     "Usage: /task_history [task] [N]\nN - number of runs from 1 to 50, 10 by default",
+	"task_history.error":     "Failed to get the task history",
+	"task_history.not_found": "❌ Task %s not found. Task list: /tasks_list",
+	"task_history.title":     "📜 <b>Runs of %s</b>\n\n",
+	"task_history.empty":     "No runs yet",
+
+	"reload_playlist.started": "🔄 Reloading the playlist...",
+	"reload_playlist.error":   "❌ Failed to reload the playlist: %v",
+	"reload_playlist.done":    "✅ Playlist reloaded!",
+
+	"changes.usage": "Usage: /changes [days]\nNumber of days from 1 to 365, 7 by default",
+	"changes.error": "❌ Failed to get changes: %v",
+
+	"llm_metrics.title":    "📊 *LLM metrics*\n\n",
+	"llm_metrics.error":    "❌ Error: %v\n",
+	"llm_metrics.requests": "📈 Total requests: %v\n✅ Succeeded: %v\n❌ Failed: %v\n",
+	"llm_metrics.last":     "🕐 Last request: %s\n",
+	"llm_metrics.never":    "🕐 Last request: never\n",
+	"llm_metrics.delay":    "⏱️ Delay: %v ms\n",
+	"llm_metrics.cache":    "💾 Cache: %v hits, %v misses\n",
+
+	"alias.usage": "Usage:\n" +
+		"• /alias - all aliases\n" +
+		"• /alias [artist] - artist aliases\n" +
+		"• /alias add [artist] = [aliases] - add aliases\n" +
+		"• /alias remove [artist] = [aliases] - remove aliases\n\n" +
+		"Examples:\n" +
+		"• /alias add IVE = IVE (아이브), 아이브\n" +
+		"• /alias remove tripleS = triple S",
+	"alias.conflict":   "⚠️ Alias already taken: %s",
+	"alias.error":      "❌ Failed to change aliases: %v",
+	"alias.added":      "✅ Aliases added for %s: %d of %d",
+	"alias.removed":    "✅ Aliases removed for %s: %d of %d",
+	"alias.list_error": "❌ Failed to get aliases",
+	"alias.empty":      "No aliases found\n\n",
+	"alias.title":      "🏷 <b>Artist aliases:</b>\n",
+
+	"near_miss.title": "\n\n⚠️ <b>Similar names not matched:</b>\n",
+	"near_miss.hint":  "\nAdd an alias: /alias add [artist] = [name]",
+
+	"relation.usage": "Usage:\n" +
+		"• /relation - all relations\n" +
+		"• /relation [artist] - artist relations\n" +
+		"• /relation add member|subunit|collab [artist] = [group] - link an artist to a group\n" +
+		"• /relation remove [artist] = [group] - remove a relation\n" +
+		"• /relation include [group] on|off - list the group's soloists, sub-units and collaborations with it\n\n" +
+		"Examples:\n" +
+		"• /relation add subunit IRENE & SEULGI = Red Velvet\n" +
+		"• /relation add member WENDY = Red Velvet\n" +
+		"• /relation include Red Velvet on",
+	"relation.self":             "⚠️ An artist cannot be related to itself",
+	"relation.add_error":        "❌ Failed to add the relation: %v",
+	"relation.added":            "✅ %s - %s %s",
+	"relation.created_inactive": "\n\nThe artist was added inactive: it is listed through the group with /relation include %s on",
+	"relation.remove_error":     "❌ Failed to remove the relation: %v",
+	"relation.not_found":        "Relation %s = %s not found",
+	"relation.removed":          "✅ Relation %s = %s removed",
+	"relation.include_error":    "❌ Failed to change the list: %v",
+	"relation.included":         "✅ Soloists, sub-units and collaborations of %s are now listed",
+	"relation.excluded":         "✅ Soloists, sub-units and collaborations of %s are no longer listed",
+	"relation.list_error":       "❌ Failed to get relations",
+	"relation.empty":            "No relations found\n\n",
+	"relation.title":            "👥 <b>Artist relations:</b>\n",
+	"relation.with_related":     " (listed with related artists)",
+
+	"role.owner":  "owner",
+	"role.admin":  "admin",
+	"role.editor": "editor",
+	"role.user":   "user",
+
+	"grant.usage":          "Usage: /grant [id|@username] [admin|editor]\nExample: /grant @moderator editor\n\n",
+	"grant.done":           "✅ %s is now %s",
+	"revoke.usage":         "Usage: /revoke [id|@username]\nExample: /revoke @moderator",
+	"revoke.done":          "✅ Role revoked, %s is now %s",
+	"roles.user_not_found": "❌ User %s not found. They must message the bot at least once, or use their Telegram ID",
+	"roles.not_grantable":  "❌ Only the admin or editor roles can be granted",
+	"roles.insufficient":   "❌ Not enough rights: you can only manage users with a lower role than yours",
+	"roles.self":           "❌ You cannot change your own role",
+	"roles.unchanged":      "ℹ️ The user's role has not changed",
+	"roles.error":          "❌ Failed to change the role: %v",
+	"roles.staff_error":    "Failed to get the role list",
+	"roles.staff_empty":    "No roles assigned yet",
+	"roles.staff_title":    "<b>Current roles:</b>\n",
+	"roles.staff_entry":    "• %s (id %s) - %s\n",
+
+	"audit.limit":     "The number of entries must be from 1 to %d",
+	"audit.error":     "❌ Failed to get the audit log",
+	"audit.empty":     "📜 The audit log is empty",
+	"audit.title":     "📜 <b>Audit log</b> (last %d)\n",
+	"audit.more":      "\n… and %d more entries, lower N or specify a command",
+	"audit.arguments": "   Arguments: <code>%s</code>\n",
+	"audit.before":    "   Before: <code>%s</code>\n",
+	"audit.after":     "   After: <code>%s</code>\n",
+	"audit.failure":   "   Error: %s\n",
+
+	"snapshots.usage":  "Usage: /snapshots [N], number of snapshots from 1 to %d",
+	"snapshots.error":  "❌ Failed to get page snapshots",
+	"replay.usage":     "Usage: /replay [snapshot number]\nSnapshot list: /snapshots",
+	"replay.started":   "🔁 Parsing snapshot #%s...",
+	"replay.disabled":  "Page snapshots are not saved",
+	"replay.error":     "❌ Failed to parse the snapshot: %v",
+	"replay.not_found": "Snapshot #%s not found. Snapshot list: /snapshots",
+
+	"discover.usage":         "Usage: /discover [N], number of artists from 1 to %d",
+	"discover.error":         "❌ Failed to get new artists",
+	"discover.found":         "\n\n🔎 Artists missing from the lists: %d. See them: /discover",
+	"discover.action_female": "added to the female list",
+	"discover.action_male":   "added to the male list",
+	"discover.action_ignore": "hidden from the report",
+	"discover.not_found":     "⚠️ Artist not found",
+	"discover.already":       "⚠️ %s has already been handled",
+	"discover.resolve_error": "❌ Error: %s",
+	"discover.resolved":      "✅ %s %s",
+}
+
+// enPlurals английские сообщения с числом
+var enPlurals = map[string]catalog.Message{
+	"homework.times": plural.Selectf(1, "%d",
+		"one", "%d time",
+		"other", "%d times"),
+}
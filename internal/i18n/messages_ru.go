@@ -0,0 +1,452 @@
+package i18n
+
+import (
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/message/catalog"
+)
+
+// ruMessages русские сообщения бота
+var ruMessages = map[string]string{
+	"lang.name": "Русский",
+
+	"month.january":   "январь",
+	"month.february":  "февраль",
+	"month.march":     "март",
+	"month.april":     "апрель",
+	"month.may":       "май",
+	"month.june":      "июнь",
+	"month.july":      "июль",
+	"month.august":    "август",
+	"month.september": "сентябрь",
+	"month.october":   "октябрь",
+	"month.november":  "ноябрь",
+	"month.december":  "декабрь",
+
+	"start.text": "Добро пожаловать! Выберите месяц:",
+	"help.text": "Доступные команды:\n" +
+		"\n/start - Начать работу с ботом\n" +
+		"/help - Показать это сообщение\n" +
+		"/month [месяц] - Получить релизы за указанный месяц текущего года\n" +
+		"/month [месяц] [год] - Получить релизы за указанный месяц и год\n" +
+		"/month [месяц] -f - Релизы только женских групп за текущий год\n" +
+		"/month [месяц] -m - Релизы только мужских групп за текущий год\n" +
+		"/month [месяц] -a - Релизы всех групп без фильтра из /settings\n" +
+		"/month [месяц] -t [тип] - Релизы одного типа: single, album, ep, ost, digital\n" +
+		"/search [артист] - Поиск релизов по артисту\n" +
+		"/search [артист] -t [тип] - Релизы артиста одного типа\n" +
+		"/artists - Показать списки артистов\n" +
+		"/metrics - Показать метрики системы\n" +
+		"/homework - Получить случайное домашнее задание\n" +
+		"/playlist - Информация о плейлисте\n" +
+		"/subscribe [артист] - Подписаться на новые релизы артиста\n" +
+		"/unsubscribe [артист] - Отписаться от артиста\n" +
+		"/subscriptions - Мои подписки\n" +
+		"/calendar [all|-f|-m|артисты] - Ссылка на ленту релизов для календаря\n" +
+		"/lang [ru|en] - Язык интерфейса\n" +
+		"/settings - Часовой пояс, фильтр /month и формат релизов\n" +
+		"/digest on|off - Дайджест релизов на сегодня и на неделю\n" +
+		"/remind [минуты|off] - Напоминание перед релизами из подписок\n" +
+		"/chat - Настройки группового чата\n" +
+		"\n" +
+		"По вопросам вайтлистов: @%s",
+	"month.choose":    "Пожалуйста, выберите месяц:",
+	"common.error":    "Ошибка: %v",
+	"unknown.command": "Неизвестная команда. Используйте /help для получения справки.",
+	"keyboard.back":   "Назад",
+
+	"releases.month_title":    "🎵 Релизы за %s %s:\n\n",
+	"releases.artist_title":   "🎵 Релизы артиста %s:\n\n",
+	"releases.not_found":      "Релизы не найдены",
+	"releases.did_you_mean":   "\nВозможно, вы имели в виду: %s",
+	"releases.related_title":  "\n👥 <b>%s</b> (%s):\n\n",
+	"releases.month_empty":    "Релизы для %s не найдены.",
+	"releases.entry_date":     "📅 %s\n",
+	"releases.entry_datetime": "📅 %s в %s\n",
+	"releases.entry_album":    "💿 %s\n",
+	"releases.entry_track":    "🎵 %s\n",
+	"releases.type_filter":    "🏷 Тип: %s\n\n",
+	"releases.type_usage":     "Неизвестный тип релиза. Укажите тип после -t: single, album, ep, ost или digital\nПример: /month october -t album",
+
+	"release_type.single":  "синглы",
+	"release_type.album":   "альбомы",
+	"release_type.ep":      "мини-альбомы",
+	"release_type.ost":     "OST",
+	"release_type.digital": "цифровые синглы",
+
+	"relation.member":  "сольный проект",
+	"relation.subunit": "саб-юнит",
+	"relation.collab":  "совместный проект",
+
+	"artists.female":  "<b>Женские артисты:</b>\n",
+	"artists.male":    "<b>Мужские артисты:</b>\n",
+	"artists.empty":   "пусто\n",
+	"artists.summary": "\n📊 Всего артистов: %d\n💃 Женских: %d\n🤦‍♂️ Мужских: %d",
+
+	"search.usage": "Использование: /search имя_артиста [-t тип]\nПример: /search ITZY -t ep",
+	"search.error": "Ошибка при поиске релизов: %v",
+
+	"metrics.title":              "📊 *Метрики системы*\n\n",
+	"metrics.artists":            "🎤 *Артисты в фильтрах:*\n",
+	"metrics.artist_counts":      "  • Женские группы: %d\n  • Мужские группы: %d\n  • Всего артистов: %d\n\n",
+	"metrics.releases":           "💿 *Релизы в базе:*\n",
+	"metrics.release_count":      "  • Количество релизов: %d\n\n",
+	"metrics.data_error":         "  • Ошибка получения данных\n\n",
+	"metrics.llm":                "🤖 *LLM метрики:*\n",
+	"metrics.llm_error":          "  • Ошибка: %v\n\n",
+	"metrics.llm_requests":       "  • Всего запросов: %s\n  • Успешных: %s\n  • Неудачных: %s\n",
+	"metrics.llm_last":           "  • Последний запрос: %s\n",
+	"metrics.llm_never":          "  • Последний запрос: никогда\n",
+	"metrics.llm_delay":          "  • Задержка: %s мс\n",
+	"metrics.llm_cache":          "  • Кэш: %s попаданий, %s промахов\n",
+	"metrics.homework":           "📚 *Домашние задания:*\n",
+	"metrics.homework_counts":    "  • Всего выдано: %d\n  • Уникальных пользователей: %d\n\n",
+	"metrics.scheduler":          "🔄 *Планировщик:*\n",
+	"metrics.scheduler_active":   "  • Статус: Активен\n",
+	"metrics.scheduler_tasks":    "  • Активных задач: %d\n",
+	"metrics.scheduler_inactive": "  • Статус: Неактивен\n",
+	"metrics.system":             "⚡ *Система:*\n  • Статус: Работает\n  • Время: %s\n",
+
+	"homework.check_error":  "❌ Ошибка при проверке возможности запроса домашнего задания.",
+	"homework.wait_hours":   "%d ч %d мин",
+	"homework.wait_minutes": "%d мин",
+	"homework.current":      "\n\n📚 Ваше текущее задание:\n🎵 \"%s - %s\" (<a href=\"%s\">Spotify</a>) %s",
+	"homework.already":      "⏰ Вы уже получили домашнее задание сегодня! Следующее задание будет доступно через %s.%s",
+	"homework.error":        "❌ Ошибка при получении домашнего задания. Попробуйте позже.",
+	"homework.assigned":     "🎲 Ваше домашнее задание: послушать \"%s - %s\" (<a href=\"%s\">Spotify</a>) %s %s",
+
+	"playlist.unavailable": "❌ Сервис плейлиста недоступен. Проверьте настройки Spotify.",
+	"playlist.not_found":   "❌ Плейлист не найден. Проверьте:\n• Существует ли плейлист\n• Доступен ли он публично\n• Правильный ли URL в конфигурации",
+	"playlist.error":       "❌ Ошибка при получении информации о плейлисте. Попробуйте позже.",
+	"playlist.info": "📚 Информация о плейлисте:\n\n" +
+		"🎵 Название: %s\n" +
+		"📊 Количество треков: %d\n" +
+		"👤 Владелец: %s\n" +
+		"📝 Описание: %s\n\n" +
+		"🔗 Ссылка: (<a href=\"%s\">Открыть в Spotify</a>)",
+
+	"subscribe.usage":        "Использование: /subscribe имя_артиста [-all]\n-all - также солисты, юниты и совместные проекты\nПример: /subscribe ITZY",
+	"subscribe.error":        "Ошибка при оформлении подписки: %v",
+	"subscribe.not_found":    "Артист %s не найден в списках. Посмотреть списки: /artists",
+	"subscribe.already":      "Вы уже подписаны на <b>%s</b>",
+	"subscribe.done":         "🔔 Вы подписались на <b>%s</b>. Пришлю сообщение, когда появится новый релиз.",
+	"subscribe.done_related": "🔔 Вы подписались на <b>%s</b> вместе с солистами, юнитами и совместными проектами. Пришлю сообщение, когда появится новый релиз.",
+
+	"unsubscribe.usage":          "Использование: /unsubscribe имя_артиста\nСписок подписок: /subscriptions",
+	"unsubscribe.error":          "Ошибка при отмене подписки: %v",
+	"unsubscribe.not_subscribed": "Вы не подписаны на %s",
+	"unsubscribe.done":           "🔕 Вы отписались от <b>%s</b>",
+
+	"subscriptions.error":        "❌ Ошибка при получении подписок. Попробуйте позже.",
+	"subscriptions.empty":        "У вас нет подписок.\nПодписаться: /subscribe имя_артиста",
+	"subscriptions.title":        "🔔 Ваши подписки:\n\n",
+	"subscriptions.hint":         "\nНажмите на артиста, чтобы отписаться.",
+	"subscriptions.with_related": " + солисты и юниты",
+
+	"notification.title": "🔔 Новый релиз: <b>%s</b>\n\n",
+	"notification.date":  "📅 Дата: %s\n",
+	"notification.album": "💿 Альбом: %s\n",
+	"notification.track": "🎵 Трек: %s\n",
+
+	"calendar.disabled": "📅 Лента релизов для календаря не настроена",
+	"calendar.error":    "❌ Ошибка при формировании ссылки. Попробуйте позже.",
+	"calendar.text": "📅 <b>Лента релизов: %s</b>\n\n<code>%s</code>\n\n" +
+		"Добавьте ссылку в Google Calendar («Добавить календарь» → «По URL») " +
+		"или Apple Calendar («Файл» → «Новая подписка на календарь»).",
+	"calendar.personal": "\n\n🔒 Ссылка персональная: лента обновляется вместе с вашими подписками, не делитесь ею.",
+	"calendar.no_subscriptions": "У вас пока нет подписок. Подпишитесь через /subscribe или укажите фильтр:\n" +
+		"/calendar all - все релизы\n" +
+		"/calendar -f - женские группы\n" +
+		"/calendar -m - мужские группы\n" +
+		"/calendar [артисты] - выбранные артисты через запятую",
+	"calendar.usage":  "Использование: /calendar [all|-f|-m|артисты]",
+	"calendar.mine":   "мои подписки (%d)",
+	"calendar.all":    "все релизы",
+	"calendar.female": "женские группы",
+	"calendar.male":   "мужские группы",
+
+	"settings.text":            "⚙️ <b>Настройки</b>\n\n🕒 Часовой пояс: %s (сейчас %s)\n👥 Фильтр /month по умолчанию: %s\n📋 Формат релизов: %s\n\nДругой часовой пояс: /settings tz Europe/Paris",
+	"settings.timezone_button": "🕒 Часовой пояс: %s",
+	"settings.gender_all":      "Все",
+	"settings.gender_female":   "Женские",
+	"settings.gender_male":     "Мужские",
+	"settings.layout_compact":  "Компактно",
+	"settings.layout_verbose":  "Подробно",
+	"settings.usage":           "Использование: /settings или /settings tz Europe/Moscow",
+	"settings.tz_invalid":      "❌ Неизвестный часовой пояс %s. Укажите пояс IANA, например Europe/Moscow или Asia/Seoul",
+	"settings.error":           "❌ Не удалось сохранить настройки. Попробуйте позже.",
+
+	"digest.today":      "📅 <b>Релизы сегодня, %s</b>\n\n",
+	"digest.week":       "🗓 <b>Релизы на неделе: %s – %s</b>\n\n",
+	"digest.days":       "🗓 <b>Релизы на %d дн.: %s – %s</b>\n\n",
+	"digest.footer":     "\nОтключить дайджест: /digest off",
+	"digest.status_on":  "📬 Дайджест релизов включен: сводка на сегодня каждое утро и на неделю по понедельникам.\nОтключить: /digest off",
+	"digest.status_off": "📭 Дайджест релизов отключен.\nВключить: /digest on",
+	"digest.enabled":    "📬 Дайджест включен. Пришлю сводку релизов на сегодня и на неделю.",
+	"digest.disabled":   "📭 Дайджест отключен.",
+	"digest.usage":      "Использование: /digest on|off",
+
+	"remind.status_on":  "⏰ Напоминания включены: за %d мин до релиза артистов из подписок.\nОтключить: /remind off",
+	"remind.status_off": "🔕 Напоминания о релизах отключены.\nВключить: /remind 30",
+	"remind.enabled":    "⏰ Напомню за %d мин до релизов артистов из ваших подписок.",
+	"remind.disabled":   "🔕 Напоминания о релизах отключены.",
+	"remind.usage":      "Использование: /remind N, где N - минуты от 1 до %d, или /remind off",
+	"reminder.title":    "⏰ <b>%s</b>: релиз через %d мин (%s)\n\n",
+
+	"chat.text":             "👥 <b>Настройки группы</b>\n\n🌐 Язык: %s\n🔍 Фильтр /month по умолчанию: %s\n📬 Дайджест: %s\n🤫 Тихий режим: %s\n⌨️ Команды: %s\n\nИзменить (администраторы группы): /chat lang|filter|digest|quiet|commands",
+	"chat.language_user":    "язык участника",
+	"chat.gender_user":      "фильтр участника",
+	"chat.commands_all":     "все",
+	"chat.on":               "вкл",
+	"chat.off":              "выкл",
+	"chat.saved":            "✅ Настройки группы сохранены.\n\n",
+	"chat.group_only":       "Команда /chat настраивает групповой чат. Личные настройки: /settings",
+	"chat.admin_only":       "Менять настройки группы могут только ее администраторы.",
+	"chat.command_disabled": "Эта команда отключена администраторами группы.",
+	"chat.usage": "Использование:\n" +
+		"/chat lang ru|en|auto - язык ответов в группе\n" +
+		"/chat filter female|male|off - фильтр /month по умолчанию\n" +
+		"/chat digest on|off - дайджест релизов в группе\n" +
+		"/chat quiet on|off - отвечать только на команды с @именем бота или ответы боту\n" +
+		"/chat commands all|команда... - разрешенные команды",
+
+	"lang.current": "🌐 Язык интерфейса: %s\n\nИзменить: /lang ru или /lang en",
+	"lang.usage":   "Использование: /lang [ru|en]",
+	"lang.changed": "🌐 Язык интерфейса: %s",
+	"lang.error":   "❌ Не удалось сохранить язык. Попробуйте позже.",
+
+	"command.start":         "Начать работу с ботом",
+	"command.help":          "Показать справку",
+	"command.month":         "Получить релизы за месяц",
+	"command.search":        "Поиск релизов по артисту",
+	"command.artists":       "Показать списки артистов",
+	"command.metrics":       "Показать метрики системы",
+	"command.homework":      "Получить случайное домашнее задание",
+	"command.playlist":      "Информация о плейлисте",
+	"command.subscribe":     "Подписаться на релизы артиста",
+	"command.unsubscribe":   "Отписаться от артиста",
+	"command.subscriptions": "Мои подписки",
+	"command.calendar":      "Лента релизов для календаря",
+	"command.lang":          "Язык интерфейса / Interface language",
+	"command.settings":      "Часовой пояс, фильтр и формат релизов",
+	"command.digest":        "Дайджест релизов на сегодня и на неделю",
+	"command.remind":        "Напоминания перед релизами из подписок",
+	"command.chat":          "Настройки группового чата",
+
+	"admin.forbidden":        "У вас нет прав для выполнения этой команды",
+	"admin.artist_not_found": "Артист %s не найден. Посмотреть списки: /artists",
+	"admin.artist_id":        "артист #%s",
+	"admin.more":             "… и еще %d\n",
+	"admin.help": "🔧 <b>Команды администратора:</b>\n\n" +
+		"/add_artist [имена] [-f|-m] - Добавить артиста(ов)\n" +
+		"/remove_artist [имена] - Деактивировать артиста(ов)\n" +
+		"/alias [артист] - Псевдонимы артистов\n" +
+		"/alias add|remove [артист] = [псевдонимы] - Добавить или удалить псевдонимы\n" +
+		"/discover [N] - Артисты из расписания не из списка\n" +
+		"/relation [артист] - Солисты, юниты и совместные проекты\n" +
+		"/relation add|remove|include ... - Управление связями артистов\n" +
+		"/export - Экспорт всех артистов\n" +
+		"/config [ключ] [значение] - Установить конфигурацию\n" +
+		"/config_list - Показать конфигурацию\n" +
+		"/config_reset - Сбросить конфигурацию\n" +
+		"/tasks_list - Показать список задач\n" +
+		"/task_history [задача] [N] - Последние запуски задачи\n" +
+		"/reload_playlist - Перезагрузить плейлист\n" +
+		"/parse [год] - Парсинг релизов\n" +
+		"/llm_metrics - Показать метрики LLM\n" +
+		"/changes [дни] - Изменения релизов (переносы, MV, треки)\n" +
+		"/snapshots [N] - Сохраненные снимки страниц расписания\n" +
+		"/replay [номер] - Повторный разбор снимка без обращения к сайту\n" +
+		"/grant [id|@username] [admin|editor] - Выдать роль\n" +
+		"/revoke [id|@username] - Снять роль\n" +
+		"/audit [N] [команда] - Журнал действий администраторов\n" +
+		"/parse [месяц] [год] - Парсинг конкретного месяца\n" +
+		"/parse [месяц] - Парсинг месяца текущего года\n" +
+		"/parse - Парсинг текущего месяца\n" +
+		"/parse ... force - Разобрать страницы, даже если они не изменились\n\n" +
+		"<b>Примеры множественных артистов:</b>\n" +
+		"/add_artist ablume, aespa, apink -f\n" +
+		"/remove_artist ablume, aespa, apink",
+
+	"add_artist.usage":         "Использование: /add_artist [имена] [-f|-m]\nПример: /add_artist ITZY -f\nПример множественных: /add_artist ablume, aespa, apink -f",
+	"add_artist.invalid_flag":  "Флаг должен быть -f (женский) или -m (мужской). Пример: /add_artist ITZY -f",
+	"add_artist.no_names":      "Не найдено ни одного имени артиста",
+	"add_artist.gender_female": "женский",
+	"add_artist.gender_male":   "мужской",
+	"add_artist.error":         "Ошибка при добавлении артистов: %v",
+	"add_artist.exists":        "Все артисты уже существуют в списке: %s",
+	"add_artist.added_one":     "✅ Добавлен %s артист: %s",
+	"add_artist.added_many":    "✅ Добавлено %d %s артистов из %d: %s",
+
+	"remove_artist.usage":     "Использование: /remove_artist [имена]\nПример: /remove_artist ITZY\nПример множественных: /remove_artist ablume, aespa, apink",
+	"remove_artist.error":     "Ошибка при деактивации артистов: %v",
+	"remove_artist.not_found": "Артисты не найдены или уже деактивированы: %s",
+	"remove_artist.done_one":  "✅ Артист %s деактивирован (исключен из парсинга и отображения)",
+	"remove_artist.done_many": "✅ Деактивировано %d артистов из %d: %s",
+
+	"clearwhitelists.get_error":    "❌ Ошибка при получении списка артистов.",
+	"clearwhitelists.remove_error": "❌ Ошибка при удалении артистов.",
+	"clearwhitelists.done":         "✅ Все артисты удалены.",
+	"clearcache.done":              "✅ Кэш очищен, обновление запущено",
+	"export.error":                 "❌ Ошибка при экспорте данных.",
+
+	"config.usage":       "Использование: /config [key] [value]",
+	"config.error":       "Ошибка при установке конфигурации",
+	"config.set":         "Конфигурация %s установлена в %s",
+	"config.list_error":  "Ошибка при получении конфигурации",
+	"config.reset_error": "Ошибка при сбросе конфигурации",
+	"config.reset_done":  "Конфигурация сброшена к значениям по умолчанию",
+
+	"parse.started_month": "🔄 Начинаю парсинг релизов за %s %s...",
+	"parse.started":       "🔄 Начинаю парсинг релизов...",
+	"parse.error":         "❌ Ошибка при парсинге релизов: %v",
+	"parse.done_month":    "✅ Парсинг завершен! Сохранено %d релизов за %s %s",
+	"parse.done":          "✅ Парсинг завершен! Сохранено %d релизов",
+	"parse.invalid_year":  "❌ Неверный формат года. Используйте 4 цифры (например: 2025)",
+	"parse.unchanged":     "\n\n♻️ Страниц без изменений пропущено: %d. Разобрать заново: /parse ... force",
+	"parse.usage": "❌ Слишком много аргументов.\n\n" +
+		"Использование:\n" +
+		"• /parse - парсинг текущего месяца\n" +
+		"• /parse [месяц] - парсинг месяца текущего года\n" +
+		"• /parse [месяц] [год] - парсинг конкретного месяца и года\n" +
+		"• /parse [год] - парсинг всего года\n" +
+		"• force в конце - разобрать страницы, даже если они не изменились\n\n" +
+		"Примеры:\n" +
+		"• /parse\n" +
+		"• /parse september\n" +
+		"• /parse september 2025\n" +
+		"• /parse 2025\n" +
+		"• /parse september force",
+
+	"tasks.error":      "Ошибка при получении списка задач",
+	"tasks.empty":      "📋 Задачи не найдены",
+	"tasks.title":      "📋 Список задач:\n\n",
+	"tasks.active":     "🟢 Активна",
+	"tasks.inactive":   "🔴 Неактивна",
+	"tasks.runs":       "   📊 Запусков: %d (успешно: %d, ошибок: %d)\n",
+	"tasks.last_run":   "   🕐 Последний запуск: %s\n",
+	"tasks.next_run":   "   ⏭️ Следующий запуск: %s\n",
+	"tasks.last_error": "   ❌ Последняя ошибка: %s\n",
+
+	"task_history.usage":     "Использование: /task_history [задача] [N]\nN - количество запусков от 1 до 50, по умолчанию 10",
+	"task_history.error":     "Ошибка при получении истории задачи",
+	"task_history.not_found": "❌ Задача %s не найдена. Список задач: /tasks_list",
+	"task_history.title":     "📜 <b>История запусков %s</b>\n\n",
+	"task_history.empty":     "Запусков пока не было",
+
+	"reload_playlist.started": "🔄 Начинаю перезагрузку плейлиста...",
+	"reload_playlist.error":   "❌ Ошибка при перезагрузке плейлиста: %v",
+	"reload_playlist.done":    "✅ Плейлист успешно перезагружен!",
+
+	"changes.usage": "Использование: /changes [дни]\nКоличество дней от 1 до 365, по умолчанию 7",
+	"changes.error": "❌ Ошибка при получении изменений: %v",
+
+	"llm_metrics.title":    "📊 *Метрики LLM*\n\n",
+	"llm_metrics.error":    "❌ Ошибка: %v\n",
+	"llm_metrics.requests": "📈 Всего запросов: %v\n✅ Успешных: %v\n❌ Неудачных: %v\n",
+	"llm_metrics.last":     "🕐 Последний запрос: %s\n",
+	"llm_metrics.never":    "🕐 Последний запрос: никогда\n",
+	"llm_metrics.delay":    "⏱️ Задержка: %v мс\n",
+	"llm_metrics.cache":    "💾 Кэш: %v попаданий, %v промахов\n",
+
+	"alias.usage": "Использование:\n" +
+		"• /alias - все псевдонимы\n" +
+		"• /alias [артист] - псевдонимы артиста\n" +
+		"• /alias add [артист] = [псевдонимы] - добавить псевдонимы\n" +
+		"• /alias remove [артист] = [псевдонимы] - удалить псевдонимы\n\n" +
+		"Примеры:\n" +
+		"• /alias add IVE = IVE (아이브), 아이브\n" +
+		"• /alias remove tripleS = triple S",
+	"alias.conflict":   "⚠️ Псевдоним занят: %s",
+	"alias.error":      "❌ Ошибка при изменении псевдонимов: %v",
+	"alias.added":      "✅ Добавлено псевдонимов для %s: %d из %d",
+	"alias.removed":    "✅ Удалено псевдонимов для %s: %d из %d",
+	"alias.list_error": "❌ Ошибка при получении псевдонимов",
+	"alias.empty":      "Псевдонимы не найдены\n\n",
+	"alias.title":      "🏷 <b>Псевдонимы артистов:</b>\n",
+
+	"near_miss.title": "\n\n⚠️ <b>Похожие имена не сопоставлены:</b>\n",
+	"near_miss.hint":  "\nДобавить псевдоним: /alias add [артист] = [имя]",
+
+	"relation.usage": "Использование:\n" +
+		"• /relation - все связи\n" +
+		"• /relation [артист] - связи артиста\n" +
+		"• /relation add member|subunit|collab [артист] = [группа] - связать артиста с группой\n" +
+		"• /relation remove [артист] = [группа] - удалить связь\n" +
+		"• /relation include [группа] on|off - включать в список солистов, юниты и совместные проекты группы\n\n" +
+		"Примеры:\n" +
+		"• /relation add subunit IRENE & SEULGI = Red Velvet\n" +
+		"• /relation add member WENDY = Red Velvet\n" +
+		"• /relation include Red Velvet on",
+	"relation.self":             "⚠️ Артиста нельзя связать с самим собой",
+	"relation.add_error":        "❌ Ошибка при добавлении связи: %v",
+	"relation.added":            "✅ %s - %s %s",
+	"relation.created_inactive": "\n\nАртист добавлен неактивным: он попадает в список через группу с /relation include %s on",
+	"relation.remove_error":     "❌ Ошибка при удалении связи: %v",
+	"relation.not_found":        "Связь %s = %s не найдена",
+	"relation.removed":          "✅ Связь %s = %s удалена",
+	"relation.include_error":    "❌ Ошибка при изменении списка: %v",
+	"relation.included":         "✅ Солисты, юниты и совместные проекты %s включены в список",
+	"relation.excluded":         "✅ Солисты, юниты и совместные проекты %s исключены из списка",
+	"relation.list_error":       "❌ Ошибка при получении связей",
+	"relation.empty":            "Связи не найдены\n\n",
+	"relation.title":            "👥 <b>Связи артистов:</b>\n",
+	"relation.with_related":     " (в списке вместе со связанными)",
+
+	"role.owner":  "владелец",
+	"role.admin":  "администратор",
+	"role.editor": "редактор",
+	"role.user":   "пользователь",
+
+	"grant.usage":          "Использование: /grant [id|@username] [admin|editor]\nПример: /grant @moderator editor\n\n",
+	"grant.done":           "✅ %s теперь %s",
+	"revoke.usage":         "Использование: /revoke [id|@username]\nПример: /revoke @moderator",
+	"revoke.done":          "✅ Роль снята, %s теперь %s",
+	"roles.user_not_found": "❌ Пользователь %s не найден. Он должен хотя бы раз написать боту, либо укажите Telegram ID",
+	"roles.not_grantable":  "❌ Можно выдать только роли admin или editor",
+	"roles.insufficient":   "❌ Недостаточно прав: можно управлять только пользователями с ролью ниже вашей",
+	"roles.self":           "❌ Нельзя изменить собственную роль",
+	"roles.unchanged":      "ℹ️ Роль пользователя не изменилась",
+	"roles.error":          "❌ Ошибка при изменении роли: %v",
+	"roles.staff_error":    "Не удалось получить список ролей",
+	"roles.staff_empty":    "Роли еще не назначены",
+	"roles.staff_title":    "<b>Текущие роли:</b>\n",
+	"roles.staff_entry":    "• %s (id %s) - %s\n",
+
+	"audit.limit":     "Количество записей должно быть от 1 до %d",
+	"audit.error":     "❌ Ошибка при получении журнала аудита",
+	"audit.empty":     "📜 Журнал аудита пуст",
+	"audit.title":     "📜 <b>Журнал аудита</b> (последние %d)\n",
+	"audit.more":      "\n… и еще %d записей, уменьшите N или укажите команду",
+	"audit.arguments": "   Аргументы: <code>%s</code>\n",
+	"audit.before":    "   До: <code>%s</code>\n",
+	"audit.after":     "   После: <code>%s</code>\n",
+	"audit.failure":   "   Ошибка: %s\n",
+
+	"snapshots.usage":  "Использование: /snapshots [N], количество снимков от 1 до %d",
+	"snapshots.error":  "❌ Ошибка при получении снимков страниц",
+	"replay.usage":     "Использование: /replay [номер снимка]\nСписок снимков: /snapshots",
+	"replay.started":   "🔁 Разбираю снимок #%s...",
+	"replay.disabled":  "Снимки страниц не сохраняются",
+	"replay.error":     "❌ Ошибка при разборе снимка: %v",
+	"replay.not_found": "Снимок #%s не найден. Список снимков: /snapshots",
+
+	"discover.usage":         "Использование: /discover [N], количество артистов от 1 до %d",
+	"discover.error":         "❌ Ошибка при получении списка новых артистов",
+	"discover.found":         "\n\n🔎 Артистов не из списка: %d. Посмотреть: /discover",
+	"discover.action_female": "добавлен в женский список",
+	"discover.action_male":   "добавлен в мужской список",
+	"discover.action_ignore": "скрыт из отчета",
+	"discover.not_found":     "⚠️ Артист не найден",
+	"discover.already":       "⚠️ %s уже обработан",
+	"discover.resolve_error": "❌ Ошибка: %s",
+	"discover.resolved":      "✅ %s %s",
+}
+
+// ruPlurals русские сообщения с числом
+var ruPlurals = map[string]catalog.Message{
+	"homework.times": plural.Selectf(1, "%d",
+		"one", "%d раз",
+		"few", "%d раза",
+		"many", "%d раз",
+		"other", "%d раза"),
+}
@@ -0,0 +1,111 @@
+package keyboard
+
+import (
+	"errors"
+	"fmt"
+	"gemfactory/internal/model"
+	"gemfactory/internal/service"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Callback данные отчета /discover: discover_<действие>_<id>
+const (
+	discoverCallbackPrefix = "discover_"
+	discoverCommand        = "discover"
+	discoverDefaultRows    = 10
+)
+
+// discoverActionLabels ключи каталога с описанием решений для ответа администратору
+var discoverActionLabels = map[service.DiscoveryAction]string{
+	service.DiscoveryAddFemale: "discover.action_female",
+	service.DiscoveryAddMale:   "discover.action_male",
+	service.DiscoveryIgnore:    "discover.action_ignore",
+}
+
+// GetDiscoveryKeyboard возвращает кнопки решений по найденным артистам, по строке на артиста
+func (k *Manager) GetDiscoveryKeyboard(artists []model.DiscoveredArtist) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(artists))
+	for i, artist := range artists {
+		callback := func(action service.DiscoveryAction) string {
+			return fmt.Sprintf("%s%s_%d", discoverCallbackPrefix, action, artist.DiscoveredID)
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d. ♀", i+1), callback(service.DiscoveryAddFemale)),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d. ♂", i+1), callback(service.DiscoveryAddMale)),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d. 🚫", i+1), callback(service.DiscoveryIgnore)),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// handleDiscoverCallback применяет решение по найденному артисту и обновляет отчет
+func (k *Manager) handleDiscoverCallback(callback *tgbotapi.CallbackQuery) error {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+
+	// Кнопки видны всем участникам чата, поэтому права проверяются как у команды /discover
+	audit := k.services.Audit.Begin(callback.From.ID, callback.From.UserName, chatID, messageID, discoverCommand, callback.Data)
+	defer k.services.Audit.Finish(audit)
+
+	if !k.services.Access.CanExecute(callback.From.ID, callback.From.UserName, discoverCommand) {
+		audit.Deny()
+		k.logger.Warn("Unauthorized discover callback", zap.Int64("user_id", callback.From.ID))
+		return nil
+	}
+
+	lang := k.callbackLang(callback)
+	action, id, _ := strings.Cut(strings.TrimPrefix(callback.Data, discoverCallbackPrefix), "_")
+	discoveredID, err := strconv.Atoi(id)
+	label, known := discoverActionLabels[service.DiscoveryAction(action)]
+	if err != nil || !known {
+		audit.Invalid("invalid callback data")
+		return fmt.Errorf("invalid discover callback data %s", callback.Data)
+	}
+
+	var status string
+	discovered, err := k.services.Discovery.Resolve(discoveredID, service.DiscoveryAction(action))
+	switch {
+	case err == nil && discovered == nil:
+		audit.Invalid("discovered artist not found")
+		status = lang.T("discover.not_found")
+	case errors.Is(err, service.ErrDiscoveryResolved):
+		audit.Invalid(err.Error())
+		status = lang.T("discover.already", html.EscapeString(discovered.Name))
+	case err != nil:
+		audit.Fail(err)
+		k.logger.Error("Failed to resolve discovered artist", zap.Int("discovered_id", discoveredID), zap.Error(err))
+		status = lang.T("discover.resolve_error", html.EscapeString(err.Error()))
+	default:
+		audit.SetAfter(fmt.Sprintf("%s: %s", discovered.Name, action))
+		status = lang.T("discover.resolved", html.EscapeString(discovered.Name), lang.T(label))
+	}
+
+	limit := discoverDefaultRows
+	if markup := callback.Message.ReplyMarkup; markup != nil && len(markup.InlineKeyboard) > 0 {
+		limit = len(markup.InlineKeyboard)
+	}
+
+	artists, total, err := k.services.Discovery.GetPending(limit)
+	if err != nil {
+		return fmt.Errorf("failed to get discovered artists: %w", err)
+	}
+
+	if k.botAPI == nil {
+		k.logger.Warn("BotAPI not available, cannot edit message", zap.Int64("chat_id", chatID))
+		return nil
+	}
+
+	text := status + "\n\n" + k.services.Discovery.FormatPending(artists, total)
+	if err := k.botAPI.EditMessageTextWithMarkup(chatID, messageID, text, k.GetDiscoveryKeyboard(artists)); err != nil {
+		k.logger.Error("Failed to edit discover message", zap.Int64("chat_id", chatID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
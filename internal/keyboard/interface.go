@@ -1,12 +1,21 @@
 // Package keyboard содержит интерфейсы для управления клавиатурами Telegram-бота.
 package keyboard
 
-import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
 
 // ManagerInterface определяет интерфейс для менеджера клавиатур Telegram-бота.
 type ManagerInterface interface {
-	GetMainKeyboard() tgbotapi.InlineKeyboardMarkup
-	GetAllMonthsKeyboard() tgbotapi.InlineKeyboardMarkup
+	GetMainKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup
+	GetAllMonthsKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup
+	GetSubscriptionsKeyboard(subscriptions []model.Subscription) tgbotapi.InlineKeyboardMarkup
+	GetSettingsKeyboard(settings model.UserSettings, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup
+	GetDiscoveryKeyboard(artists []model.DiscoveredArtist) tgbotapi.InlineKeyboardMarkup
+	SendMonthReleases(chatID, userID int64, monthWithYear string, femaleOnly, maleOnly bool, releaseType model.ReleaseType, lang i18n.Lang) error
 	HandleCallbackQuery(callback *tgbotapi.CallbackQuery) error
 	Stop()
 }
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"gemfactory/internal/config"
 	"gemfactory/internal/external/telegram"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
 	"gemfactory/internal/service"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -21,8 +25,9 @@ type Manager struct {
 	logger            *zap.Logger
 	config            *config.Config
 	botAPI            telegram.BotAPI
-	allMonthsKeyboard tgbotapi.InlineKeyboardMarkup
-	mainMonthKeyboard tgbotapi.InlineKeyboardMarkup
+	mu                sync.RWMutex
+	allMonthsKeyboard map[i18n.Lang]tgbotapi.InlineKeyboardMarkup
+	mainMonthKeyboard map[i18n.Lang]tgbotapi.InlineKeyboardMarkup
 	stopChan          chan struct{}
 }
 
@@ -60,32 +65,41 @@ func (k *Manager) initKeyboards() {
 	k.updateMainMonthKeyboard()
 }
 
-// initAllMonthsKeyboard создает клавиатуру со всеми месяцами
+// initAllMonthsKeyboard создает клавиатуры со всеми месяцами для каждого языка
 func (k *Manager) initAllMonthsKeyboard() {
 	months := []string{
 		"january", "february", "march", "april", "may", "june",
 		"july", "august", "september", "october", "november", "december",
 	}
 
-	var rows [][]tgbotapi.InlineKeyboardButton
-	for i := 0; i < len(months); i += 3 {
-		var row []tgbotapi.InlineKeyboardButton
-		for j := 0; j < 3 && i+j < len(months); j++ {
-			month := months[i+j]
-			row = append(row, tgbotapi.NewInlineKeyboardButtonData(
-				cases.Title(language.English).String(month),
-				"month_"+month,
-			))
+	keyboards := make(map[i18n.Lang]tgbotapi.InlineKeyboardMarkup)
+	for _, lang := range i18n.Supported() {
+		var rows [][]tgbotapi.InlineKeyboardButton
+		for i := 0; i < len(months); i += 3 {
+			var row []tgbotapi.InlineKeyboardButton
+			for j := 0; j < 3 && i+j < len(months); j++ {
+				month := months[i+j]
+				row = append(row, tgbotapi.NewInlineKeyboardButtonData(monthButtonText(lang, month), "month_"+month))
+			}
+			rows = append(rows, row)
 		}
-		rows = append(rows, row)
+
+		// Добавляем кнопку "Назад"
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("keyboard.back"), "back_to_main"),
+		))
+
+		keyboards[lang] = tgbotapi.NewInlineKeyboardMarkup(rows...)
 	}
 
-	// Добавляем кнопку "Назад"
-	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("Назад", "back_to_main"),
-	))
+	k.mu.Lock()
+	k.allMonthsKeyboard = keyboards
+	k.mu.Unlock()
+}
 
-	k.allMonthsKeyboard = tgbotapi.NewInlineKeyboardMarkup(rows...)
+// monthButtonText возвращает название месяца для кнопки с заглавной буквы
+func monthButtonText(lang i18n.Lang, month string) string {
+	return cases.Title(language.Und).String(lang.MonthName(month))
 }
 
 // updateMainMonthKeyboard обновляет основную клавиатуру с текущим месяцем
@@ -111,25 +125,23 @@ func (k *Manager) updateMainMonthKeyboard() {
 		"july", "august", "september", "october", "november", "december",
 	}
 
-	buttons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(
-			cases.Title(language.English).String(months[prevMonth-1]),
-			"month_"+months[prevMonth-1],
-		),
-		tgbotapi.NewInlineKeyboardButtonData(
-			cases.Title(language.English).String(months[currentMonth-1]),
-			"month_"+months[currentMonth-1],
-		),
-		tgbotapi.NewInlineKeyboardButtonData(
-			cases.Title(language.English).String(months[nextMonth-1]),
-			"month_"+months[nextMonth-1],
-		),
-		tgbotapi.NewInlineKeyboardButtonData("...", "show_all_months"),
+	keyboards := make(map[i18n.Lang]tgbotapi.InlineKeyboardMarkup)
+	for _, lang := range i18n.Supported() {
+		buttons := []tgbotapi.InlineKeyboardButton{
+			tgbotapi.NewInlineKeyboardButtonData(monthButtonText(lang, months[prevMonth-1]), "month_"+months[prevMonth-1]),
+			tgbotapi.NewInlineKeyboardButtonData(monthButtonText(lang, months[currentMonth-1]), "month_"+months[currentMonth-1]),
+			tgbotapi.NewInlineKeyboardButtonData(monthButtonText(lang, months[nextMonth-1]), "month_"+months[nextMonth-1]),
+			tgbotapi.NewInlineKeyboardButtonData("...", "show_all_months"),
+		}
+
+		keyboards[lang] = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(buttons...),
+		)
 	}
 
-	k.mainMonthKeyboard = tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(buttons...),
-	)
+	k.mu.Lock()
+	k.mainMonthKeyboard = keyboards
+	k.mu.Unlock()
 
 	k.logger.Info("Updated main month keyboard", zap.String("current_month", months[currentMonth-1]))
 }
@@ -162,14 +174,73 @@ func (k *Manager) updateMainMonthKeyboardLoop() {
 	}
 }
 
-// GetMainKeyboard возвращает основную клавиатуру
-func (k *Manager) GetMainKeyboard() tgbotapi.InlineKeyboardMarkup {
-	return k.mainMonthKeyboard
+// GetMainKeyboard возвращает основную клавиатуру на указанном языке
+func (k *Manager) GetMainKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if keyboard, ok := k.mainMonthKeyboard[lang]; ok {
+		return keyboard
+	}
+	return k.mainMonthKeyboard[i18n.Default]
+}
+
+// GetAllMonthsKeyboard возвращает клавиатуру со всеми месяцами на указанном языке
+func (k *Manager) GetAllMonthsKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if keyboard, ok := k.allMonthsKeyboard[lang]; ok {
+		return keyboard
+	}
+	return k.allMonthsKeyboard[i18n.Default]
 }
 
-// GetAllMonthsKeyboard возвращает клавиатуру со всеми месяцами
-func (k *Manager) GetAllMonthsKeyboard() tgbotapi.InlineKeyboardMarkup {
-	return k.allMonthsKeyboard
+// callbackLang возвращает язык группы, если он задан в /chat, иначе язык пользователя, нажавшего кнопку
+func (k *Manager) callbackLang(callback *tgbotapi.CallbackQuery) i18n.Lang {
+	if chat := callbackChat(callback); chat != nil && !chat.IsPrivate() && k.services.ChatSettings != nil {
+		if lang, ok := k.services.ChatSettings.Language(chat.ID); ok {
+			return lang
+		}
+	}
+	if callback.From == nil || k.services.Locale == nil {
+		return i18n.Default
+	}
+	return k.services.Locale.Resolve(callback.From.ID, callback.From.LanguageCode)
+}
+
+// callbackGenderFilter возвращает фильтр /month по умолчанию для нажатой кнопки: фильтр группы или пользователя
+func (k *Manager) callbackGenderFilter(callback *tgbotapi.CallbackQuery) (femaleOnly, maleOnly bool) {
+	if chat := callbackChat(callback); chat != nil && !chat.IsPrivate() && k.services.ChatSettings != nil {
+		if femaleOnly, maleOnly, ok := k.services.ChatSettings.DefaultGenderFilter(chat.ID); ok {
+			return femaleOnly, maleOnly
+		}
+	}
+	return k.services.Settings.DefaultGenderFilter(callback.From.ID)
+}
+
+// callbackChat возвращает чат сообщения с кнопкой
+func callbackChat(callback *tgbotapi.CallbackQuery) *tgbotapi.Chat {
+	if callback.Message == nil {
+		return nil
+	}
+	return callback.Message.Chat
+}
+
+// GetSubscriptionsKeyboard возвращает клавиатуру с кнопками отписки от артистов
+func (k *Manager) GetSubscriptionsKeyboard(subscriptions []model.Subscription) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if subscription.Artist == nil {
+			continue
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"❌ "+subscription.Artist.Name,
+				fmt.Sprintf("unsub_%d", subscription.ArtistID),
+			),
+		))
+	}
+
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
 }
 
 // HandleCallbackQuery обрабатывает callback query от inline клавиатур
@@ -191,6 +262,22 @@ func (k *Manager) HandleCallbackQuery(callback *tgbotapi.CallbackQuery) error {
 		return k.handleBackToMainCallback(callback)
 	}
 
+	if strings.HasPrefix(data, "unsub_") {
+		return k.handleUnsubscribeCallback(callback)
+	}
+
+	if strings.HasPrefix(data, pageCallbackPrefix) {
+		return k.handlePageCallback(callback)
+	}
+
+	if strings.HasPrefix(data, settingsCallbackPrefix) {
+		return k.handleSettingsCallback(callback)
+	}
+
+	if strings.HasPrefix(data, discoverCallbackPrefix) {
+		return k.handleDiscoverCallback(callback)
+	}
+
 	k.logger.Warn("Unknown callback query", zap.String("data", data))
 	return fmt.Errorf("unknown callback query: %s", data)
 }
@@ -211,44 +298,40 @@ func (k *Manager) handleMonthCallback(callback *tgbotapi.CallbackQuery) error {
 		zap.Int("year", currentYear),
 		zap.String("month_with_year", monthWithYear))
 
-	// Получаем релизы за месяц текущего года
-	response, err := k.services.Release.GetReleasesForMonth(monthWithYear, false, false)
-	if err != nil {
-		k.logger.Error("Failed to get releases for month", zap.String("month", monthWithYear), zap.Error(err))
-		return fmt.Errorf("failed to get releases for month %s: %w", monthWithYear, err)
-	}
+	// Отправляем релизы за месяц текущего года с фильтром из настроек группы или пользователя
+	femaleOnly, maleOnly := k.callbackGenderFilter(callback)
+	return k.SendMonthReleases(chatID, callback.From.ID, monthWithYear, femaleOnly, maleOnly, "", k.callbackLang(callback))
+}
 
-	if response == "" {
-		k.logger.Warn("Empty response for month", zap.String("month", month))
-		response = fmt.Sprintf("Релизы для %s не найдены.", month)
-	}
+// handleShowAllMonthsCallback обрабатывает callback для показа всех месяцев
+func (k *Manager) handleShowAllMonthsCallback(callback *tgbotapi.CallbackQuery) error {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
 
-	// Отправляем ответ с основной клавиатурой
-	msg := tgbotapi.NewMessage(chatID, response)
-	msg.ReplyMarkup = k.GetMainKeyboard()
+	k.logger.Debug("Showing all months keyboard")
 
 	if k.botAPI != nil {
-		err := k.botAPI.SendMessageWithMarkup(chatID, response, msg.ReplyMarkup)
+		err := k.botAPI.EditMessageReplyMarkup(chatID, messageID, k.GetAllMonthsKeyboard(k.callbackLang(callback)))
 		if err != nil {
-			k.logger.Error("Failed to send message with markup", zap.Int64("chat_id", chatID), zap.Error(err))
+			k.logger.Error("Failed to edit message markup", zap.Int64("chat_id", chatID), zap.Error(err))
 			return err
 		}
 	} else {
-		k.logger.Warn("BotAPI not available, cannot send message", zap.Int64("chat_id", chatID))
+		k.logger.Warn("BotAPI not available, cannot edit message", zap.Int64("chat_id", chatID))
 	}
 
 	return nil
 }
 
-// handleShowAllMonthsCallback обрабатывает callback для показа всех месяцев
-func (k *Manager) handleShowAllMonthsCallback(callback *tgbotapi.CallbackQuery) error {
+// handleBackToMainCallback обрабатывает callback для возврата к основной клавиатуре
+func (k *Manager) handleBackToMainCallback(callback *tgbotapi.CallbackQuery) error {
 	chatID := callback.Message.Chat.ID
 	messageID := callback.Message.MessageID
 
-	k.logger.Debug("Showing all months keyboard")
+	k.logger.Debug("Returning to main keyboard")
 
 	if k.botAPI != nil {
-		err := k.botAPI.EditMessageReplyMarkup(chatID, messageID, k.GetAllMonthsKeyboard())
+		err := k.botAPI.EditMessageReplyMarkup(chatID, messageID, k.GetMainKeyboard(k.callbackLang(callback)))
 		if err != nil {
 			k.logger.Error("Failed to edit message markup", zap.Int64("chat_id", chatID), zap.Error(err))
 			return err
@@ -260,17 +343,32 @@ func (k *Manager) handleShowAllMonthsCallback(callback *tgbotapi.CallbackQuery)
 	return nil
 }
 
-// handleBackToMainCallback обрабатывает callback для возврата к основной клавиатуре
-func (k *Manager) handleBackToMainCallback(callback *tgbotapi.CallbackQuery) error {
+// handleUnsubscribeCallback обрабатывает callback для отписки от артиста
+func (k *Manager) handleUnsubscribeCallback(callback *tgbotapi.CallbackQuery) error {
 	chatID := callback.Message.Chat.ID
 	messageID := callback.Message.MessageID
+	userID := callback.From.ID
 
-	k.logger.Debug("Returning to main keyboard")
+	artistID, err := strconv.Atoi(strings.TrimPrefix(callback.Data, "unsub_"))
+	if err != nil {
+		return fmt.Errorf("invalid unsubscribe callback data %s: %w", callback.Data, err)
+	}
+
+	if _, err := k.services.Subscription.UnsubscribeByArtistID(userID, artistID); err != nil {
+		k.logger.Error("Failed to unsubscribe", zap.Int64("user_id", userID), zap.Int("artist_id", artistID), zap.Error(err))
+		return fmt.Errorf("failed to unsubscribe user %d from artist %d: %w", userID, artistID, err)
+	}
+
+	subscriptions, err := k.services.Subscription.GetUserSubscriptions(userID)
+	if err != nil {
+		return err
+	}
 
 	if k.botAPI != nil {
-		err := k.botAPI.EditMessageReplyMarkup(chatID, messageID, k.GetMainKeyboard())
+		text := k.services.Subscription.FormatSubscriptions(subscriptions, k.callbackLang(callback))
+		err := k.botAPI.EditMessageTextWithMarkup(chatID, messageID, text, k.GetSubscriptionsKeyboard(subscriptions))
 		if err != nil {
-			k.logger.Error("Failed to edit message markup", zap.Int64("chat_id", chatID), zap.Error(err))
+			k.logger.Error("Failed to edit subscriptions message", zap.Int64("chat_id", chatID), zap.Error(err))
 			return err
 		}
 	} else {
@@ -0,0 +1,174 @@
+package keyboard
+
+import (
+	"fmt"
+	"gemfactory/internal/external/telegram"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// pageCallbackPrefix префикс callback данных листания релизов месяца: page_<месяц-год>_<фильтр>_<страница>.
+// Фильтр - пол и, через точку, тип релиза: f, a.album
+const pageCallbackPrefix = "page_"
+
+// pageNoopCallback callback кнопки с номером страницы
+const pageNoopCallback = "page_noop"
+
+// maxCallbackDataLength ограничение Telegram на длину callback данных в байтах
+const maxCallbackDataLength = 64
+
+// Фильтры релизов в callback данных
+const (
+	filterAll    = "a"
+	filterFemale = "f"
+	filterMale   = "m"
+)
+
+// filterTypeSeparator отделяет тип релиза от пола в фильтре callback данных
+const filterTypeSeparator = "."
+
+// SendMonthReleases отправляет релизы за месяц. Длинный список разбивается на страницы с кнопками ◀ ▶
+func (k *Manager) SendMonthReleases(chatID, userID int64, monthWithYear string, femaleOnly, maleOnly bool, releaseType model.ReleaseType, lang i18n.Lang) error {
+	filter := filterAll
+	if femaleOnly {
+		filter = filterFemale
+	} else if maleOnly {
+		filter = filterMale
+	}
+	if releaseType != "" {
+		filter += filterTypeSeparator + releaseType.String()
+	}
+
+	pages, err := k.monthPages(monthWithYear, filter, userID, lang)
+	if err != nil {
+		return err
+	}
+
+	if k.botAPI == nil {
+		k.logger.Warn("BotAPI not available, cannot send message", zap.Int64("chat_id", chatID))
+		return nil
+	}
+
+	// Месяц, который не поместится в callback данные, отправляется целиком несколькими сообщениями
+	text := pages[0]
+	if len(pages) > 1 && !pageable(monthWithYear) {
+		text = strings.Join(pages, "\n")
+		pages = pages[:1]
+	}
+
+	if err := k.botAPI.SendMessageWithMarkup(chatID, text, k.pageKeyboard(monthWithYear, filter, 0, len(pages), lang)); err != nil {
+		k.logger.Error("Failed to send message with markup", zap.Int64("chat_id", chatID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// handlePageCallback открывает страницу релизов месяца в том же сообщении
+func (k *Manager) handlePageCallback(callback *tgbotapi.CallbackQuery) error {
+	if callback.Data == pageNoopCallback {
+		return nil
+	}
+
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+
+	// Месяц не содержит подчеркиваний, поэтому разбираем данные с конца
+	parts := strings.Split(strings.TrimPrefix(callback.Data, pageCallbackPrefix), "_")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid page callback data: %s", callback.Data)
+	}
+	monthWithYear, filter := parts[0], parts[1]
+	page, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return fmt.Errorf("invalid page callback data %s: %w", callback.Data, err)
+	}
+
+	// Релизы перечитываются, поэтому число страниц могло измениться
+	lang := k.callbackLang(callback)
+	pages, err := k.monthPages(monthWithYear, filter, callback.From.ID, lang)
+	if err != nil {
+		return err
+	}
+	page = max(0, min(page, len(pages)-1))
+
+	k.logger.Debug("Processing page callback",
+		zap.String("month", monthWithYear),
+		zap.String("filter", filter),
+		zap.Int("page", page),
+		zap.Int("pages", len(pages)))
+
+	if k.botAPI == nil {
+		k.logger.Warn("BotAPI not available, cannot edit message", zap.Int64("chat_id", chatID))
+		return nil
+	}
+
+	err = k.botAPI.EditMessageTextWithMarkup(chatID, messageID, pages[page], k.pageKeyboard(monthWithYear, filter, page, len(pages), lang))
+	if err != nil {
+		k.logger.Error("Failed to edit page message", zap.Int64("chat_id", chatID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// monthPages возвращает релизы за месяц в настройках пользователя, разбитые на страницы
+func (k *Manager) monthPages(monthWithYear, filter string, userID int64, lang i18n.Lang) ([]string, error) {
+	gender, releaseType, _ := strings.Cut(filter, filterTypeSeparator)
+	response, err := k.services.Release.GetReleasesForMonth(monthWithYear, gender == filterFemale, gender == filterMale,
+		model.ReleaseType(releaseType), userID, lang)
+	if err != nil {
+		k.logger.Error("Failed to get releases for month", zap.String("month", monthWithYear), zap.Error(err))
+		return nil, fmt.Errorf("failed to get releases for month %s: %w", monthWithYear, err)
+	}
+
+	if response == "" {
+		k.logger.Warn("Empty response for month", zap.String("month", monthWithYear))
+		response = lang.T("releases.month_empty", monthWithYear)
+	}
+
+	return telegram.SplitMessage(response, telegram.MaxMessageLength), nil
+}
+
+// pageable проверяет, что месяц можно передать в callback данных листания
+func pageable(monthWithYear string) bool {
+	longest := fmt.Sprintf("%s%s_%s%s%s_%d", pageCallbackPrefix, monthWithYear, filterAll, filterTypeSeparator, model.ReleaseTypeDigitalSingle, 999)
+	return !strings.Contains(monthWithYear, "_") && len(longest) <= maxCallbackDataLength
+}
+
+// pageKeyboard возвращает основную клавиатуру с кнопками листания, если страниц несколько
+func (k *Manager) pageKeyboard(monthWithYear, filter string, page, total int, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	mainKeyboard := k.GetMainKeyboard(lang)
+	if total <= 1 {
+		return mainKeyboard
+	}
+
+	pageData := func(target int) string {
+		if target < 0 || target >= total {
+			return pageNoopCallback
+		}
+		return fmt.Sprintf("%s%s_%s_%d", pageCallbackPrefix, monthWithYear, filter, target)
+	}
+
+	prev, next := "◀", "▶"
+	if page == 0 {
+		prev = " "
+	}
+	if page == total-1 {
+		next = " "
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(prev, pageData(page-1)),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d/%d", page+1, total), pageNoopCallback),
+			tgbotapi.NewInlineKeyboardButtonData(next, pageData(page+1)),
+		),
+	}
+	rows = append(rows, mainKeyboard.InlineKeyboard...)
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,131 @@
+package keyboard
+
+import (
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/service"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// Callback данные меню /settings
+const (
+	settingsCallbackPrefix = "settings_"
+	settingsTimezonesMenu  = "settings_tzmenu"
+	settingsBack           = "settings_back"
+	settingsTimezonePrefix = "settings_tz_"
+	settingsGenderPrefix   = "settings_gender_"
+	settingsLayoutPrefix   = "settings_layout_"
+)
+
+// GetSettingsKeyboard возвращает меню настроек с отмеченными текущими значениями
+func (k *Manager) GetSettingsKeyboard(settings model.UserSettings, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	mark := func(selected bool, text string) string {
+		if selected {
+			return "✅ " + text
+		}
+		return text
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("settings.timezone_button", settings.Timezone), settingsTimezonesMenu),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(mark(settings.GenderFilter == "", lang.T("settings.gender_all")), settingsGenderPrefix+filterAll),
+			tgbotapi.NewInlineKeyboardButtonData(mark(settings.GenderFilter == model.GenderFemale, lang.T("settings.gender_female")), settingsGenderPrefix+filterFemale),
+			tgbotapi.NewInlineKeyboardButtonData(mark(settings.GenderFilter == model.GenderMale, lang.T("settings.gender_male")), settingsGenderPrefix+filterMale),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(mark(settings.Layout == model.LayoutCompact, lang.T("settings.layout_compact")), settingsLayoutPrefix+string(model.LayoutCompact)),
+			tgbotapi.NewInlineKeyboardButtonData(mark(settings.Layout == model.LayoutVerbose, lang.T("settings.layout_verbose")), settingsLayoutPrefix+string(model.LayoutVerbose)),
+		),
+	)
+}
+
+// timezonesKeyboard возвращает список часовых поясов меню /settings
+func (k *Manager) timezonesKeyboard(settings model.UserSettings, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i := 0; i < len(service.SettingsTimezones); i += 2 {
+		var row []tgbotapi.InlineKeyboardButton
+		for j := 0; j < 2 && i+j < len(service.SettingsTimezones); j++ {
+			timezone := service.SettingsTimezones[i+j]
+			text := timezone
+			if timezone == settings.Timezone {
+				text = "✅ " + timezone
+			}
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(text, settingsTimezonePrefix+timezone))
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("keyboard.back"), settingsBack),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// handleSettingsCallback изменяет настройку и обновляет сообщение с меню
+func (k *Manager) handleSettingsCallback(callback *tgbotapi.CallbackQuery) error {
+	data := callback.Data
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := callback.From.ID
+	lang := k.callbackLang(callback)
+	before := k.services.Settings.Get(userID)
+
+	var err error
+	showTimezones := false
+	switch {
+	case data == settingsTimezonesMenu:
+		showTimezones = true
+	case data == settingsBack:
+	case strings.HasPrefix(data, settingsTimezonePrefix):
+		err = k.services.Settings.SetTimezone(userID, strings.TrimPrefix(data, settingsTimezonePrefix))
+	case strings.HasPrefix(data, settingsGenderPrefix):
+		var gender model.Gender
+		switch strings.TrimPrefix(data, settingsGenderPrefix) {
+		case filterFemale:
+			gender = model.GenderFemale
+		case filterMale:
+			gender = model.GenderMale
+		}
+		err = k.services.Settings.SetGenderFilter(userID, gender)
+	case strings.HasPrefix(data, settingsLayoutPrefix):
+		err = k.services.Settings.SetLayout(userID, model.Layout(strings.TrimPrefix(data, settingsLayoutPrefix)))
+	default:
+		return fmt.Errorf("unknown settings callback: %s", data)
+	}
+	if err != nil {
+		k.logger.Error("Failed to update settings", zap.Int64("user_id", userID), zap.String("data", data), zap.Error(err))
+		return fmt.Errorf("failed to update settings of user %d: %w", userID, err)
+	}
+
+	if k.botAPI == nil {
+		k.logger.Warn("BotAPI not available, cannot edit message", zap.Int64("chat_id", chatID))
+		return nil
+	}
+
+	settings := k.services.Settings.Get(userID)
+	unchanged := settings.Timezone == before.Timezone && settings.GenderFilter == before.GenderFilter && settings.Layout == before.Layout
+	if unchanged && (strings.HasPrefix(data, settingsGenderPrefix) || strings.HasPrefix(data, settingsLayoutPrefix)) {
+		// Повторное нажатие на выбранное значение: Telegram не позволяет отправить то же сообщение
+		return nil
+	}
+
+	markup := k.GetSettingsKeyboard(settings, lang)
+	if showTimezones {
+		markup = k.timezonesKeyboard(settings, lang)
+	}
+
+	text := k.services.Settings.FormatSettings(settings, lang)
+	if err := k.botAPI.EditMessageTextWithMarkup(chatID, messageID, text, markup); err != nil {
+		k.logger.Error("Failed to edit settings message", zap.Int64("chat_id", chatID), zap.Error(err))
+		return err
+	}
+	return nil
+}
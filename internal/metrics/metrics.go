@@ -0,0 +1,175 @@
+// Package metrics содержит метрики приложения в формате Prometheus.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gemfactory"
+
+// Статусы для меток status
+const (
+	StatusSuccess      = "success"
+	StatusError        = "error"
+	StatusUnauthorized = "unauthorized"
+	StatusPanic        = "panic"
+)
+
+// Причины отклонения обновлений middleware
+const (
+	RejectionRateLimit = "rate_limit"
+	RejectionDebounce  = "debounce"
+)
+
+// Registry реестр метрик приложения
+var Registry = prometheus.NewRegistry()
+
+// factory создает метрики, зарегистрированные в Registry
+var factory = promauto.With(Registry)
+
+var (
+	// CommandsTotal количество обработанных команд и callback'ов
+	CommandsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "commands_total",
+		Help:      "Handled bot commands by command and status.",
+	}, []string{"command", "status"})
+
+	// CommandDuration время обработки команд
+	CommandDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "command_duration_seconds",
+		Help:      "Bot command handling latency.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"command"})
+
+	// MiddlewareRejectionsTotal количество обновлений, отклоненных middleware
+	MiddlewareRejectionsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "middleware_rejections_total",
+		Help:      "Updates rejected by middleware by reason.",
+	}, []string{"reason"})
+
+	// LLMRequestsTotal количество запросов к LLM провайдеру
+	LLMRequestsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "llm_requests_total",
+		Help:      "LLM completion requests by provider, model and status.",
+	}, []string{"provider", "model", "status"})
+
+	// LLMRequestDuration время ответа LLM провайдера
+	LLMRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "llm_request_duration_seconds",
+		Help:      "LLM completion request latency.",
+		Buckets:   []float64{0.5, 1, 2, 5, 10, 20, 30, 60, 120},
+	}, []string{"provider", "model"})
+
+	// LLMCacheLookupsTotal обращения к кэшу ответов LLM
+	LLMCacheLookupsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "llm_cache_lookups_total",
+		Help:      "LLM response cache lookups by result (hit or miss).",
+	}, []string{"result"})
+
+	// ScraperRequestsTotal количество HTTP запросов скрейпера
+	ScraperRequestsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scraper_requests_total",
+		Help:      "Scraper HTTP requests by host and status.",
+	}, []string{"host", "status"})
+
+	// ScraperRequestDuration время HTTP запросов скрейпера
+	ScraperRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scraper_request_duration_seconds",
+		Help:      "Scraper HTTP request latency.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"host"})
+
+	// TaskRunsTotal количество запусков задач планировщика
+	TaskRunsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "task_runs_total",
+		Help:      "Scheduled task runs by task and status.",
+	}, []string{"task", "status"})
+
+	// TaskRunDuration время выполнения задач планировщика
+	TaskRunDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_run_duration_seconds",
+		Help:      "Scheduled task run duration.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600},
+	}, []string{"task"})
+
+	// DBQueryDuration время выполнения запросов к базе данных
+	DBQueryDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by operation.",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 5},
+	}, []string{"operation"})
+
+	// DBQueryErrorsTotal количество ошибок запросов к базе данных
+	DBQueryErrorsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "Failed database queries by operation.",
+	}, []string{"operation"})
+)
+
+// init регистрирует стандартные метрики Go рантайма и процесса
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler возвращает HTTP обработчик /metrics
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Status возвращает значение метки status по ошибке
+func Status(err error) string {
+	if err != nil {
+		return StatusError
+	}
+	return StatusSuccess
+}
+
+// ObserveCommand учитывает обработку команды
+func ObserveCommand(command, status string, duration time.Duration) {
+	CommandsTotal.WithLabelValues(command, status).Inc()
+	CommandDuration.WithLabelValues(command).Observe(duration.Seconds())
+}
+
+// ObserveLLMRequest учитывает запрос к LLM провайдеру
+func ObserveLLMRequest(provider, model string, err error, duration time.Duration) {
+	LLMRequestsTotal.WithLabelValues(provider, model, Status(err)).Inc()
+	LLMRequestDuration.WithLabelValues(provider, model).Observe(duration.Seconds())
+}
+
+// ObserveScraperRequest учитывает HTTP запрос скрейпера
+func ObserveScraperRequest(host, status string, duration time.Duration) {
+	ScraperRequestsTotal.WithLabelValues(host, status).Inc()
+	ScraperRequestDuration.WithLabelValues(host).Observe(duration.Seconds())
+}
+
+// ObserveTaskRun учитывает запуск задачи планировщика
+func ObserveTaskRun(task, status string, duration time.Duration) {
+	TaskRunsTotal.WithLabelValues(task, status).Inc()
+	TaskRunDuration.WithLabelValues(task).Observe(duration.Seconds())
+}
+
+// IncMiddlewareRejection учитывает отклоненное middleware обновление
+func IncMiddlewareRejection(reason string) {
+	MiddlewareRejectionsTotal.WithLabelValues(reason).Inc()
+}
@@ -1,32 +1,25 @@
-// Package middleware содержит middleware для проверки прав администратора.
+// Package middleware содержит middleware для проверки прав доступа.
 package middleware
 
 import (
-	"gemfactory/internal/config"
-
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
 )
 
-// AdminOnlyMiddleware ограничивает доступ только администраторам с лучшей валидацией
-func AdminOnlyMiddleware(adminUsername string, logger *zap.Logger) func(update tgbotapi.Update, next func(tgbotapi.Update)) {
+// PermissionChecker проверяет права пользователя на выполнение команды
+type PermissionChecker interface {
+	CanExecute(userID int64, username, command string) bool
+}
+
+// AdminOnlyMiddleware пропускает команды только пользователям с достаточной ролью
+func AdminOnlyMiddleware(checker PermissionChecker, logger *zap.Logger) func(update tgbotapi.Update, next func(tgbotapi.Update)) {
 	return func(update tgbotapi.Update, next func(tgbotapi.Update)) {
 		if update.Message == nil {
 			next(update)
 			return
 		}
 
-		if update.Message.From == nil {
-			logger.Warn("No user information in message")
-			return
-		}
-
-		if update.Message.From.UserName != adminUsername {
-			user := getUserIdentifier(update.Message.From)
-			logger.Warn("Unauthorized access attempt",
-				zap.String("command", update.Message.Command()),
-				zap.String("user", user),
-				zap.String("expected_admin", adminUsername))
+		if !checkPermission(checker, update.Message, logger) {
 			return
 		}
 
@@ -34,24 +27,14 @@ func AdminOnlyMiddleware(adminUsername string, logger *zap.Logger) func(update t
 	}
 }
 
-// AdminOnlyMiddlewareWithError ограничивает доступ только администраторам с обработкой ошибок
-func AdminOnlyMiddlewareWithError(adminUsername string, logger *zap.Logger) func(update tgbotapi.Update, next func(tgbotapi.Update) error) error {
+// AdminOnlyMiddlewareWithError пропускает команды только пользователям с достаточной ролью с обработкой ошибок
+func AdminOnlyMiddlewareWithError(checker PermissionChecker, logger *zap.Logger) func(update tgbotapi.Update, next func(tgbotapi.Update) error) error {
 	return func(update tgbotapi.Update, next func(tgbotapi.Update) error) error {
 		if update.Message == nil {
 			return next(update)
 		}
 
-		if update.Message.From == nil {
-			logger.Warn("No user information in message")
-			return nil
-		}
-
-		if update.Message.From.UserName != adminUsername {
-			user := getUserIdentifier(update.Message.From)
-			logger.Warn("Unauthorized access attempt",
-				zap.String("command", update.Message.Command()),
-				zap.String("user", user),
-				zap.String("expected_admin", adminUsername))
+		if !checkPermission(checker, update.Message, logger) {
 			return nil
 		}
 
@@ -59,12 +42,24 @@ func AdminOnlyMiddlewareWithError(adminUsername string, logger *zap.Logger) func
 	}
 }
 
-// AdminOnlyMiddlewareWithConfig ограничивает доступ только администраторам с использованием конфигурации
-func AdminOnlyMiddlewareWithConfig(config *config.Config, logger *zap.Logger) func(update tgbotapi.Update, next func(tgbotapi.Update)) {
-	return AdminOnlyMiddleware(config.AdminUsername, logger)
-}
+// checkPermission проверяет права автора сообщения на команду
+func checkPermission(checker PermissionChecker, message *tgbotapi.Message, logger *zap.Logger) bool {
+	if message.From == nil {
+		logger.Warn("No user information in message")
+		return false
+	}
+
+	if checker == nil {
+		logger.Warn("Permission checker is not configured")
+		return false
+	}
+
+	if !checker.CanExecute(message.From.ID, message.From.UserName, message.Command()) {
+		logger.Warn("Unauthorized access attempt",
+			zap.String("command", message.Command()),
+			zap.String("user", getUserIdentifier(message.From)))
+		return false
+	}
 
-// AdminOnlyMiddlewareWithConfigAndError ограничивает доступ только администраторам с использованием конфигурации и обработкой ошибок
-func AdminOnlyMiddlewareWithConfigAndError(config *config.Config, logger *zap.Logger) func(update tgbotapi.Update, next func(tgbotapi.Update) error) error {
-	return AdminOnlyMiddlewareWithError(config.AdminUsername, logger)
+	return true
 }
@@ -3,6 +3,7 @@ package middleware
 
 import (
 	"fmt"
+	"gemfactory/internal/metrics"
 	"strings"
 	"sync"
 	"time"
@@ -82,7 +83,8 @@ func (d *Debouncer) Cleanup() {
 // DebounceMiddleware предотвращает двойные клики с контекстным таймаутом
 func DebounceMiddleware(debouncer DebouncerInterface, logger *zap.Logger) func(update tgbotapi.Update, next func(tgbotapi.Update)) {
 	return func(update tgbotapi.Update, next func(tgbotapi.Update)) {
-		if update.Message == nil {
+		// Дебаунсятся только команды, обычная переписка в группе проходит дальше
+		if update.Message == nil || !update.Message.IsCommand() {
 			next(update)
 			return
 		}
@@ -108,6 +110,7 @@ func DebounceMiddleware(debouncer DebouncerInterface, logger *zap.Logger) func(u
 				zap.String("user", user),
 				zap.Int("update_id", update.UpdateID),
 				zap.Duration("timeout", timeout))
+			metrics.IncMiddlewareRejection(metrics.RejectionDebounce)
 
 			return
 		}
@@ -119,7 +122,7 @@ func DebounceMiddleware(debouncer DebouncerInterface, logger *zap.Logger) func(u
 // DebounceMiddlewareWithError предотвращает двойные клики с обработкой ошибок
 func DebounceMiddlewareWithError(debouncer DebouncerInterface, logger *zap.Logger) func(update tgbotapi.Update, next func(tgbotapi.Update) error) error {
 	return func(update tgbotapi.Update, next func(tgbotapi.Update) error) error {
-		if update.Message == nil {
+		if update.Message == nil || !update.Message.IsCommand() {
 			return next(update)
 		}
 
@@ -144,6 +147,7 @@ func DebounceMiddlewareWithError(debouncer DebouncerInterface, logger *zap.Logge
 				zap.String("user", user),
 				zap.Int("update_id", update.UpdateID),
 				zap.Duration("timeout", timeout))
+			metrics.IncMiddlewareRejection(metrics.RejectionDebounce)
 
 			return nil
 		}
@@ -193,6 +197,7 @@ func DebounceCallbackMiddleware(debouncer DebouncerInterface, logger *zap.Logger
 				zap.String("user", user),
 				zap.Int("update_id", update.UpdateID),
 				zap.Duration("timeout", timeout))
+			metrics.IncMiddlewareRejection(metrics.RejectionDebounce)
 
 			return
 		}
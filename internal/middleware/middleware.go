@@ -3,6 +3,7 @@ package middleware
 
 import (
 	"gemfactory/internal/config"
+	"gemfactory/internal/metrics"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -11,10 +12,12 @@ import (
 
 // Middleware представляет middleware компонент
 type Middleware struct {
-	rateLimiter RateLimiterInterface
-	debouncer   DebouncerInterface
-	logger      *zap.Logger
-	config      *config.Config
+	rateLimiter     RateLimiterInterface
+	chatRateLimiter RateLimiterInterface // Общий лимит команд группового чата
+	debouncer       DebouncerInterface
+	permissions     PermissionChecker
+	logger          *zap.Logger
+	config          *config.Config
 }
 
 // New создает новый middleware
@@ -22,24 +25,40 @@ func New(config *config.Config, logger *zap.Logger) *Middleware {
 	// Создаем rate limiter (10 запросов в минуту)
 	rateLimiter := NewRateLimiter(10, 60*time.Second, logger)
 
+	// Создаем rate limiter для групп (30 команд в минуту на чат)
+	chatRateLimiter := NewRateLimiter(30, 60*time.Second, logger)
+
 	// Создаем debouncer (1 секунда между запросами)
 	debouncer := NewDebouncer(1*time.Second, logger)
 
 	return &Middleware{
-		rateLimiter: rateLimiter,
-		debouncer:   debouncer,
-		logger:      logger,
-		config:      config,
+		rateLimiter:     rateLimiter,
+		chatRateLimiter: chatRateLimiter,
+		debouncer:       debouncer,
+		logger:          logger,
+		config:          config,
 	}
 }
 
+// SetPermissionChecker устанавливает проверку прав для admin middleware
+func (m *Middleware) SetPermissionChecker(checker PermissionChecker) {
+	m.permissions = checker
+}
+
 // Process обрабатывает обновление
 func (m *Middleware) Process(update tgbotapi.Update) bool {
-	// Применяем rate limiting
-	if update.Message != nil {
-		userID := update.Message.From.ID
-		if !m.rateLimiter.Allow(userID) {
-			m.logger.Warn("Rate limit exceeded", zap.Int64("user_id", userID))
+	// Применяем rate limiting только к командам: обычная переписка в группе не расходует лимит
+	message := update.Message
+	if message != nil && message.IsCommand() {
+		if message.From != nil && !m.rateLimiter.Allow(message.From.ID) {
+			m.logger.Warn("Rate limit exceeded", zap.Int64("user_id", message.From.ID))
+			metrics.IncMiddlewareRejection(metrics.RejectionRateLimit)
+			return false
+		}
+
+		if !message.Chat.IsPrivate() && !m.chatRateLimiter.Allow(message.Chat.ID) {
+			m.logger.Warn("Chat rate limit exceeded", zap.Int64("chat_id", message.Chat.ID))
+			metrics.IncMiddlewareRejection(metrics.RejectionRateLimit)
 			return false
 		}
 	}
@@ -97,17 +116,18 @@ func (m *Middleware) ProcessWithMiddlewareAndError(update tgbotapi.Update, handl
 
 // GetAdminMiddleware возвращает middleware для проверки прав администратора
 func (m *Middleware) GetAdminMiddleware() func(update tgbotapi.Update, next func(tgbotapi.Update)) {
-	return AdminOnlyMiddlewareWithConfig(m.config, m.logger)
+	return AdminOnlyMiddleware(m.permissions, m.logger)
 }
 
 // GetAdminMiddlewareWithError возвращает middleware для проверки прав администратора с обработкой ошибок
 func (m *Middleware) GetAdminMiddlewareWithError() func(update tgbotapi.Update, next func(tgbotapi.Update) error) error {
-	return AdminOnlyMiddlewareWithConfigAndError(m.config, m.logger)
+	return AdminOnlyMiddlewareWithError(m.permissions, m.logger)
 }
 
 // Cleanup очищает устаревшие записи в middleware
 func (m *Middleware) Cleanup() {
 	m.rateLimiter.Cleanup()
+	m.chatRateLimiter.Cleanup()
 	m.debouncer.Cleanup()
 }
 
@@ -20,6 +20,9 @@ type Artist struct {
 	IsActive  bool      `bun:"is_active,notnull,default:true" json:"is_active"`
 	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+
+	// IncludeRelated включает в список солистов, юниты и совместные проекты артиста
+	IncludeRelated bool `bun:"include_related,notnull" json:"include_related"`
 }
 
 // Validate проверяет валидность артиста
@@ -69,6 +72,7 @@ type ArtistRepository interface {
 	Repository[Artist]
 	GetByGender(gender Gender) ([]Artist, error)
 	GetByName(name string) (*Artist, error)
+	SearchByName(query string, limit int) ([]Artist, error)
 	GetActive() ([]Artist, error)
 	GetByGenderAndActive(gender Gender, active bool) ([]Artist, error)
 }
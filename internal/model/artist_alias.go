@@ -0,0 +1,34 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: ArtistAlias, ArtistAliasRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ArtistAlias представляет альтернативное написание имени артиста на сайтах-источниках
+type ArtistAlias struct {
+	bun.BaseModel `bun:"table:gemfactory.artist_aliases,alias:artist_alias"`
+
+	AliasID    int       `bun:"alias_id,pk,autoincrement" json:"alias_id"`
+	ArtistID   int       `bun:"artist_id,notnull" json:"artist_id"`
+	Alias      string    `bun:"alias,notnull" json:"alias"`
+	Normalized string    `bun:"normalized,notnull,unique" json:"normalized"` // NormalizeArtistName(Alias)
+	CreatedAt  time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+
+	// Связи
+	Artist *Artist `bun:"rel:belongs-to,join:artist_id=artist_id" json:"artist,omitempty"`
+}
+
+// ArtistAliasRepository определяет интерфейс для работы с псевдонимами артистов
+type ArtistAliasRepository interface {
+	GetAll() ([]ArtistAlias, error)
+	GetByArtist(artistID int) ([]ArtistAlias, error)
+	GetByNormalized(normalized string) (*ArtistAlias, error)
+	Create(alias *ArtistAlias) error
+	Delete(artistID int, normalized string) (bool, error)
+}
@@ -0,0 +1,221 @@
+// Package model содержит утилиты для сопоставления имен артистов.
+//
+// Группа: UTILS - Утилиты для артистов
+// Содержит: ArtistMatcher, ArtistMatch, NormalizeArtistName
+package model
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Пороги триграммного сходства имен артистов
+const (
+	// ArtistMatchThreshold сходство, начиная с которого имя считается тем же артистом
+	ArtistMatchThreshold = 0.7
+	// ArtistNearMissThreshold сходство, начиная с которого артист предлагается как похожий
+	ArtistNearMissThreshold = 0.4
+)
+
+// artistBracketPattern находит пояснения в скобках: "IVE (아이브)", "tripleS [트리플에스]"
+var artistBracketPattern = regexp.MustCompile(`[(\[（【][^)\]）】]*[)\]）】]`)
+
+// NormalizeArtistName приводит имя артиста к форме для сравнения: декодирует HTML-сущности,
+// применяет NFKC, убирает диакритику, регистр, пояснения в скобках, пробелы и пунктуацию.
+// Символ & сохраняется, так как он часть имени (&TEAM)
+func NormalizeArtistName(name string) string {
+	name = html.UnescapeString(name)
+	name = norm.NFKC.String(name)
+	name = strings.ToLower(name)
+
+	// Пояснения в скобках убираем, только если вне скобок что-то остается
+	if stripped := artistBracketPattern.ReplaceAllString(name, " "); strings.TrimSpace(stripped) != "" {
+		name = stripped
+	}
+
+	var result strings.Builder
+	for _, r := range norm.NFKD.String(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '&' {
+			result.WriteRune(r)
+		}
+	}
+
+	// Хангыль при NFKD раскладывается на чамо, собираем слоги обратно
+	return norm.NFC.String(result.String())
+}
+
+// artistTrigrams возвращает триграммы нормализованного имени с выравниванием как в pg_trgm
+func artistTrigrams(normalized string) map[string]struct{} {
+	runes := []rune("  " + normalized + " ")
+	trigrams := make(map[string]struct{}, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		trigrams[string(runes[i:i+3])] = struct{}{}
+	}
+	return trigrams
+}
+
+// trigramSimilarity возвращает долю общих триграмм (коэффициент Жаккара)
+func trigramSimilarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for trigram := range a {
+		if _, ok := b[trigram]; ok {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// ArtistNameSimilarity возвращает триграммное сходство двух имен артистов от 0 до 1
+func ArtistNameSimilarity(a, b string) float64 {
+	normalizedA, normalizedB := NormalizeArtistName(a), NormalizeArtistName(b)
+	if normalizedA == "" || normalizedB == "" {
+		return 0
+	}
+	if normalizedA == normalizedB {
+		return 1
+	}
+	return trigramSimilarity(artistTrigrams(normalizedA), artistTrigrams(normalizedB))
+}
+
+// ArtistMatch результат сопоставления имени с артистом
+type ArtistMatch struct {
+	Artist  Artist
+	Matched string  // имя или псевдоним артиста, с которым совпало имя
+	Score   float64 // сходство от 0 до 1
+	Exact   bool    // совпадение после нормализации
+}
+
+// artistMatchEntry имя или псевдоним артиста в индексе
+type artistMatchEntry struct {
+	artist   Artist
+	name     string
+	trigrams map[string]struct{}
+}
+
+// ArtistMatcher сопоставляет имена из источников с артистами по именам и псевдонимам:
+// сначала точно после нормализации, затем по триграммному сходству
+type ArtistMatcher struct {
+	exact   map[string]int
+	entries []artistMatchEntry
+}
+
+// NewArtistMatcher строит индекс артистов. Псевдонимы артистов, которых нет в списке, пропускаются
+func NewArtistMatcher(artists []Artist, aliases []ArtistAlias) *ArtistMatcher {
+	matcher := &ArtistMatcher{
+		exact: make(map[string]int, len(artists)+len(aliases)),
+	}
+
+	byID := make(map[int]Artist, len(artists))
+	for _, artist := range artists {
+		byID[artist.ArtistID] = artist
+		matcher.add(artist, artist.Name)
+	}
+
+	for _, alias := range aliases {
+		if artist, ok := byID[alias.ArtistID]; ok {
+			matcher.add(artist, alias.Alias)
+		}
+	}
+
+	return matcher
+}
+
+// add добавляет имя артиста в индекс (первое добавленное имя выигрывает при совпадении)
+func (m *ArtistMatcher) add(artist Artist, name string) {
+	normalized := NormalizeArtistName(name)
+	if normalized == "" {
+		return
+	}
+	if _, ok := m.exact[normalized]; ok {
+		return
+	}
+
+	m.exact[normalized] = len(m.entries)
+	m.entries = append(m.entries, artistMatchEntry{
+		artist:   artist,
+		name:     name,
+		trigrams: artistTrigrams(normalized),
+	})
+}
+
+// Match возвращает артиста для имени: точное совпадение или сходство не ниже ArtistMatchThreshold
+func (m *ArtistMatcher) Match(name string) (ArtistMatch, bool) {
+	normalized := NormalizeArtistName(name)
+	if normalized == "" {
+		return ArtistMatch{}, false
+	}
+
+	if index, ok := m.exact[normalized]; ok {
+		entry := m.entries[index]
+		return ArtistMatch{Artist: entry.artist, Matched: entry.name, Score: 1, Exact: true}, true
+	}
+
+	candidates := m.rank(normalized, ArtistMatchThreshold)
+	if len(candidates) == 0 {
+		return ArtistMatch{}, false
+	}
+	return candidates[0], true
+}
+
+// Candidates возвращает похожих артистов со сходством не ниже ArtistNearMissThreshold по убыванию сходства
+func (m *ArtistMatcher) Candidates(name string, limit int) []ArtistMatch {
+	normalized := NormalizeArtistName(name)
+	if normalized == "" {
+		return nil
+	}
+
+	candidates := m.rank(normalized, ArtistNearMissThreshold)
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
+// Allow сообщает, что имя соответствует одному из артистов (фильтр строк скрейпера)
+func (m *ArtistMatcher) Allow(name string) bool {
+	_, ok := m.Match(name)
+	return ok
+}
+
+// rank возвращает лучшие совпадения по каждому артисту со сходством не ниже порога
+func (m *ArtistMatcher) rank(normalized string, threshold float64) []ArtistMatch {
+	trigrams := artistTrigrams(normalized)
+
+	best := make(map[int]int)
+	var matches []ArtistMatch
+	for _, entry := range m.entries {
+		score := trigramSimilarity(trigrams, entry.trigrams)
+		if score < threshold {
+			continue
+		}
+
+		match := ArtistMatch{Artist: entry.artist, Matched: entry.name, Score: score}
+		if index, ok := best[entry.artist.ArtistID]; ok {
+			if matches[index].Score < score {
+				matches[index] = match
+			}
+			continue
+		}
+		best[entry.artist.ArtistID] = len(matches)
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Artist.Name < matches[j].Artist.Name
+	})
+
+	return matches
+}
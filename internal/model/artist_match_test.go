@@ -0,0 +1,139 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeArtistName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"IVE (아이브)", "ive"},
+		{"(아이브)", "아이브"},
+		{"&amp;TEAM", "&team"},
+		{"&TEAM", "&team"},
+		{"tripleS", "triples"},
+		{"TRIPLE S", "triples"},
+		{"triple-s", "triples"},
+		{"tripleS [트리플에스]", "triples"},
+		{"ＩＶＥ", "ive"},
+		{"Beyoncé", "beyonce"},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeArtistName(tt.name); got != tt.want {
+				t.Errorf("NormalizeArtistName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"tripleS", "triple S", 1},
+		{"LE SSERAFIM", "LE SSERAFIMM", 0.769},
+		{"SEVENTEEN", "SEVENTEN", 0.727},
+		{"STAYC", "STAYCC", 0.625},
+		{"Kep1er", "Kepler", 0.4},
+		{"NMIXX", "MIXX", 0.375},
+		{"IVE", "IVE SECRET", 0.273},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			got := trigramSimilarity(artistTrigrams(NormalizeArtistName(tt.a)), artistTrigrams(NormalizeArtistName(tt.b)))
+			if math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("trigramSimilarity(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+
+	if got := trigramSimilarity(nil, artistTrigrams("ive")); got != 0 {
+		t.Errorf("trigramSimilarity with empty set = %.3f, want 0", got)
+	}
+}
+
+func TestArtistMatcherMatch(t *testing.T) {
+	if ArtistMatchThreshold != 0.7 || ArtistNearMissThreshold != 0.4 {
+		t.Fatalf("thresholds = %.2f / %.2f, want 0.70 / 0.40", ArtistMatchThreshold, ArtistNearMissThreshold)
+	}
+
+	artists := []Artist{
+		{ArtistID: 1, Name: "IVE"},
+		{ArtistID: 2, Name: "NMIXX"},
+		{ArtistID: 3, Name: "tripleS"},
+		{ArtistID: 4, Name: "&TEAM"},
+		{ArtistID: 5, Name: "LE SSERAFIM"},
+		{ArtistID: 6, Name: "Kep1er"},
+	}
+	aliases := []ArtistAlias{
+		{ArtistID: 1, Alias: "아이브"},
+		{ArtistID: 99, Alias: "Unknown"},
+	}
+	matcher := NewArtistMatcher(artists, aliases)
+
+	tests := []struct {
+		name      string
+		wantID    int // 0 - артист не должен сопоставиться
+		wantExact bool
+		matched   string
+	}{
+		{"IVE (아이브)", 1, true, "IVE"},
+		{"아이브", 1, true, "아이브"},
+		{"&amp;TEAM", 4, true, "&TEAM"},
+		{"TRIPLE S", 3, true, "tripleS"},
+		{"triple-s", 3, true, "tripleS"},
+		{"tripleS [트리플에스]", 3, true, "tripleS"},
+		{"LE SSERAFIMM", 5, false, "LE SSERAFIM"},
+		{"IVE SECRET", 0, false, ""},
+		{"MIXX", 0, false, ""},
+		{"Kepler", 0, false, ""},
+		{"Unknown", 0, false, ""},
+		{"", 0, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := matcher.Match(tt.name)
+			if tt.wantID == 0 {
+				if ok {
+					t.Fatalf("Match(%q) = %s (%.3f), want no match", tt.name, match.Artist.Name, match.Score)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("Match(%q) found nothing, want artist %d", tt.name, tt.wantID)
+			}
+			if match.Artist.ArtistID != tt.wantID || match.Exact != tt.wantExact || match.Matched != tt.matched {
+				t.Errorf("Match(%q) = artist %d, exact %v, matched %q; want artist %d, exact %v, matched %q",
+					tt.name, match.Artist.ArtistID, match.Exact, match.Matched, tt.wantID, tt.wantExact, tt.matched)
+			}
+			if !tt.wantExact && match.Score < ArtistMatchThreshold {
+				t.Errorf("Match(%q) score %.3f is below ArtistMatchThreshold", tt.name, match.Score)
+			}
+		})
+	}
+}
+
+func TestArtistMatcherCandidates(t *testing.T) {
+	matcher := NewArtistMatcher([]Artist{
+		{ArtistID: 1, Name: "NMIXX"},
+		{ArtistID: 2, Name: "Kep1er"},
+	}, nil)
+
+	candidates := matcher.Candidates("Kepler", 5)
+	if len(candidates) != 1 || candidates[0].Artist.ArtistID != 2 {
+		t.Fatalf("Candidates(Kepler) = %+v, want Kep1er at the near-miss threshold", candidates)
+	}
+
+	if candidates := matcher.Candidates("MIXX", 5); len(candidates) != 0 {
+		t.Errorf("Candidates(MIXX) = %+v, want none below ArtistNearMissThreshold", candidates)
+	}
+}
@@ -0,0 +1,54 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: ArtistRelation, ArtistRelationRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// RelationType тип связи артиста с группой или другим артистом
+type RelationType string
+
+// Типы связей артистов
+const (
+	RelationMember        RelationType = "member"  // Сольный проект участника группы
+	RelationSubUnit       RelationType = "subunit" // Саб-юнит группы
+	RelationCollaboration RelationType = "collab"  // Совместный проект с артистом
+)
+
+// IsValid проверяет, что тип связи известен
+func (t RelationType) IsValid() bool {
+	switch t {
+	case RelationMember, RelationSubUnit, RelationCollaboration:
+		return true
+	}
+	return false
+}
+
+// ArtistRelation связывает артиста (солиста, юнит, совместный проект) с родительским артистом
+type ArtistRelation struct {
+	bun.BaseModel `bun:"table:gemfactory.artist_relations,alias:artist_relation"`
+
+	RelationID int          `bun:"relation_id,pk,autoincrement" json:"relation_id"`
+	ArtistID   int          `bun:"artist_id,notnull" json:"artist_id"` // Солист, юнит или совместный проект
+	ParentID   int          `bun:"parent_id,notnull" json:"parent_id"` // Группа или артист, к которому относится
+	Type       RelationType `bun:"relation_type,notnull" json:"relation_type"`
+	CreatedAt  time.Time    `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+
+	// Связи
+	Artist *Artist `bun:"rel:belongs-to,join:artist_id=artist_id" json:"artist,omitempty"`
+	Parent *Artist `bun:"rel:belongs-to,join:parent_id=artist_id" json:"parent,omitempty"`
+}
+
+// ArtistRelationRepository определяет интерфейс для работы со связями артистов
+type ArtistRelationRepository interface {
+	GetAll() ([]ArtistRelation, error)
+	GetByArtist(artistID int) ([]ArtistRelation, error) // Связи, где артист родитель или связанный
+	GetChildren(parentID int) ([]ArtistRelation, error) // Связанные артисты родителя вместе с артистами
+	Create(relation *ArtistRelation) error
+	Delete(artistID, parentID int) (bool, error)
+}
@@ -0,0 +1,42 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: AuditEntry, AuditLogRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Результаты выполнения команд в журнале аудита
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeError   = "error"
+	AuditOutcomeInvalid = "invalid"
+	AuditOutcomeDenied  = "denied"
+)
+
+// AuditEntry представляет запись журнала действий администраторов
+type AuditEntry struct {
+	bun.BaseModel `bun:"table:gemfactory.audit_log,alias:audit"`
+
+	AuditID       int       `bun:"audit_id,pk,autoincrement" json:"audit_id"`
+	ActorID       int64     `bun:"actor_id,notnull" json:"actor_id"`
+	ActorUsername string    `bun:"actor_username" json:"actor_username"`
+	ChatID        int64     `bun:"chat_id,notnull" json:"chat_id"`
+	Command       string    `bun:"command,notnull" json:"command"`
+	Arguments     string    `bun:"arguments" json:"arguments"`
+	Before        string    `bun:"before_state" json:"before_state"`
+	After         string    `bun:"after_state" json:"after_state"`
+	Outcome       string    `bun:"outcome,notnull" json:"outcome"`
+	Error         string    `bun:"error" json:"error"`
+	CreatedAt     time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// AuditLogRepository определяет интерфейс для работы с журналом аудита
+type AuditLogRepository interface {
+	Create(entry *AuditEntry) error
+	GetRecent(limit int, command string) ([]AuditEntry, error)
+}
@@ -0,0 +1,46 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: ChatSettings, ChatSettingsRepository
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ChatSettings представляет настройки группового чата, которые задают администраторы группы
+type ChatSettings struct {
+	bun.BaseModel `bun:"table:gemfactory.chat_settings,alias:cs"`
+
+	ChatID          int64     `bun:"chat_id,pk" json:"chat_id"`
+	Language        string    `bun:"language,notnull" json:"language"`           // Язык ответов в группе, пусто - язык пользователя
+	GenderFilter    Gender    `bun:"gender_filter,notnull" json:"gender_filter"` // Фильтр /month по умолчанию, пусто - фильтр пользователя
+	Digest          bool      `bun:"digest_enabled,notnull" json:"digest_enabled"`
+	QuietMode       bool      `bun:"quiet_mode,notnull" json:"quiet_mode"`                   // Отвечать только на команды, адресованные боту
+	AllowedCommands []string  `bun:"allowed_commands,array,notnull" json:"allowed_commands"` // Разрешенные команды, пусто - все
+	CreatedAt       time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt       time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// DefaultChatSettings возвращает настройки чата, который их не менял
+func DefaultChatSettings(chatID int64) *ChatSettings {
+	return &ChatSettings{
+		ChatID:          chatID,
+		AllowedCommands: []string{},
+	}
+}
+
+// IsCommandAllowed проверяет, разрешена ли команда в чате
+func (s *ChatSettings) IsCommandAllowed(command string) bool {
+	return len(s.AllowedCommands) == 0 || slices.Contains(s.AllowedCommands, command)
+}
+
+// ChatSettingsRepository определяет интерфейс для работы с настройками групповых чатов
+type ChatSettingsRepository interface {
+	GetByChatID(chatID int64) (*ChatSettings, error)
+	Upsert(settings *ChatSettings) error
+	GetDigestChats() ([]ChatSettings, error)
+}
@@ -8,9 +8,11 @@ package model
 type ReleaseType string
 
 const (
-	ReleaseTypeSingle ReleaseType = "single"
-	ReleaseTypeAlbum  ReleaseType = "album"
-	ReleaseTypeEP     ReleaseType = "ep"
+	ReleaseTypeSingle        ReleaseType = "single"
+	ReleaseTypeAlbum         ReleaseType = "album"
+	ReleaseTypeEP            ReleaseType = "ep"
+	ReleaseTypeOST           ReleaseType = "ost"
+	ReleaseTypeDigitalSingle ReleaseType = "digital"
 )
 
 // Gender представляет пол артиста
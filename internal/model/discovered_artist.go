@@ -0,0 +1,48 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: DiscoveredArtist, DiscoveredArtistRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Статусы найденных артистов
+const (
+	DiscoveryStatusNew     = "new"     // Ожидает решения администратора
+	DiscoveryStatusAdded   = "added"   // Добавлен в список артистов
+	DiscoveryStatusIgnored = "ignored" // Скрыт из отчета
+)
+
+// DiscoveredArtist артист из расписания на сайтах-источниках, которого нет в списке
+type DiscoveredArtist struct {
+	bun.BaseModel `bun:"table:gemfactory.discovered_artists,alias:discovered_artist"`
+
+	DiscoveredID    int        `bun:"discovered_id,pk,autoincrement" json:"discovered_id"`
+	Name            string     `bun:"name,notnull" json:"name"`                                       // Имя в написании источника
+	Normalized      string     `bun:"normalized,notnull,unique" json:"normalized"`                    // NormalizeArtistName(Name)
+	Status          string     `bun:"status,notnull,default:'new'" json:"status"`                     // new, added, ignored
+	SeenCount       int        `bun:"seen_count,notnull" json:"seen_count"`                           // Сколько разных строк расписания с артистом встречалось
+	Sightings       []string   `bun:"sightings,array,notnull" json:"-"`                               // Встреченные строки: страница, дата и релиз
+	FirstSeenAt     time.Time  `bun:"first_seen_at,notnull" json:"first_seen_at"`                     // Первый парсинг, в котором встретился
+	LastSeenAt      time.Time  `bun:"last_seen_at,notnull" json:"last_seen_at"`                       // Последний парсинг, в котором встретился
+	LastReleaseDate *time.Time `bun:"last_release_date,type:date" json:"last_release_date,omitempty"` // Самая поздняя дата релиза из строк
+	SampleReleases  []string   `bun:"sample_releases,array,notnull" json:"sample_releases"`           // Примеры релизов из строк
+	SourceURL       string     `bun:"source_url" json:"source_url"`                                   // Страница, где встретился последним
+	CreatedAt       time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt       time.Time  `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// DiscoveredArtistRepository определяет интерфейс для работы с найденными артистами
+type DiscoveredArtistRepository interface {
+	GetByID(discoveredID int) (*DiscoveredArtist, error)
+	GetByNormalized(normalized string) (*DiscoveredArtist, error)
+	GetByStatus(status string, limit int) ([]DiscoveredArtist, error) // Сначала встречавшиеся чаще
+	CountByStatus(status string) (int, error)
+	Create(artist *DiscoveredArtist) error
+	Update(artist *DiscoveredArtist) error
+	UpdateStatus(discoveredID int, status string) error
+}
@@ -0,0 +1,32 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: LLMCacheEntry, LLMCacheRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// LLMCacheEntry представляет закэшированный ответ LLM для HTML блока
+type LLMCacheEntry struct {
+	bun.BaseModel `bun:"table:gemfactory.llm_cache,alias:llm_cache"`
+
+	CacheKey      string    `bun:"cache_key,pk" json:"cache_key"` // sha256(версия промпта + месяц + очищенный блок)
+	Month         string    `bun:"month,notnull" json:"month"`
+	PromptVersion string    `bun:"prompt_version,notnull" json:"prompt_version"`
+	Model         string    `bun:"model" json:"model"`
+	Response      string    `bun:"response,type:jsonb,notnull" json:"response"`
+	HitCount      int       `bun:"hit_count,notnull,default:0" json:"hit_count"`
+	CreatedAt     time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	LastHitAt     time.Time `bun:"last_hit_at,nullzero" json:"last_hit_at"`
+}
+
+// LLMCacheRepository определяет интерфейс для работы с кэшем ответов LLM
+type LLMCacheRepository interface {
+	Get(cacheKey string) (*LLMCacheEntry, error)
+	Set(entry *LLMCacheEntry) error
+	MarkHit(cacheKey string) error
+}
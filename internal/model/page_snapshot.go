@@ -0,0 +1,40 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: PageSnapshot, PageSnapshotRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// PageSnapshot представляет сохраненную страницу расписания в том виде, в котором ее получил парсер
+type PageSnapshot struct {
+	bun.BaseModel `bun:"table:gemfactory.page_snapshots,alias:page_snapshot"`
+
+	SnapshotID   int       `bun:"snapshot_id,pk,autoincrement" json:"snapshot_id"`
+	Source       string    `bun:"source,notnull" json:"source"`
+	URL          string    `bun:"url,notnull" json:"url"`
+	Month        string    `bun:"month,notnull" json:"month"` // Месяц парсинга: october
+	Year         string    `bun:"year,notnull" json:"year"`
+	FetchedAt    time.Time `bun:"fetched_at,notnull" json:"fetched_at"`
+	ETag         string    `bun:"etag,nullzero" json:"etag"`
+	LastModified string    `bun:"last_modified,nullzero" json:"last_modified"`
+	ContentHash  string    `bun:"content_hash,notnull" json:"content_hash"` // SHA-256 несжатой страницы
+	Size         int       `bun:"size,notnull" json:"size"`                 // Размер несжатой страницы в байтах
+	Body         []byte    `bun:"body,type:bytea,notnull" json:"-"`         // Страница, сжатая gzip
+	FilterHash   string    `bun:"filter_hash,nullzero" json:"filter_hash"`  // Отпечаток списка артистов и промпта последнего успешного парсинга
+	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// PageSnapshotRepository определяет интерфейс для работы со снимками страниц
+type PageSnapshotRepository interface {
+	GetByID(snapshotID int) (*PageSnapshot, error)
+	GetLatestByURL(url string) (*PageSnapshot, error)
+	GetRecent(limit int) ([]PageSnapshot, error) // Без тела страницы
+	Create(snapshot *PageSnapshot) error
+	UpdateFilterHash(snapshotID int, filterHash string) error
+	DeleteOld(url string, keep int) error // Оставляет keep последних снимков страницы
+}
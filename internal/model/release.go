@@ -14,17 +14,20 @@ import (
 type Release struct {
 	bun.BaseModel `bun:"table:gemfactory.releases"`
 
-	ReleaseID  int       `bun:"release_id,pk,autoincrement" json:"release_id"`
-	ArtistID   int       `bun:"artist_id,notnull" json:"artist_id"`
-	Title      string    `bun:"title,notnull" json:"title"`
-	TitleTrack string    `bun:"title_track" json:"title_track"` // Название титульного трека
-	AlbumName  string    `bun:"album_name" json:"album_name"`   // Название альбома
-	MV         string    `bun:"mv" json:"mv"`                   // Ссылка на MV
-	Date       string    `bun:"date,notnull" json:"date"`       // Дата релиза в формате DD.MM.YYYY
-	TimeMSK    string    `bun:"time_msk" json:"time_msk"`       // Время в MSK
-	IsActive   bool      `bun:"is_active,notnull,default:true" json:"is_active"`
-	CreatedAt  time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
-	UpdatedAt  time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+	ReleaseID   int         `bun:"release_id,pk,autoincrement" json:"release_id"`
+	ArtistID    int         `bun:"artist_id,notnull" json:"artist_id"`
+	Title       string      `bun:"title,notnull" json:"title"`
+	TitleTrack  string      `bun:"title_track" json:"title_track"`                       // Название титульного трека
+	AlbumName   string      `bun:"album_name" json:"album_name"`                         // Название альбома
+	MV          string      `bun:"mv" json:"mv"`                                         // Ссылка на MV
+	Type        ReleaseType `bun:"release_type,nullzero" json:"release_type,omitempty"`  // Тип релиза, пусто - не указан в источнике
+	Date        string      `bun:"date,notnull" json:"date"`                             // Дата релиза в формате DD.MM.YYYY
+	TimeMSK     string      `bun:"time_msk" json:"time_msk"`                             // Время в MSK
+	ReleaseDate *time.Time  `bun:"release_date,type:date" json:"release_date,omitempty"` // Дата релиза (типизированная)
+	ReleaseAt   *time.Time  `bun:"release_at" json:"release_at,omitempty"`               // Момент релиза (дата KST + TimeMSK)
+	IsActive    bool        `bun:"is_active,notnull,default:true" json:"is_active"`
+	CreatedAt   time.Time   `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt   time.Time   `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
 
 	// Связи
 	Artist *Artist `bun:"rel:belongs-to,join:artist_id=artist_id" json:"artist,omitempty"`
@@ -65,12 +68,16 @@ type ReleaseRepository interface {
 	GetByGender(gender Gender) ([]Release, error)
 	GetByArtist(artistID int) ([]Release, error)
 	GetByArtistName(artistName string) ([]Release, error)
-	GetByDateRange(start, end time.Time) ([]Release, error)
+	GetActiveByArtist(artistID int) ([]Release, error)
+	GetByDateRange(start, end time.Time) ([]Release, error) // Диапазон [start, end) по release_date
 	GetActive() ([]Release, error)
 	GetWithRelations() ([]Release, error)
 	GetByArtistAndTitle(artistID int, title string) (*Release, error)
 	GetByArtistDateAndTrack(artistID int, date, titleTrack string) (*Release, error)
+	GetActiveByArtistAndTrack(artistID int, titleTrack string) ([]Release, error)
+	GetActiveByArtistAndDate(artistID int, date string) ([]Release, error)
 	GetTotalCount() (int, error)
+	GetUpcomingByArtist(artistID int, from time.Time) ([]Release, error) // Активные релизы с release_at после from
 }
 
 // ScrapedReleaseData представляет данные релиза для скрейпера
@@ -92,8 +99,11 @@ func (r *Release) ToScrapedReleaseData() ScrapedReleaseData {
 		genderName = r.Artist.Gender.String()
 	}
 
-	// Тип релиза по умолчанию
+	// Тип релиза по умолчанию, если источник его не указал
 	typeName = "release"
+	if r.Type != "" {
+		typeName = r.Type.String()
+	}
 
 	return ScrapedReleaseData{
 		Artist:    artistName,
@@ -0,0 +1,56 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: ReleaseReminder, ReminderStatus, ReleaseReminderRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ReminderStatus представляет состояние напоминания о релизе
+type ReminderStatus string
+
+const (
+	ReminderStatusPending ReminderStatus = "pending" // Ожидает отправки
+	ReminderStatusSent    ReminderStatus = "sent"    // Отправлено
+	ReminderStatusExpired ReminderStatus = "expired" // Релиз вышел или подписка отменена до отправки
+)
+
+// MaxReminderMinutes максимальное время напоминания до релиза в минутах
+const MaxReminderMinutes = 24 * 60
+
+// ReleaseReminder представляет одноразовое напоминание пользователю о выходе релиза
+type ReleaseReminder struct {
+	bun.BaseModel `bun:"table:gemfactory.release_reminders,alias:rr"`
+
+	ReminderID int            `bun:"reminder_id,pk,autoincrement" json:"reminder_id"`
+	UserID     int64          `bun:"user_id,notnull" json:"user_id"`
+	ChatID     int64          `bun:"chat_id,notnull" json:"chat_id"`
+	ReleaseID  int            `bun:"release_id,notnull" json:"release_id"`
+	ReleaseAt  time.Time      `bun:"release_at,notnull" json:"release_at"` // Момент релиза, для которого рассчитано напоминание
+	RemindAt   time.Time      `bun:"remind_at,notnull" json:"remind_at"`
+	Status     ReminderStatus `bun:"status,notnull,default:'pending'" json:"status"`
+	SentAt     *time.Time     `bun:"sent_at" json:"sent_at,omitempty"`
+	CreatedAt  time.Time      `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt  time.Time      `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+
+	// Связи
+	Release *Release `bun:"rel:belongs-to,join:release_id=release_id" json:"release,omitempty"`
+}
+
+// ReleaseReminderRepository определяет интерфейс для работы с напоминаниями о релизах
+type ReleaseReminderRepository interface {
+	// Schedule создает или обновляет напоминание. При изменении момента релиза отправленное напоминание снова ожидает отправки
+	Schedule(reminder *ReleaseReminder) error
+	GetByID(reminderID int) (*ReleaseReminder, error)
+	GetPendingBefore(before time.Time) ([]ReleaseReminder, error)
+	// Claim помечает напоминание отправленным, если оно еще ожидает отправки на указанный момент
+	Claim(reminderID int, remindAt time.Time) (bool, error)
+	Expire(reminderID int) error
+	ExpireReleased(now time.Time) (int, error)
+	ExpirePendingByRelease(releaseID int) (int, error)
+	ExpirePendingByUser(userID int64) (int, error)
+}
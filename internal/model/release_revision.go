@@ -0,0 +1,45 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: ReleaseRevision, ReleaseRevisionRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Поля релиза, изменения которых записываются в историю
+const (
+	RevisionFieldDate       = "date"
+	RevisionFieldTitleTrack = "title_track"
+	RevisionFieldAlbumName  = "album_name"
+	RevisionFieldMV         = "mv"
+	RevisionFieldTimeMSK    = "time_msk"
+)
+
+// ReleaseRevision представляет изменение одного поля релиза
+type ReleaseRevision struct {
+	bun.BaseModel `bun:"table:gemfactory.release_revisions,alias:revision"`
+
+	RevisionID int       `bun:"revision_id,pk,autoincrement" json:"revision_id"`
+	ReleaseID  int       `bun:"release_id,notnull" json:"release_id"`
+	ArtistID   int       `bun:"artist_id,notnull" json:"artist_id"`
+	Field      string    `bun:"field,notnull" json:"field"`
+	OldValue   string    `bun:"old_value" json:"old_value"`
+	NewValue   string    `bun:"new_value" json:"new_value"`
+	SourceURL  string    `bun:"source_url" json:"source_url"`
+	ChangedAt  time.Time `bun:"changed_at,notnull,default:current_timestamp" json:"changed_at"`
+
+	// Связи
+	Release *Release `bun:"rel:belongs-to,join:release_id=release_id" json:"release,omitempty"`
+	Artist  *Artist  `bun:"rel:belongs-to,join:artist_id=artist_id" json:"artist,omitempty"`
+}
+
+// ReleaseRevisionRepository определяет интерфейс для работы с историей изменений релизов
+type ReleaseRevisionRepository interface {
+	CreateBatch(revisions []ReleaseRevision) error
+	GetSince(since time.Time) ([]ReleaseRevision, error)
+	GetByRelease(releaseID int) ([]ReleaseRevision, error)
+}
@@ -0,0 +1,79 @@
+// Package model содержит классификацию типов релизов.
+//
+// Группа: BASE - Базовые компоненты
+// Содержит: ParseReleaseType, ClassifyReleaseType
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// releaseTypeAliases варианты написания типа релиза в фильтрах команд и ответах LLM
+var releaseTypeAliases = map[string]ReleaseType{
+	"single":         ReleaseTypeSingle,
+	"album":          ReleaseTypeAlbum,
+	"full":           ReleaseTypeAlbum,
+	"ep":             ReleaseTypeEP,
+	"mini":           ReleaseTypeEP,
+	"mini album":     ReleaseTypeEP,
+	"ost":            ReleaseTypeOST,
+	"soundtrack":     ReleaseTypeOST,
+	"digital":        ReleaseTypeDigitalSingle,
+	"digital single": ReleaseTypeDigitalSingle,
+	"digital_single": ReleaseTypeDigitalSingle,
+}
+
+// Шаблоны классификации в порядке приоритета: OST и цифровой сингл указываются вместе
+// с номером альбома ("Digital Single Album"), мини-альбом - раньше полноформатного
+var (
+	releaseTypeOSTPattern     = regexp.MustCompile(`(?i)\b(ost|soundtrack)\b`)
+	releaseTypeDigitalPattern = regexp.MustCompile(`(?i)\bdigital\s+single\b`)
+	releaseTypeEPPattern      = regexp.MustCompile(`(?i)\b(ep|mini[\s-]*album|mini)\b`)
+	releaseTypeSinglePattern  = regexp.MustCompile(`(?i)\bsingle\b`)
+	releaseTypeAlbumPattern   = regexp.MustCompile(`(?i)\b(album|full[\s-]*length|repackage)\b`)
+)
+
+// String возвращает строковое представление типа релиза
+func (t ReleaseType) String() string {
+	return string(t)
+}
+
+// IsValid проверяет валидность типа релиза
+func (t ReleaseType) IsValid() bool {
+	switch t {
+	case ReleaseTypeSingle, ReleaseTypeAlbum, ReleaseTypeEP, ReleaseTypeOST, ReleaseTypeDigitalSingle:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseReleaseType разбирает тип релиза из фильтра команды или ответа LLM
+func ParseReleaseType(value string) (ReleaseType, bool) {
+	key := strings.Join(strings.Fields(strings.ToLower(strings.TrimSpace(value))), " ")
+	releaseType, ok := releaseTypeAliases[key]
+	return releaseType, ok
+}
+
+// ClassifyReleaseType определяет тип релиза по тексту расписания ("1st EP", "2nd Mini Album",
+// "OST", "Digital Single"). Тексты проверяются вместе, пустой результат - тип не указан
+func ClassifyReleaseType(texts ...string) ReleaseType {
+	text := strings.Join(texts, " ")
+	switch {
+	case strings.TrimSpace(text) == "":
+		return ""
+	case releaseTypeOSTPattern.MatchString(text):
+		return ReleaseTypeOST
+	case releaseTypeDigitalPattern.MatchString(text):
+		return ReleaseTypeDigitalSingle
+	case releaseTypeEPPattern.MatchString(text):
+		return ReleaseTypeEP
+	case releaseTypeSinglePattern.MatchString(text):
+		return ReleaseTypeSingle
+	case releaseTypeAlbumPattern.MatchString(text):
+		return ReleaseTypeAlbum
+	default:
+		return ""
+	}
+}
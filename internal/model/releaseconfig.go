@@ -18,6 +18,8 @@ type ReleaseConfig struct {
 	timeFormat      string
 	timeParseFormat string
 	kstToMSKDiff    time.Duration
+	mskLocation     *time.Location
+	kstLocation     *time.Location
 }
 
 // NewReleaseConfig создает новую конфигурацию релизов
@@ -46,6 +48,8 @@ func NewReleaseConfig() *ReleaseConfig {
 		timeFormat:      "15:04",
 		timeParseFormat: "3 PM",
 		kstToMSKDiff:    -6 * time.Hour,
+		mskLocation:     time.FixedZone("MSK", 3*60*60),
+		kstLocation:     time.FixedZone("KST", 9*60*60),
 	}
 }
 
@@ -87,6 +91,16 @@ func (c *ReleaseConfig) KSTToMSKDiff() time.Duration {
 	return c.kstToMSKDiff
 }
 
+// MSKLocation возвращает часовой пояс MSK, в котором хранится TimeMSK
+func (c *ReleaseConfig) MSKLocation() *time.Location {
+	return c.mskLocation
+}
+
+// KSTLocation возвращает часовой пояс KST, в котором указана дата релиза
+func (c *ReleaseConfig) KSTLocation() *time.Location {
+	return c.kstLocation
+}
+
 // CurrentYear возвращает текущий год
 func CurrentYear() string {
 	return time.Now().Format("2006")
@@ -331,6 +331,40 @@ func (u *ReleaseUtils) CleanArtistName(artist string) string {
 	return artist
 }
 
+// FillReleaseDates заполняет типизированные дату и момент релиза из строковых Date и TimeMSK.
+// Date - календарная дата в KST, поэтому момент строится в KST: релиз в 00:00 KST 5-го числа
+// приходится на 18:00 MSK 4-го
+func (u *ReleaseUtils) FillReleaseDates(release *Release) error {
+	parsedDate, err := u.ParseReleaseDate(release.Date)
+	if err != nil {
+		release.ReleaseDate = nil
+		release.ReleaseAt = nil
+		return fmt.Errorf("failed to parse release date: %w", err)
+	}
+
+	releaseDate := time.Date(parsedDate.Year(), parsedDate.Month(), parsedDate.Day(), 0, 0, 0, 0, time.UTC)
+	release.ReleaseDate = &releaseDate
+	release.ReleaseAt = nil
+
+	if release.TimeMSK == "" || release.TimeMSK == "N/A" {
+		return nil
+	}
+
+	parsedTime, err := time.Parse(u.config.TimeFormat(), strings.TrimSpace(release.TimeMSK))
+	if err != nil {
+		return fmt.Errorf("failed to parse release time '%s': %w", release.TimeMSK, err)
+	}
+
+	mskAt := time.Date(releaseDate.Year(), releaseDate.Month(), releaseDate.Day(),
+		parsedTime.Hour(), parsedTime.Minute(), 0, 0, u.config.MSKLocation())
+	kstClock := mskAt.In(u.config.KSTLocation())
+	releaseAt := time.Date(releaseDate.Year(), releaseDate.Month(), releaseDate.Day(),
+		kstClock.Hour(), kstClock.Minute(), 0, 0, u.config.KSTLocation())
+	release.ReleaseAt = &releaseAt
+
+	return nil
+}
+
 // ValidateRelease проверяет валидность релиза
 func (u *ReleaseUtils) ValidateRelease(release *Release) error {
 	if release.ArtistID <= 0 {
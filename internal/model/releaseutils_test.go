@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFillReleaseDates(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		timeMSK string
+		wantAt  string // Момент релиза в UTC, пусто - время неизвестно
+	}{
+		{"midnight KST is previous evening MSK", "05.11.25", "18:00", "2025-11-04T15:00:00Z"},
+		{"01:00 KST", "05.11.25", "19:00", "2025-11-04T16:00:00Z"},
+		{"afternoon KST", "05.11.25", "12:00", "2025-11-05T09:00:00Z"},
+		{"last minute before KST midnight", "05.11.25", "17:59", "2025-11-05T14:59:00Z"},
+		{"month boundary", "01.12.25", "18:00", "2025-11-30T15:00:00Z"},
+		{"unknown time", "05.11.25", "N/A", ""},
+	}
+
+	utils := NewReleaseUtils()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := &Release{Date: tt.date, TimeMSK: tt.timeMSK}
+			if err := utils.FillReleaseDates(release); err != nil {
+				t.Fatalf("FillReleaseDates() error = %v", err)
+			}
+
+			if release.ReleaseDate == nil || release.ReleaseDate.Format(time.DateOnly) != "20"+tt.date[6:]+"-"+tt.date[3:5]+"-"+tt.date[:2] {
+				t.Errorf("ReleaseDate = %v, want KST date %s", release.ReleaseDate, tt.date)
+			}
+
+			if tt.wantAt == "" {
+				if release.ReleaseAt != nil {
+					t.Errorf("ReleaseAt = %v, want nil", release.ReleaseAt)
+				}
+				return
+			}
+			if release.ReleaseAt == nil {
+				t.Fatalf("ReleaseAt = nil, want %s", tt.wantAt)
+			}
+			if got := release.ReleaseAt.UTC().Format(time.RFC3339); got != tt.wantAt {
+				t.Errorf("ReleaseAt = %s, want %s", got, tt.wantAt)
+			}
+			if got := release.ReleaseAt.In(NewReleaseConfig().KSTLocation()).Format("02.01.06"); got != tt.date {
+				t.Errorf("ReleaseAt KST date = %s, want %s", got, tt.date)
+			}
+		})
+	}
+}
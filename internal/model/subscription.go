@@ -0,0 +1,36 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: Subscription, SubscriptionRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Subscription представляет подписку пользователя на релизы артиста
+type Subscription struct {
+	bun.BaseModel `bun:"table:gemfactory.subscriptions"`
+
+	SubscriptionID int       `bun:"subscription_id,pk,autoincrement" json:"subscription_id"`
+	UserID         int64     `bun:"user_id,notnull" json:"user_id"`
+	ChatID         int64     `bun:"chat_id,notnull" json:"chat_id"` // Чат для отправки уведомлений
+	ArtistID       int       `bun:"artist_id,notnull" json:"artist_id"`
+	IncludeRelated bool      `bun:"include_related,notnull" json:"include_related"` // Подписка и на солистов, юниты и совместные проекты
+	CreatedAt      time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+
+	// Связи
+	Artist *Artist `bun:"rel:belongs-to,join:artist_id=artist_id" json:"artist,omitempty"`
+}
+
+// SubscriptionRepository определяет интерфейс для работы с подписками
+type SubscriptionRepository interface {
+	GetByUser(userID int64) ([]Subscription, error)
+	GetByArtist(artistID int) ([]Subscription, error) // Включая подписки на группы артиста с IncludeRelated
+	GetByUserAndArtist(userID int64, artistID int) (*Subscription, error)
+	IsFollowing(userID int64, artistID int) (bool, error) // Подписка на артиста или на его группу с IncludeRelated
+	Create(subscription *Subscription) error
+	Delete(userID int64, artistID int) error
+}
@@ -19,12 +19,13 @@ const (
 	TaskTypeUpdatePlaylist TaskType = "update_playlist"
 	TaskTypeUpdateHomework TaskType = "update_homework"
 	TaskTypeHomeworkReset  TaskType = "homework_reset"
+	TaskTypeDigest         TaskType = "digest"
 )
 
 // IsValid проверяет валидность типа задачи
 func (t TaskType) IsValid() bool {
 	switch t {
-	case TaskTypeParseReleases, TaskTypeUpdatePlaylist, TaskTypeUpdateHomework, TaskTypeHomeworkReset:
+	case TaskTypeParseReleases, TaskTypeUpdatePlaylist, TaskTypeUpdateHomework, TaskTypeHomeworkReset, TaskTypeDigest:
 		return true
 	default:
 		return false
@@ -156,6 +157,35 @@ func (t *Task) GetConfigBool(key string) (bool, bool) {
 	return false, false
 }
 
+// GetConfigInt64Slice получает список целых чисел из конфигурации, например ID чатов
+func (t *Task) GetConfigInt64Slice(key string) ([]int64, bool) {
+	value, exists := t.GetConfigValue(key)
+	if !exists {
+		return nil, false
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	result := make([]int64, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case int64:
+			result = append(result, v)
+		case int:
+			result = append(result, int64(v))
+		case float64:
+			result = append(result, int64(v))
+		case json.Number:
+			if i, err := v.Int64(); err == nil {
+				result = append(result, i)
+			}
+		}
+	}
+	return result, true
+}
+
 // UpdateRunStats обновляет статистику выполнения задачи
 func (t *Task) UpdateRunStats(success bool, err error) {
 	t.RunCount++
@@ -0,0 +1,50 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: TaskRun, TaskRunStatus, TaskRunRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// TaskRunStatus представляет статус запуска задачи
+type TaskRunStatus string
+
+const (
+	TaskRunStatusRunning     TaskRunStatus = "running"
+	TaskRunStatusSuccess     TaskRunStatus = "success"
+	TaskRunStatusError       TaskRunStatus = "error"
+	TaskRunStatusInterrupted TaskRunStatus = "interrupted"
+)
+
+// TaskRun представляет один запуск задачи
+type TaskRun struct {
+	bun.BaseModel `bun:"table:gemfactory.task_runs,alias:run"`
+
+	RunID      int                    `bun:"run_id,pk,autoincrement" json:"run_id"`
+	TaskID     int                    `bun:"task_id,notnull" json:"task_id"`
+	TaskName   string                 `bun:"task_name,notnull" json:"task_name"`
+	Status     TaskRunStatus          `bun:"status,notnull" json:"status"`
+	StartedAt  time.Time              `bun:"started_at,notnull,default:current_timestamp" json:"started_at"`
+	FinishedAt *time.Time             `bun:"finished_at" json:"finished_at"`
+	DurationMs int64                  `bun:"duration_ms,notnull,default:0" json:"duration_ms"`
+	Error      string                 `bun:"error" json:"error"`
+	Result     map[string]interface{} `bun:"result,type:jsonb" json:"result"`
+}
+
+// Duration возвращает длительность запуска
+func (r *TaskRun) Duration() time.Duration {
+	return time.Duration(r.DurationMs) * time.Millisecond
+}
+
+// TaskRunRepository определяет интерфейс для работы с историей запусков задач
+type TaskRunRepository interface {
+	Create(run *TaskRun) error
+	Finish(run *TaskRun) error
+	GetRecentByTask(taskID int, limit int) ([]TaskRun, error)
+	MarkInterrupted() (int, error)
+	DeleteOlderThan(taskID int, before time.Time) (int, error)
+}
@@ -0,0 +1,84 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: User, Role, UserRepository
+package model
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Role представляет роль пользователя бота
+type Role string
+
+const (
+	RoleUser   Role = "user"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+	RoleOwner  Role = "owner"
+)
+
+// roleLevels задает иерархию ролей: старшая роль включает права младших
+var roleLevels = map[Role]int{
+	RoleUser:   0,
+	RoleEditor: 1,
+	RoleAdmin:  2,
+	RoleOwner:  3,
+}
+
+// IsValid проверяет валидность роли
+func (r Role) IsValid() bool {
+	_, ok := roleLevels[r]
+	return ok
+}
+
+// Level возвращает уровень роли в иерархии
+func (r Role) Level() int {
+	return roleLevels[r]
+}
+
+// AtLeast проверяет, что роль не ниже указанной
+func (r Role) AtLeast(required Role) bool {
+	return r.Level() >= required.Level()
+}
+
+// String возвращает строковое представление роли
+func (r Role) String() string {
+	return string(r)
+}
+
+// User представляет пользователя бота, идентифицируемого по Telegram ID
+type User struct {
+	bun.BaseModel `bun:"table:gemfactory.users,alias:u"`
+
+	UserID    int64     `bun:"user_id,pk" json:"user_id"` // Telegram user ID (не меняется)
+	Username  string    `bun:"username" json:"username"`  // Последний известный username без @
+	Role      Role      `bun:"role,notnull,default:'user'" json:"role"`
+	GrantedBy *int64    `bun:"granted_by" json:"granted_by"`
+	Language  string    `bun:"language,nullzero" json:"language"` // Выбранный язык, пусто - по настройкам Telegram
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// DisplayName возвращает имя пользователя для отображения
+func (u *User) DisplayName() string {
+	if u.Username != "" {
+		return "@" + u.Username
+	}
+	return "id " + strconv.FormatInt(u.UserID, 10)
+}
+
+// UserRepository определяет интерфейс для работы с пользователями и ролями
+type UserRepository interface {
+	GetByID(userID int64) (*User, error)
+	GetByUsername(username string) (*User, error)
+	Create(user *User) error
+	UpdateUsername(userID int64, username string) error
+	SetRole(userID int64, role Role, grantedBy *int64) error
+	SetLanguage(userID int64, username, language string) error
+	GetByRoles(roles []Role) ([]User, error)
+	HasRole(role Role) (bool, error)
+}
@@ -0,0 +1,68 @@
+// Package model содержит модели данных.
+//
+// Группа: ENTITIES - Основные сущности
+// Содержит: UserSettings, Layout, UserSettingsRepository
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Layout представляет формат вывода списка релизов
+type Layout string
+
+const (
+	LayoutCompact Layout = "compact" // Одна строка на релиз
+	LayoutVerbose Layout = "verbose" // Карточка релиза со временем, альбомом и треком
+)
+
+// IsValid проверяет валидность формата
+func (l Layout) IsValid() bool {
+	return l == LayoutCompact || l == LayoutVerbose
+}
+
+// DefaultTimezone часовой пояс по умолчанию, в котором исторически показывались релизы
+const DefaultTimezone = "Europe/Moscow"
+
+// UserSettings представляет настройки отображения релизов пользователя
+type UserSettings struct {
+	bun.BaseModel `bun:"table:gemfactory.user_settings,alias:us"`
+
+	UserID          int64     `bun:"user_id,pk" json:"user_id"`
+	Timezone        string    `bun:"timezone,notnull" json:"timezone"`           // IANA часовой пояс, например Europe/Moscow
+	GenderFilter    Gender    `bun:"gender_filter,notnull" json:"gender_filter"` // Фильтр /month по умолчанию, пусто - все артисты
+	Layout          Layout    `bun:"layout,notnull" json:"layout"`
+	Digest          bool      `bun:"digest_enabled,notnull" json:"digest_enabled"`     // Подписка на дайджесты релизов
+	ReminderMinutes int       `bun:"reminder_minutes,notnull" json:"reminder_minutes"` // За сколько минут напоминать о релизах, 0 - не напоминать
+	CreatedAt       time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt       time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// DefaultUserSettings возвращает настройки пользователя, который их не менял
+func DefaultUserSettings(userID int64) *UserSettings {
+	return &UserSettings{
+		UserID:   userID,
+		Timezone: DefaultTimezone,
+		Layout:   LayoutCompact,
+	}
+}
+
+// Location возвращает часовой пояс настроек, при ошибке - часовой пояс по умолчанию
+func (s *UserSettings) Location() *time.Location {
+	if loc, err := time.LoadLocation(s.Timezone); err == nil {
+		return loc
+	}
+	if loc, err := time.LoadLocation(DefaultTimezone); err == nil {
+		return loc
+	}
+	return time.FixedZone("MSK", 3*60*60)
+}
+
+// UserSettingsRepository определяет интерфейс для работы с настройками пользователей
+type UserSettingsRepository interface {
+	GetByUserID(userID int64) (*UserSettings, error)
+	Upsert(settings *UserSettings) error
+	GetDigestSubscribers() ([]UserSettings, error)
+}
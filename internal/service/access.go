@@ -0,0 +1,299 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gemfactory/internal/config"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// Ошибки управления ролями
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrRoleNotGrantable  = errors.New("role cannot be granted")
+	ErrInsufficientRole  = errors.New("insufficient role")
+	ErrCannotChangeSelf  = errors.New("cannot change own role")
+	ErrRoleAlreadyActive = errors.New("user already has this role")
+)
+
+// commandRoles минимальная роль для команд. Команды, которых нет в списке, доступны всем
+var commandRoles = map[string]model.Role{
+	"admin":           model.RoleEditor,
+	"add_artist":      model.RoleEditor,
+	"remove_artist":   model.RoleEditor,
+	"alias":           model.RoleEditor,
+	"discover":        model.RoleEditor,
+	"relation":        model.RoleEditor,
+	"export":          model.RoleEditor,
+	"parse":           model.RoleEditor,
+	"changes":         model.RoleEditor,
+	"snapshots":       model.RoleEditor,
+	"replay":          model.RoleEditor,
+	"reload_playlist": model.RoleEditor,
+	"clearcache":      model.RoleAdmin,
+	"config_list":     model.RoleAdmin,
+	"tasks_list":      model.RoleAdmin,
+	"task_history":    model.RoleAdmin,
+	"llm_metrics":     model.RoleAdmin,
+	"grant":           model.RoleAdmin,
+	"audit":           model.RoleAdmin,
+	"revoke":          model.RoleAdmin,
+	"config":          model.RoleOwner,
+	"config_reset":    model.RoleOwner,
+	"clearwhitelists": model.RoleOwner,
+}
+
+// AccessService проверяет права пользователей на команды по ролям
+type AccessService struct {
+	repo   model.UserRepository
+	config *config.Config
+	logger *zap.Logger
+
+	// known последние сохраненные username, чтобы не писать в базу на каждую команду
+	known   map[int64]string
+	knownMu sync.Mutex
+}
+
+// NewAccessService создает новый сервис прав доступа
+func NewAccessService(db *bun.DB, cfg *config.Config, logger *zap.Logger) *AccessService {
+	return &AccessService{
+		repo:   repository.NewUserRepository(db, logger),
+		config: cfg,
+		logger: logger,
+		known:  make(map[int64]string),
+	}
+}
+
+// RequiredRole возвращает минимальную роль для команды
+func RequiredRole(command string) model.Role {
+	if role, ok := commandRoles[strings.ToLower(command)]; ok {
+		return role
+	}
+	return model.RoleUser
+}
+
+// CanExecute проверяет, может ли пользователь выполнить команду
+func (s *AccessService) CanExecute(userID int64, username, command string) bool {
+	required := RequiredRole(command)
+	if required == model.RoleUser {
+		s.remember(userID, username)
+		return true
+	}
+
+	role := s.RoleOf(userID, username)
+	if role.AtLeast(required) {
+		return true
+	}
+
+	s.logger.Warn("Permission denied",
+		zap.Int64("user_id", userID),
+		zap.String("username", username),
+		zap.String("command", command),
+		zap.String("role", role.String()),
+		zap.String("required_role", required.String()))
+	return false
+}
+
+// RoleOf возвращает роль пользователя. При ошибке базы данных возвращает RoleUser
+func (s *AccessService) RoleOf(userID int64, username string) model.Role {
+	user, err := s.ensureUser(userID, username)
+	if err != nil {
+		s.logger.Error("Failed to load user role", zap.Int64("user_id", userID), zap.Error(err))
+		return model.RoleUser
+	}
+	return user.Role
+}
+
+// ensureUser возвращает пользователя, создавая запись и обновляя username при необходимости.
+// ADMIN_USERNAME становится владельцем, только пока в системе нет ни одного владельца
+func (s *AccessService) ensureUser(userID int64, username string) (*model.User, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		user = &model.User{UserID: userID, Username: username, Role: model.RoleUser}
+		if err := s.repo.Create(user); err != nil {
+			return nil, err
+		}
+	} else if username != "" && user.Username != username {
+		if err := s.repo.UpdateUsername(userID, username); err != nil {
+			s.logger.Warn("Failed to update username", zap.Int64("user_id", userID), zap.Error(err))
+		}
+		user.Username = username
+	}
+	s.rememberKnown(userID, username)
+
+	if user.Role != model.RoleOwner && s.isBootstrapOwner(username) {
+		if err := s.repo.SetRole(userID, model.RoleOwner, nil); err != nil {
+			return nil, err
+		}
+		s.logger.Info("Bootstrap owner assigned from ADMIN_USERNAME",
+			zap.Int64("user_id", userID),
+			zap.String("username", username))
+		user.Role = model.RoleOwner
+	}
+
+	return user, nil
+}
+
+// isBootstrapOwner проверяет, что пользователь указан в ADMIN_USERNAME и владельца еще нет
+func (s *AccessService) isBootstrapOwner(username string) bool {
+	adminUsername := strings.TrimPrefix(s.config.AdminUsername, "@")
+	if adminUsername == "" || username == "" || !strings.EqualFold(username, adminUsername) {
+		return false
+	}
+
+	hasOwner, err := s.repo.HasRole(model.RoleOwner)
+	if err != nil {
+		s.logger.Error("Failed to check owner existence", zap.Error(err))
+		return false
+	}
+	return !hasOwner
+}
+
+// remember сохраняет пользователя, если он еще не известен или сменил username
+func (s *AccessService) remember(userID int64, username string) {
+	s.knownMu.Lock()
+	knownUsername, ok := s.known[userID]
+	s.knownMu.Unlock()
+
+	if ok && knownUsername == username {
+		return
+	}
+
+	if _, err := s.ensureUser(userID, username); err != nil {
+		s.logger.Warn("Failed to remember user", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
+
+// rememberKnown запоминает сохраненный username пользователя
+func (s *AccessService) rememberKnown(userID int64, username string) {
+	s.knownMu.Lock()
+	defer s.knownMu.Unlock()
+	s.known[userID] = username
+}
+
+// Grant назначает пользователю роль от имени actor
+func (s *AccessService) Grant(actorID int64, actorUsername, target string, role model.Role) (*model.User, error) {
+	if role != model.RoleAdmin && role != model.RoleEditor {
+		return nil, ErrRoleNotGrantable
+	}
+
+	actorRole, user, err := s.prepareRoleChange(actorID, actorUsername, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if actorRole.Level() <= role.Level() {
+		return nil, ErrInsufficientRole
+	}
+	if user.Role == role {
+		return user, ErrRoleAlreadyActive
+	}
+
+	if err := s.repo.SetRole(user.UserID, role, &actorID); err != nil {
+		return nil, fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	s.logger.Info("Role granted",
+		zap.Int64("actor_id", actorID),
+		zap.Int64("user_id", user.UserID),
+		zap.String("old_role", user.Role.String()),
+		zap.String("new_role", role.String()))
+
+	user.Role = role
+	return user, nil
+}
+
+// Revoke снимает с пользователя роль от имени actor
+func (s *AccessService) Revoke(actorID int64, actorUsername, target string) (*model.User, error) {
+	_, user, err := s.prepareRoleChange(actorID, actorUsername, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == model.RoleUser {
+		return user, ErrRoleAlreadyActive
+	}
+
+	if err := s.repo.SetRole(user.UserID, model.RoleUser, &actorID); err != nil {
+		return nil, fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	s.logger.Info("Role revoked",
+		zap.Int64("actor_id", actorID),
+		zap.Int64("user_id", user.UserID),
+		zap.String("old_role", user.Role.String()))
+
+	user.Role = model.RoleUser
+	return user, nil
+}
+
+// prepareRoleChange находит цель и проверяет, что actor старше ее текущей роли
+func (s *AccessService) prepareRoleChange(actorID int64, actorUsername, target string) (model.Role, *model.User, error) {
+	actorRole := s.RoleOf(actorID, actorUsername)
+
+	user, err := s.findUser(target)
+	if err != nil {
+		return actorRole, nil, err
+	}
+
+	if user.UserID == actorID {
+		return actorRole, nil, ErrCannotChangeSelf
+	}
+	if actorRole.Level() <= user.Role.Level() {
+		return actorRole, nil, ErrInsufficientRole
+	}
+
+	return actorRole, user, nil
+}
+
+// findUser находит пользователя по Telegram ID или @username
+func (s *AccessService) findUser(target string) (*model.User, error) {
+	target = strings.TrimSpace(target)
+
+	if userID, err := strconv.ParseInt(target, 10, 64); err == nil {
+		user, err := s.repo.GetByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil {
+			// Пользователь еще не писал боту, но ID неизменяем - роль можно выдать заранее
+			return &model.User{UserID: userID, Role: model.RoleUser}, nil
+		}
+		return user, nil
+	}
+
+	user, err := s.repo.GetByUsername(strings.TrimPrefix(target, "@"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// GetStaff возвращает пользователей с ролями выше RoleUser, начиная со старших
+func (s *AccessService) GetStaff() ([]model.User, error) {
+	users, err := s.repo.GetByRoles([]model.Role{model.RoleOwner, model.RoleAdmin, model.RoleEditor})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].Role.Level() > users[j].Role.Level()
+	})
+	return users, nil
+}
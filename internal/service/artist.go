@@ -2,7 +2,9 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"gemfactory/internal/i18n"
 	"gemfactory/internal/model"
 	"gemfactory/internal/storage/repository"
 	"sort"
@@ -12,18 +14,192 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrAliasConflict псевдоним уже принадлежит другому артисту или совпадает с его именем
+var ErrAliasConflict = errors.New("alias belongs to another artist")
+
 // ArtistService содержит бизнес-логику для работы с артистами
 type ArtistService struct {
-	repo   model.ArtistRepository
-	logger *zap.Logger
+	repo         model.ArtistRepository
+	aliasRepo    model.ArtistAliasRepository
+	relationRepo model.ArtistRelationRepository
+	logger       *zap.Logger
 }
 
 // NewArtistService создает новый сервис артистов
 func NewArtistService(db *bun.DB, logger *zap.Logger) *ArtistService {
 	return &ArtistService{
-		repo:   repository.NewArtistRepository(db, logger),
-		logger: logger,
+		repo:         repository.NewArtistRepository(db, logger),
+		aliasRepo:    repository.NewArtistAliasRepository(db, logger),
+		relationRepo: repository.NewArtistRelationRepository(db, logger),
+		logger:       logger,
+	}
+}
+
+// whitelistedArtists возвращает артистов из списка: активных, а также солистов, юниты и совместные
+// проекты активных артистов с включенным IncludeRelated, даже если сами они неактивны
+func whitelistedArtists(artistRepo model.ArtistRepository, relationRepo model.ArtistRelationRepository) ([]model.Artist, error) {
+	artists, err := artistRepo.GetActive()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active artists: %w", err)
+	}
+
+	included := make(map[int]bool, len(artists))
+	withRelated := make(map[int]bool)
+	for _, artist := range artists {
+		included[artist.ArtistID] = true
+		if artist.IncludeRelated {
+			withRelated[artist.ArtistID] = true
+		}
+	}
+	if len(withRelated) == 0 {
+		return artists, nil
+	}
+
+	relations, err := relationRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artist relations: %w", err)
+	}
+	for _, relation := range relations {
+		if !withRelated[relation.ParentID] || included[relation.ArtistID] || relation.Artist == nil {
+			continue
+		}
+		included[relation.ArtistID] = true
+		artists = append(artists, *relation.Artist)
+	}
+
+	return artists, nil
+}
+
+// newActiveArtistMatcher строит индекс сопоставления артистов из списка и их псевдонимов
+func newActiveArtistMatcher(artistRepo model.ArtistRepository, aliasRepo model.ArtistAliasRepository, relationRepo model.ArtistRelationRepository) (*model.ArtistMatcher, error) {
+	artists, err := whitelistedArtists(artistRepo, relationRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := aliasRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artist aliases: %w", err)
+	}
+
+	return model.NewArtistMatcher(artists, aliases), nil
+}
+
+// Matcher возвращает индекс сопоставления имен с артистами из списка
+func (s *ArtistService) Matcher() (*model.ArtistMatcher, error) {
+	return newActiveArtistMatcher(s.repo, s.aliasRepo, s.relationRepo)
+}
+
+// AddAliases добавляет псевдонимы артисту. Возвращает nil, если артист не найден
+func (s *ArtistService) AddAliases(artistName string, aliases []string) (*model.Artist, int, error) {
+	artist, err := s.repo.GetByName(artistName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get artist %s: %w", artistName, err)
+	}
+	if artist == nil {
+		return nil, 0, nil
+	}
+
+	artists, err := s.repo.GetAll()
+	if err != nil {
+		return artist, 0, fmt.Errorf("failed to get artists: %w", err)
+	}
+	names := make(map[string]model.Artist, len(artists))
+	for _, existing := range artists {
+		names[model.NormalizeArtistName(existing.Name)] = existing
+	}
+
+	addedCount := 0
+	for _, alias := range aliases {
+		normalized := model.NormalizeArtistName(alias)
+		if normalized == "" {
+			continue
+		}
+
+		// Имя артиста совпадает с псевдонимом и без записи в таблице
+		if owner, ok := names[normalized]; ok {
+			if owner.ArtistID == artist.ArtistID {
+				continue
+			}
+			return artist, addedCount, fmt.Errorf("%w: %s is %s", ErrAliasConflict, alias, owner.Name)
+		}
+
+		existing, err := s.aliasRepo.GetByNormalized(normalized)
+		if err != nil {
+			return artist, addedCount, fmt.Errorf("failed to check alias %s: %w", alias, err)
+		}
+		if existing != nil {
+			if existing.ArtistID == artist.ArtistID {
+				continue
+			}
+			owner := fmt.Sprintf("artist %d", existing.ArtistID)
+			if existing.Artist != nil {
+				owner = existing.Artist.Name
+			}
+			return artist, addedCount, fmt.Errorf("%w: %s is used by %s", ErrAliasConflict, alias, owner)
+		}
+
+		err = s.aliasRepo.Create(&model.ArtistAlias{
+			ArtistID:   artist.ArtistID,
+			Alias:      strings.TrimSpace(alias),
+			Normalized: normalized,
+		})
+		if err != nil {
+			return artist, addedCount, fmt.Errorf("failed to create alias %s: %w", alias, err)
+		}
+		addedCount++
+	}
+
+	return artist, addedCount, nil
+}
+
+// RemoveAliases удаляет псевдонимы артиста. Возвращает nil, если артист не найден
+func (s *ArtistService) RemoveAliases(artistName string, aliases []string) (*model.Artist, int, error) {
+	artist, err := s.repo.GetByName(artistName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get artist %s: %w", artistName, err)
+	}
+	if artist == nil {
+		return nil, 0, nil
+	}
+
+	removedCount := 0
+	for _, alias := range aliases {
+		removed, err := s.aliasRepo.Delete(artist.ArtistID, model.NormalizeArtistName(alias))
+		if err != nil {
+			return artist, removedCount, fmt.Errorf("failed to remove alias %s: %w", alias, err)
+		}
+		if removed {
+			removedCount++
+		}
 	}
+
+	return artist, removedCount, nil
+}
+
+// GetAliases возвращает псевдонимы артиста, либо все псевдонимы, если имя пустое
+func (s *ArtistService) GetAliases(artistName string) ([]model.ArtistAlias, error) {
+	if strings.TrimSpace(artistName) == "" {
+		return s.aliasRepo.GetAll()
+	}
+
+	artist, err := s.repo.GetByName(artistName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artist %s: %w", artistName, err)
+	}
+	if artist == nil {
+		return nil, nil
+	}
+
+	aliases, err := s.aliasRepo.GetByArtist(artist.ArtistID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range aliases {
+		aliases[i].Artist = artist
+	}
+
+	return aliases, nil
 }
 
 // AddArtists добавляет артистов
@@ -73,6 +249,29 @@ func (s *ArtistService) AddArtists(artists []string, isFemale bool) (int, error)
 	return addedCount, nil
 }
 
+// SetIncludeRelated включает или выключает для артистов список солистов, юнитов и совместных проектов.
+// Возвращает количество измененных артистов
+func (s *ArtistService) SetIncludeRelated(artists []string, include bool) (int, error) {
+	changedCount := 0
+	for _, artistName := range artists {
+		artist, err := s.repo.GetByName(artistName)
+		if err != nil {
+			return changedCount, fmt.Errorf("failed to get artist %s: %w", artistName, err)
+		}
+		if artist == nil || artist.IncludeRelated == include {
+			continue
+		}
+
+		artist.IncludeRelated = include
+		if err := s.repo.Update(artist); err != nil {
+			return changedCount, fmt.Errorf("failed to update artist %s: %w", artistName, err)
+		}
+		changedCount++
+	}
+
+	return changedCount, nil
+}
+
 // RemoveArtists удаляет артистов (физическое удаление)
 func (s *ArtistService) RemoveArtists(artists []string) (int, error) {
 	removedCount := 0
@@ -132,6 +331,33 @@ func (s *ArtistService) DeactivateArtists(artists []string) (int, error) {
 	return deactivatedCount, nil
 }
 
+// AuditState возвращает состояние артистов до изменения для журнала аудита.
+// Артисты, которых нет в базе, отмечаются как absent
+func (s *ArtistService) AuditState(artists []string) (string, error) {
+	found := make([]model.Artist, 0, len(artists))
+	var absent []string
+	for _, artistName := range artists {
+		artist, err := s.repo.GetByName(artistName)
+		if err != nil {
+			return "", fmt.Errorf("failed to get artist %s: %w", artistName, err)
+		}
+		if artist == nil {
+			absent = append(absent, artistName+": absent")
+			continue
+		}
+		found = append(found, *artist)
+	}
+
+	state := ArtistsAuditState(found)
+	if len(absent) > 0 {
+		if state != "" {
+			state += "; "
+		}
+		state += strings.Join(absent, "; ")
+	}
+	return state, nil
+}
+
 // GetFemaleArtists возвращает активных женских артистов
 func (s *ArtistService) GetFemaleArtists() ([]string, error) {
 	artists, err := s.repo.GetByGenderAndActive(model.GenderFemale, true)
@@ -221,15 +447,15 @@ func (s *ArtistService) Export() (string, error) {
 }
 
 // FormatArtists форматирует артистов для отображения
-func (s *ArtistService) FormatArtists() string {
+func (s *ArtistService) FormatArtists(lang i18n.Lang) string {
 	femaleArtists, _ := s.GetFemaleArtists()
 	maleArtists, _ := s.GetMaleArtists()
 
 	var response strings.Builder
 
-	response.WriteString("<b>Женские артисты:</b>\n")
+	response.WriteString(lang.T("artists.female"))
 	if len(femaleArtists) == 0 {
-		response.WriteString("пусто\n")
+		response.WriteString(lang.T("artists.empty"))
 	} else {
 		sort.Strings(femaleArtists)
 		response.WriteString(fmt.Sprintf("<code>%s</code>\n", strings.Join(femaleArtists, ", ")))
@@ -238,16 +464,16 @@ func (s *ArtistService) FormatArtists() string {
 	// Добавляем перенос строки между категориями
 	response.WriteString("\n")
 
-	response.WriteString("<b>Мужские артисты:</b>\n")
+	response.WriteString(lang.T("artists.male"))
 	if len(maleArtists) == 0 {
-		response.WriteString("пусто\n")
+		response.WriteString(lang.T("artists.empty"))
 	} else {
 		sort.Strings(maleArtists)
 		response.WriteString(fmt.Sprintf("<code>%s</code>\n", strings.Join(maleArtists, ", ")))
 	}
 
 	// Добавляем подсчет в конце
-	response.WriteString(fmt.Sprintf("\n📊 Всего артистов: %d\n💃 Женских: %d\n🤦‍♂️ Мужских: %d",
+	response.WriteString(lang.T("artists.summary",
 		len(femaleArtists)+len(maleArtists), len(femaleArtists), len(maleArtists)))
 
 	return response.String()
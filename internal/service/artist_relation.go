@@ -0,0 +1,108 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gemfactory/internal/model"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ErrSelfRelation артиста нельзя связать с самим собой
+var ErrSelfRelation = errors.New("artist cannot be related to itself")
+
+// AddRelation связывает артиста с родителем. Отсутствующий артист создается неактивным с полом родителя:
+// он попадает в список только через родителя с включенным IncludeRelated.
+// Возвращает nil, если родитель не найден, и признак создания артиста
+func (s *ArtistService) AddRelation(artistName, parentName string, relationType model.RelationType) (*model.ArtistRelation, bool, error) {
+	parent, err := s.repo.GetByName(parentName)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get artist %s: %w", parentName, err)
+	}
+	if parent == nil {
+		return nil, false, nil
+	}
+
+	artist, err := s.repo.GetByName(artistName)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get artist %s: %w", artistName, err)
+	}
+
+	created := false
+	if artist == nil {
+		artist = &model.Artist{
+			Name:   strings.TrimSpace(artistName),
+			Gender: parent.Gender,
+		}
+		if err := s.repo.Create(artist); err != nil {
+			return nil, false, fmt.Errorf("failed to create artist %s: %w", artistName, err)
+		}
+		created = true
+
+		// Нулевое значение is_active при вставке заменяется на DEFAULT true, поэтому снимаем флаг отдельно
+		artist.IsActive = false
+		if err := s.repo.Update(artist); err != nil {
+			return nil, created, fmt.Errorf("failed to deactivate artist %s: %w", artistName, err)
+		}
+	}
+
+	if artist.ArtistID == parent.ArtistID {
+		return nil, false, ErrSelfRelation
+	}
+
+	relation := &model.ArtistRelation{
+		ArtistID: artist.ArtistID,
+		ParentID: parent.ArtistID,
+		Type:     relationType,
+		Artist:   artist,
+		Parent:   parent,
+	}
+	if err := s.relationRepo.Create(relation); err != nil {
+		return nil, created, err
+	}
+
+	s.logger.Info("Artist relation added",
+		zap.String("artist", artist.Name),
+		zap.String("parent", parent.Name),
+		zap.String("type", string(relationType)),
+		zap.Bool("artist_created", created))
+
+	return relation, created, nil
+}
+
+// RemoveRelation удаляет связь артиста с родителем, возвращает false, если связи не было
+func (s *ArtistService) RemoveRelation(artistName, parentName string) (bool, error) {
+	parent, err := s.repo.GetByName(parentName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get artist %s: %w", parentName, err)
+	}
+	artist, err := s.repo.GetByName(artistName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get artist %s: %w", artistName, err)
+	}
+	if parent == nil || artist == nil {
+		return false, nil
+	}
+
+	return s.relationRepo.Delete(artist.ArtistID, parent.ArtistID)
+}
+
+// GetRelations возвращает связи артиста, либо все связи, если имя пустое.
+// Возвращает nil, если артист не найден
+func (s *ArtistService) GetRelations(artistName string) ([]model.ArtistRelation, error) {
+	if strings.TrimSpace(artistName) == "" {
+		return s.relationRepo.GetAll()
+	}
+
+	artist, err := s.repo.GetByName(artistName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artist %s: %w", artistName, err)
+	}
+	if artist == nil {
+		return nil, nil
+	}
+
+	return s.relationRepo.GetByArtist(artist.ArtistID)
+}
@@ -0,0 +1,238 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// maskedValue заменяет секретные значения в журнале аудита
+const maskedValue = "***"
+
+// AuditService ведет журнал действий администраторов
+type AuditService struct {
+	repo   model.AuditLogRepository
+	logger *zap.Logger
+
+	// pending записи команд, которые сейчас обрабатываются, по чату и сообщению
+	pending sync.Map
+}
+
+// NewAuditService создает новый сервис журнала аудита
+func NewAuditService(db *bun.DB, logger *zap.Logger) *AuditService {
+	return &AuditService{
+		repo:   repository.NewAuditLogRepository(db, logger),
+		logger: logger,
+	}
+}
+
+// AuditRecord запись аудита обрабатываемой команды.
+// Методы безопасно вызывать у nil, если команда не записывается в журнал
+type AuditRecord struct {
+	mu       sync.Mutex
+	key      string
+	entry    model.AuditEntry
+	detached bool // Команда продолжает работу в фоне, запись сохраняет FinishDetached
+}
+
+// SetBefore сохраняет состояние до выполнения команды
+func (r *AuditRecord) SetBefore(state string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entry.Before = state
+}
+
+// SetAfter сохраняет состояние после выполнения команды
+func (r *AuditRecord) SetAfter(state string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entry.After = state
+}
+
+// Fail отмечает, что команда завершилась ошибкой
+func (r *AuditRecord) Fail(err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entry.Outcome = model.AuditOutcomeError
+	if err != nil {
+		r.entry.Error = err.Error()
+	}
+}
+
+// Invalid отмечает, что команда отклонена из-за неверных аргументов
+func (r *AuditRecord) Invalid(reason string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entry.Outcome = model.AuditOutcomeInvalid
+	r.entry.Error = reason
+}
+
+// Deny отмечает, что у пользователя нет прав на команду
+func (r *AuditRecord) Deny() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entry.Outcome = model.AuditOutcomeDenied
+}
+
+// Detach откладывает сохранение записи до окончания фоновой работы команды:
+// Finish при выходе из обработчика ее пропускает, сохраняет FinishDetached
+func (r *AuditRecord) Detach() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.detached = true
+}
+
+// Begin начинает запись аудита для команды
+func (s *AuditService) Begin(actorID int64, actorUsername string, chatID int64, messageID int, command, arguments string) *AuditRecord {
+	record := &AuditRecord{
+		key: auditKey(chatID, messageID),
+		entry: model.AuditEntry{
+			ActorID:       actorID,
+			ActorUsername: actorUsername,
+			ChatID:        chatID,
+			Command:       command,
+			Arguments:     sanitizeAuditArguments(command, arguments),
+			Outcome:       model.AuditOutcomeSuccess,
+			CreatedAt:     time.Now(),
+		},
+	}
+
+	s.pending.Store(record.key, record)
+	return record
+}
+
+// Record возвращает запись аудита обрабатываемой команды или nil
+func (s *AuditService) Record(chatID int64, messageID int) *AuditRecord {
+	value, ok := s.pending.Load(auditKey(chatID, messageID))
+	if !ok {
+		return nil
+	}
+	return value.(*AuditRecord)
+}
+
+// Finish сохраняет запись аудита
+func (s *AuditService) Finish(record *AuditRecord) {
+	if record == nil {
+		return
+	}
+	s.pending.Delete(record.key)
+
+	record.mu.Lock()
+	entry, detached := record.entry, record.detached
+	record.mu.Unlock()
+
+	if detached {
+		return
+	}
+	s.save(entry)
+}
+
+// FinishDetached сохраняет запись аудита команды, завершившей работу в фоне
+func (s *AuditService) FinishDetached(record *AuditRecord) {
+	if record == nil {
+		return
+	}
+
+	// Признак detached не снимается: Finish обработчика мог еще не выполниться и не должен сохранить запись повторно
+	record.mu.Lock()
+	entry := record.entry
+	record.mu.Unlock()
+
+	s.save(entry)
+}
+
+// save записывает запись аудита в журнал
+func (s *AuditService) save(entry model.AuditEntry) {
+	if err := s.repo.Create(&entry); err != nil {
+		s.logger.Error("Failed to write audit entry",
+			zap.Int64("actor_id", entry.ActorID),
+			zap.String("command", entry.Command),
+			zap.String("outcome", entry.Outcome),
+			zap.Error(err))
+	}
+}
+
+// GetRecent возвращает последние записи журнала
+func (s *AuditService) GetRecent(limit int, command string) ([]model.AuditEntry, error) {
+	return s.repo.GetRecent(limit, strings.ToLower(strings.TrimPrefix(command, "/")))
+}
+
+// ConfigSnapshot возвращает конфигурацию в виде JSON для журнала, скрывая секретные значения
+func ConfigSnapshot(values map[string]string) string {
+	masked := make(map[string]string, len(values))
+	for key, value := range values {
+		masked[key] = AuditConfigValue(key, value)
+	}
+
+	// json.Marshal сортирует ключи, поэтому снимки можно сравнивать
+	data, err := json.Marshal(masked)
+	if err != nil {
+		return fmt.Sprintf("%d keys", len(values))
+	}
+	return string(data)
+}
+
+// AuditConfigValue возвращает значение конфигурации для журнала, скрывая секретные
+func AuditConfigValue(key, value string) string {
+	if IsSensitiveConfigKey(key) && value != "" {
+		return maskedValue
+	}
+	return value
+}
+
+// ArtistsAuditState возвращает пол и активность артистов для журнала аудита
+func ArtistsAuditState(artists []model.Artist) string {
+	states := make([]string, 0, len(artists))
+	for _, artist := range artists {
+		state := "active"
+		if !artist.IsActive {
+			state = "inactive"
+		}
+		states = append(states, fmt.Sprintf("%s: %s, %s", artist.Name, artist.Gender, state))
+	}
+	return strings.Join(states, "; ")
+}
+
+// sanitizeAuditArguments скрывает секретные значения в аргументах команды
+func sanitizeAuditArguments(command, arguments string) string {
+	if command != "config" {
+		return arguments
+	}
+
+	fields := strings.Fields(arguments)
+	if len(fields) >= 2 && IsSensitiveConfigKey(fields[0]) {
+		return fields[0] + " " + maskedValue
+	}
+	return arguments
+}
+
+// auditKey возвращает ключ обрабатываемой команды
+func auditKey(chatID int64, messageID int) string {
+	return fmt.Sprintf("%d:%d", chatID, messageID)
+}
@@ -0,0 +1,350 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gemfactory/internal/config"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// CalendarPath путь ленты релизов на health сервере
+const CalendarPath = "/calendar.ics"
+
+const (
+	// calendarPastDays сколько дней прошедших релизов попадает в ленту
+	calendarPastDays = 30
+	// calendarFutureDays сколько дней будущих релизов попадает в ленту
+	calendarFutureDays = 365
+	// calendarEventDuration длительность события релиза с известным временем
+	calendarEventDuration = time.Hour
+	// calendarTokenLength длина подписи персональной ссылки в hex символах
+	calendarTokenLength = 32
+	// icsLineLimit максимальная длина строки iCalendar в октетах (RFC 5545, 3.1)
+	icsLineLimit = 75
+)
+
+// Ошибки ленты релизов
+var (
+	ErrCalendarDisabled      = errors.New("calendar feed is not configured")
+	ErrInvalidCalendarFilter = errors.New("invalid calendar filter")
+	ErrInvalidCalendarToken  = errors.New("invalid calendar token")
+)
+
+// CalendarFilter параметры ленты релизов
+type CalendarFilter struct {
+	Genders []model.Gender // пустой список - все артисты
+	Artists []string       // имена артистов в нижнем регистре, пустой список - все артисты
+	UserID  int64          // ненулевое значение - только подписки пользователя
+}
+
+// CalendarService формирует ленту релизов в формате iCalendar (RFC 5545)
+type CalendarService struct {
+	releaseRepo      model.ReleaseRepository
+	subscriptionRepo model.SubscriptionRepository
+	relationRepo     model.ArtistRelationRepository
+	config           *config.Config
+	logger           *zap.Logger
+}
+
+// NewCalendarService создает новый сервис ленты релизов
+func NewCalendarService(db *bun.DB, cfg *config.Config, logger *zap.Logger) *CalendarService {
+	return &CalendarService{
+		releaseRepo:      repository.NewReleaseRepository(db, logger),
+		subscriptionRepo: repository.NewSubscriptionRepository(db, logger),
+		relationRepo:     repository.NewArtistRelationRepository(db, logger),
+		config:           cfg,
+		logger:           logger,
+	}
+}
+
+// CalendarFeed формирует ленту по параметрам запроса: gender, artists, user и token
+func (s *CalendarService) CalendarFeed(query url.Values) ([]byte, error) {
+	filter, err := s.ParseFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	return s.Render(filter)
+}
+
+// ParseFilter разбирает и проверяет параметры запроса ленты
+func (s *CalendarService) ParseFilter(query url.Values) (CalendarFilter, error) {
+	var filter CalendarFilter
+
+	for _, value := range splitCalendarParam(query.Get("gender")) {
+		gender := model.Gender(value)
+		if !gender.IsValid() {
+			return filter, fmt.Errorf("%w: unknown gender %q", ErrInvalidCalendarFilter, value)
+		}
+		filter.Genders = append(filter.Genders, gender)
+	}
+
+	filter.Artists = splitCalendarParam(query.Get("artists"))
+
+	if user := query.Get("user"); user != "" {
+		userID, err := strconv.ParseInt(user, 10, 64)
+		if err != nil || userID <= 0 {
+			return filter, fmt.Errorf("%w: invalid user %q", ErrInvalidCalendarFilter, user)
+		}
+		if !hmac.Equal([]byte(query.Get("token")), []byte(s.userToken(userID))) {
+			return filter, ErrInvalidCalendarToken
+		}
+		filter.UserID = userID
+	}
+
+	return filter, nil
+}
+
+// Render формирует ленту релизов по фильтру
+func (s *CalendarService) Render(filter CalendarFilter) ([]byte, error) {
+	now := time.Now()
+	releases, err := s.releaseRepo.GetByDateRange(now.AddDate(0, 0, -calendarPastDays), now.AddDate(0, 0, calendarFutureDays))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get releases for calendar: %w", err)
+	}
+
+	var subscribed map[int]bool
+	if filter.UserID != 0 {
+		subscriptions, err := s.subscriptionRepo.GetByUser(filter.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subscriptions for calendar: %w", err)
+		}
+		subscribed = make(map[int]bool, len(subscriptions))
+		for _, subscription := range subscriptions {
+			artistIDs, err := followedArtistIDs(subscription, s.relationRepo)
+			if err != nil {
+				return nil, err
+			}
+			for _, artistID := range artistIDs {
+				subscribed[artistID] = true
+			}
+		}
+	}
+
+	var events []model.Release
+	for _, release := range releases {
+		if release.ReleaseDate == nil || !filter.matches(release, subscribed) {
+			continue
+		}
+		events = append(events, release)
+	}
+
+	s.logger.Debug("Rendered calendar feed",
+		zap.Int("releases", len(releases)),
+		zap.Int("events", len(events)),
+		zap.Int64("user_id", filter.UserID))
+
+	return renderICS(events, now), nil
+}
+
+// FeedURL возвращает ссылку на ленту. Ненулевой userID добавляет подписанный фильтр по подпискам
+func (s *CalendarService) FeedURL(filter CalendarFilter) (string, error) {
+	baseURL := strings.TrimRight(s.config.CalendarConfig.BaseURL, "/")
+	if baseURL == "" || !s.config.HealthCheckEnabled {
+		return "", ErrCalendarDisabled
+	}
+
+	query := url.Values{}
+	if len(filter.Genders) > 0 {
+		genders := make([]string, 0, len(filter.Genders))
+		for _, gender := range filter.Genders {
+			genders = append(genders, gender.String())
+		}
+		query.Set("gender", strings.Join(genders, ","))
+	}
+	if len(filter.Artists) > 0 {
+		query.Set("artists", strings.Join(filter.Artists, ","))
+	}
+	if filter.UserID != 0 {
+		query.Set("user", strconv.FormatInt(filter.UserID, 10))
+		query.Set("token", s.userToken(filter.UserID))
+	}
+
+	feedURL := baseURL + CalendarPath
+	if encoded := query.Encode(); encoded != "" {
+		feedURL += "?" + encoded
+	}
+	return feedURL, nil
+}
+
+// userToken возвращает подпись персональной ссылки пользователя
+func (s *CalendarService) userToken(userID int64) string {
+	secret := s.config.CalendarConfig.Secret
+	if secret == "" {
+		secret = s.config.BotToken
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("calendar:" + strconv.FormatInt(userID, 10)))
+	return hex.EncodeToString(mac.Sum(nil))[:calendarTokenLength]
+}
+
+// matches проверяет, что релиз подходит под фильтр
+func (f CalendarFilter) matches(release model.Release, subscribed map[int]bool) bool {
+	if subscribed != nil && !subscribed[release.ArtistID] {
+		return false
+	}
+	if release.Artist == nil {
+		return len(f.Genders) == 0 && len(f.Artists) == 0
+	}
+
+	if len(f.Genders) > 0 {
+		found := false
+		for _, gender := range f.Genders {
+			if release.Artist.Gender == gender {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Artists) > 0 {
+		name := strings.ToLower(release.Artist.Name)
+		for _, artist := range f.Artists {
+			if artist == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// splitCalendarParam разбирает список значений через запятую
+func splitCalendarParam(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// renderICS формирует календарь из релизов
+func renderICS(releases []model.Release, now time.Time) []byte {
+	var ics strings.Builder
+	writeICSLine(&ics, "BEGIN:VCALENDAR")
+	writeICSLine(&ics, "VERSION:2.0")
+	writeICSLine(&ics, "PRODID:-//gemfactory//K-pop releases//RU")
+	writeICSLine(&ics, "CALSCALE:GREGORIAN")
+	writeICSLine(&ics, "METHOD:PUBLISH")
+	writeICSLine(&ics, "X-WR-CALNAME:K-pop релизы")
+	writeICSLine(&ics, "X-WR-TIMEZONE:Europe/Moscow")
+	writeICSLine(&ics, "REFRESH-INTERVAL;VALUE=DURATION:PT6H")
+	writeICSLine(&ics, "X-PUBLISHED-TTL:PT6H")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, release := range releases {
+		writeICSEvent(&ics, release, stamp)
+	}
+
+	writeICSLine(&ics, "END:VCALENDAR")
+	return []byte(ics.String())
+}
+
+// writeICSEvent добавляет событие релиза. Релиз без времени становится событием на весь день
+func writeICSEvent(ics *strings.Builder, release model.Release, stamp string) {
+	artist := fmt.Sprintf("артист #%d", release.ArtistID)
+	if release.Artist != nil {
+		artist = release.Artist.Name
+	}
+	track := strings.TrimSpace(strings.ReplaceAll(release.GetDisplayTrack(), "Title Track:", ""))
+
+	writeICSLine(ics, "BEGIN:VEVENT")
+	writeICSLine(ics, fmt.Sprintf("UID:release-%d@gemfactory", release.ReleaseID))
+	// DTSTAMP и LAST-MODIFIED берутся из updated_at релиза, а не из времени запроса. updated_at обновляется
+	// при каждом парсинге, поэтому ETag ленты совпадает между запросами, но меняется после каждого парсинга
+	if !release.UpdatedAt.IsZero() {
+		stamp = release.UpdatedAt.UTC().Format("20060102T150405Z")
+		writeICSLine(ics, "LAST-MODIFIED:"+stamp)
+	}
+	writeICSLine(ics, "DTSTAMP:"+stamp)
+
+	if release.ReleaseAt != nil {
+		start := release.ReleaseAt.UTC()
+		writeICSLine(ics, "DTSTART:"+start.Format("20060102T150405Z"))
+		writeICSLine(ics, "DTEND:"+start.Add(calendarEventDuration).Format("20060102T150405Z"))
+	} else {
+		day := *release.ReleaseDate
+		writeICSLine(ics, "DTSTART;VALUE=DATE:"+day.Format("20060102"))
+		writeICSLine(ics, "DTEND;VALUE=DATE:"+day.AddDate(0, 0, 1).Format("20060102"))
+	}
+
+	summary := artist
+	if !isEmptyValue(track) {
+		summary = fmt.Sprintf("%s — %s", artist, track)
+	}
+	writeICSLine(ics, "SUMMARY:"+escapeICSText(summary))
+
+	var description []string
+	description = append(description, "Артист: "+artist)
+	if !isEmptyValue(track) {
+		description = append(description, "Титульный трек: "+track)
+	}
+	if !isEmptyValue(release.AlbumName) {
+		description = append(description, "Альбом: "+release.AlbumName)
+	}
+	if !isEmptyValue(release.TimeMSK) {
+		description = append(description, "Время (МСК): "+release.TimeMSK)
+	}
+	if release.HasMV() {
+		description = append(description, "MV: "+release.MV)
+	}
+	writeICSLine(ics, "DESCRIPTION:"+escapeICSText(strings.Join(description, "\n")))
+
+	if release.HasMV() {
+		writeICSLine(ics, "URL:"+release.MV)
+	}
+	writeICSLine(ics, "CATEGORIES:K-POP")
+	writeICSLine(ics, "TRANSP:TRANSPARENT")
+	writeICSLine(ics, "END:VEVENT")
+}
+
+// escapeICSText экранирует значение TEXT (RFC 5545, 3.3.11)
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeICSLine записывает строку с CRLF, перенося длинные строки без разрыва UTF-8 символов
+func writeICSLine(ics *strings.Builder, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		ics.WriteString(line[:cut])
+		ics.WriteString("\r\n ")
+		line = line[cut:]
+		// Строки продолжения начинаются с пробела, который входит в лимит
+		limit = icsLineLimit - 1
+	}
+	ics.WriteString(line)
+	ics.WriteString("\r\n")
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"gemfactory/internal/model"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeICSText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"aespa — Whiplash", "aespa — Whiplash"},
+		{"Red, Velvet", `Red\, Velvet`},
+		{"Album; Repackage", `Album\; Repackage`},
+		{`back\slash`, `back\\slash`},
+		{"line1\nline2", `line1\nline2`},
+		{"line1\r\nline2\rline3", `line1\nline2\nline3`},
+		{`a,b;c\d` + "\n", `a\,b\;c\\d\n`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := escapeICSText(tt.value); got != tt.want {
+				t.Errorf("escapeICSText(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteICSLineFolding(t *testing.T) {
+	summary := "SUMMARY:" + strings.Repeat("Очень длинное название релиза ", 8)
+
+	var ics strings.Builder
+	writeICSLine(&ics, summary)
+	output := ics.String()
+
+	if !strings.HasSuffix(output, "\r\n") {
+		t.Fatalf("line %q does not end with CRLF", output)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("long line was not folded: %q", output)
+	}
+	for i, line := range lines {
+		if len(line) > icsLineLimit {
+			t.Errorf("line %d is %d octets long, limit %d", i, len(line), icsLineLimit)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a UTF-8 character: %q", i, line)
+		}
+		if i > 0 && !strings.HasPrefix(line, " ") {
+			t.Errorf("continuation line %d does not start with a space: %q", i, line)
+		}
+	}
+
+	// Разворачивание по RFC 5545 (удаление CRLF и пробела) восстанавливает исходную строку
+	if unfolded := strings.ReplaceAll(strings.TrimSuffix(output, "\r\n"), "\r\n ", ""); unfolded != summary {
+		t.Errorf("unfolded line = %q, want %q", unfolded, summary)
+	}
+}
+
+func TestRenderICSEvent(t *testing.T) {
+	day := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 10, 20, 12, 30, 0, 0, time.UTC)
+	release := model.Release{
+		ReleaseID:   42,
+		ArtistID:    1,
+		Artist:      &model.Artist{Name: "Red Velvet"},
+		TitleTrack:  "Cosmic, Part 1; Remix",
+		AlbumName:   "Cosmic",
+		ReleaseDate: &day,
+		UpdatedAt:   updated,
+	}
+
+	output := string(renderICS([]model.Release{release}, time.Date(2025, 11, 1, 8, 0, 0, 0, time.UTC)))
+	unfolded := strings.ReplaceAll(output, "\r\n ", "")
+
+	for _, want := range []string{
+		"UID:release-42@gemfactory\r\n",
+		"DTSTAMP:20251020T123000Z\r\n",
+		"LAST-MODIFIED:20251020T123000Z\r\n",
+		"DTSTART;VALUE=DATE:20251103\r\n",
+		"DTEND;VALUE=DATE:20251104\r\n",
+		`SUMMARY:Red Velvet — Cosmic\, Part 1\; Remix` + "\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("calendar does not contain %q:\n%s", want, unfolded)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"html"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// AlwaysAllowedChatCommands команды, которые нельзя отключить в группе, чтобы администраторы не потеряли доступ к настройкам
+var AlwaysAllowedChatCommands = []string{"chat", "help"}
+
+// ChatSettingsService управляет настройками групповых чатов
+type ChatSettingsService struct {
+	repo   model.ChatSettingsRepository
+	logger *zap.Logger
+
+	mu    sync.RWMutex
+	cache map[int64]*model.ChatSettings
+}
+
+// NewChatSettingsService создает новый сервис настроек групповых чатов
+func NewChatSettingsService(db *bun.DB, logger *zap.Logger) *ChatSettingsService {
+	return &ChatSettingsService{
+		repo:   repository.NewChatSettingsRepository(db, logger),
+		logger: logger,
+		cache:  make(map[int64]*model.ChatSettings),
+	}
+}
+
+// Get возвращает копию настроек чата. Если настройки не заданы или недоступны, возвращает значения по умолчанию
+func (s *ChatSettingsService) Get(chatID int64) model.ChatSettings {
+	s.mu.RLock()
+	settings, ok := s.cache[chatID]
+	s.mu.RUnlock()
+	if ok {
+		return *settings
+	}
+
+	settings, err := s.repo.GetByChatID(chatID)
+	if err != nil {
+		// Не кэшируем ошибку, чтобы повторить попытку при следующем запросе
+		s.logger.Warn("Failed to load chat settings", zap.Int64("chat_id", chatID), zap.Error(err))
+		return *model.DefaultChatSettings(chatID)
+	}
+	if settings == nil {
+		settings = model.DefaultChatSettings(chatID)
+	}
+
+	s.mu.Lock()
+	s.cache[chatID] = settings
+	s.mu.Unlock()
+	return *settings
+}
+
+// Language возвращает язык, заданный для чата. false - язык не задан и используется язык пользователя
+func (s *ChatSettingsService) Language(chatID int64) (i18n.Lang, bool) {
+	return i18n.Parse(s.Get(chatID).Language)
+}
+
+// DefaultGenderFilter возвращает фильтр /month чата в виде флагов -f/-m. false - фильтр не задан
+func (s *ChatSettingsService) DefaultGenderFilter(chatID int64) (femaleOnly, maleOnly, ok bool) {
+	switch s.Get(chatID).GenderFilter {
+	case model.GenderFemale:
+		return true, false, true
+	case model.GenderMale:
+		return false, true, true
+	}
+	return false, false, false
+}
+
+// IsCommandAllowed проверяет, разрешена ли команда в чате
+func (s *ChatSettingsService) IsCommandAllowed(chatID int64, command string) bool {
+	if slices.Contains(AlwaysAllowedChatCommands, command) {
+		return true
+	}
+	settings := s.Get(chatID)
+	return settings.IsCommandAllowed(command)
+}
+
+// SetLanguage сохраняет язык ответов в чате. Пустое значение возвращает язык пользователей
+func (s *ChatSettingsService) SetLanguage(chatID int64, lang i18n.Lang) error {
+	return s.update(chatID, func(settings *model.ChatSettings) {
+		settings.Language = lang.String()
+	})
+}
+
+// SetGenderFilter сохраняет фильтр /month по умолчанию для чата. Пустое значение возвращает фильтр пользователей
+func (s *ChatSettingsService) SetGenderFilter(chatID int64, gender model.Gender) error {
+	if gender != "" && gender != model.GenderFemale && gender != model.GenderMale {
+		return fmt.Errorf("invalid gender filter: %s", gender)
+	}
+
+	return s.update(chatID, func(settings *model.ChatSettings) {
+		settings.GenderFilter = gender
+	})
+}
+
+// SetDigest включает или отключает дайджесты релизов в чате
+func (s *ChatSettingsService) SetDigest(chatID int64, enabled bool) error {
+	return s.update(chatID, func(settings *model.ChatSettings) {
+		settings.Digest = enabled
+	})
+}
+
+// SetQuietMode включает или отключает тихий режим чата
+func (s *ChatSettingsService) SetQuietMode(chatID int64, enabled bool) error {
+	return s.update(chatID, func(settings *model.ChatSettings) {
+		settings.QuietMode = enabled
+	})
+}
+
+// SetAllowedCommands сохраняет список разрешенных в чате команд. Пустой список разрешает все команды
+func (s *ChatSettingsService) SetAllowedCommands(chatID int64, commands []string) error {
+	allowed := make([]string, 0, len(commands))
+	for _, command := range commands {
+		command = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(command), "/"))
+		if command == "" || slices.Contains(allowed, command) {
+			continue
+		}
+		allowed = append(allowed, command)
+	}
+
+	return s.update(chatID, func(settings *model.ChatSettings) {
+		settings.AllowedCommands = allowed
+	})
+}
+
+// GetDigestChats возвращает настройки групп, включивших дайджесты
+func (s *ChatSettingsService) GetDigestChats() ([]model.ChatSettings, error) {
+	chats, err := s.repo.GetDigestChats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest chats: %w", err)
+	}
+	return chats, nil
+}
+
+// FormatSettings форматирует настройки чата для команды /chat
+func (s *ChatSettingsService) FormatSettings(settings model.ChatSettings, lang i18n.Lang) string {
+	language := lang.T("chat.language_user")
+	if chatLang, ok := i18n.Parse(settings.Language); ok {
+		language = chatLang.Name()
+	}
+
+	gender := lang.T("chat.gender_user")
+	switch settings.GenderFilter {
+	case model.GenderFemale:
+		gender = lang.T("settings.gender_female")
+	case model.GenderMale:
+		gender = lang.T("settings.gender_male")
+	}
+
+	commands := lang.T("chat.commands_all")
+	if len(settings.AllowedCommands) > 0 {
+		commands = html.EscapeString("/" + strings.Join(settings.AllowedCommands, ", /"))
+	}
+
+	return lang.T("chat.text", language, gender, onOff(settings.Digest, lang), onOff(settings.QuietMode, lang), commands)
+}
+
+// update изменяет и сохраняет настройки чата
+func (s *ChatSettingsService) update(chatID int64, apply func(settings *model.ChatSettings)) error {
+	settings := s.Get(chatID)
+	settings.AllowedCommands = slices.Clone(settings.AllowedCommands)
+	apply(&settings)
+
+	if err := s.repo.Upsert(&settings); err != nil {
+		return fmt.Errorf("failed to update settings for chat %d: %w", chatID, err)
+	}
+
+	s.mu.Lock()
+	s.cache[chatID] = &settings
+	s.mu.Unlock()
+
+	s.logger.Info("Chat settings changed",
+		zap.Int64("chat_id", chatID),
+		zap.String("language", settings.Language),
+		zap.String("gender_filter", settings.GenderFilter.String()),
+		zap.Bool("digest", settings.Digest),
+		zap.Bool("quiet_mode", settings.QuietMode),
+		zap.Strings("allowed_commands", settings.AllowedCommands))
+	return nil
+}
+
+// onOff возвращает локализованное "вкл"/"выкл"
+func onOff(enabled bool, lang i18n.Lang) string {
+	if enabled {
+		return lang.T("chat.on")
+	}
+	return lang.T("chat.off")
+}
@@ -82,20 +82,12 @@ func (s *ConfigService) GetAll() (string, error) {
 		return "", fmt.Errorf("failed to get all configs: %w", err)
 	}
 
-	sensitiveKeys := map[string]bool{
-		"BOT_TOKEN":             true,
-		"LLM_API_KEY":           true,
-		"LLM_DELAY":             true,
-		"SPOTIFY_CLIENT_ID":     true,
-		"SPOTIFY_CLIENT_SECRET": true,
-	}
-
 	var result strings.Builder
 	result.WriteString("📋 Текущая конфигурация:\n\n")
 
 	for _, config := range configs {
 		var value string
-		if sensitiveKeys[config.Key] {
+		if IsSensitiveConfigKey(config.Key) {
 			value = "🔒 [СКРЫТО В ЦЕЛЯХ БЕЗОПАСНОСТИ - СМОТРИТЕ В ОКРУЖЕНИИ]"
 		} else {
 			value = config.Value
@@ -111,6 +103,20 @@ func (s *ConfigService) GetAll() (string, error) {
 	return result.String(), nil
 }
 
+// sensitiveConfigKeys ключи конфигурации, значения которых нельзя показывать и сохранять в журналах
+var sensitiveConfigKeys = map[string]bool{
+	"BOT_TOKEN":             true,
+	"LLM_API_KEY":           true,
+	"LLM_DELAY":             true,
+	"SPOTIFY_CLIENT_ID":     true,
+	"SPOTIFY_CLIENT_SECRET": true,
+}
+
+// IsSensitiveConfigKey проверяет, что значение ключа конфигурации секретное
+func IsSensitiveConfigKey(key string) bool {
+	return sensitiveConfigKeys[strings.ToUpper(key)]
+}
+
 // Reset сбрасывает конфигурацию к значениям по умолчанию
 func (s *ConfigService) Reset() error {
 	err := s.repo.Reset()
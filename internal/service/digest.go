@@ -0,0 +1,287 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/config"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxDigestLookaheadDays максимальный горизонт дайджеста в днях
+const maxDigestLookaheadDays = 31
+
+// DigestConfig описывает рассылку дайджеста, задается в Task.Config
+type DigestConfig struct {
+	LookaheadDays int          // lookahead_days: 1 - релизы сегодня, 7 - релизы недели
+	ChatIDs       []int64      // chat_ids: группы, в которые отправляется дайджест
+	Gender        model.Gender // gender: female, male или пусто для всех артистов
+	Users         bool         // users: отправлять пользователям, включившим /digest on
+	Chats         bool         // chats: отправлять группам, включившим /chat digest on
+	SendEmpty     bool         // send_empty: отправлять дайджест без релизов
+}
+
+// DigestResult содержит итоги рассылки дайджеста
+type DigestResult struct {
+	Releases  int
+	UsersSent int
+	ChatsSent int
+	Failed    int
+}
+
+// DigestConfigFromTask читает параметры дайджеста из конфигурации задачи
+func DigestConfigFromTask(task *model.Task) (DigestConfig, error) {
+	cfg := DigestConfig{LookaheadDays: 1, Users: true, Chats: true}
+
+	if days, ok := task.GetConfigInt("lookahead_days"); ok {
+		cfg.LookaheadDays = days
+	}
+	if cfg.LookaheadDays < 1 || cfg.LookaheadDays > maxDigestLookaheadDays {
+		return cfg, fmt.Errorf("lookahead_days must be between 1 and %d, got %d", maxDigestLookaheadDays, cfg.LookaheadDays)
+	}
+
+	if chatIDs, ok := task.GetConfigInt64Slice("chat_ids"); ok {
+		cfg.ChatIDs = chatIDs
+	}
+
+	if gender, ok := task.GetConfigString("gender"); ok && gender != "" {
+		cfg.Gender = model.Gender(strings.ToLower(gender))
+		if cfg.Gender != model.GenderFemale && cfg.Gender != model.GenderMale {
+			return cfg, fmt.Errorf("invalid gender filter: %s", gender)
+		}
+	}
+
+	if users, ok := task.GetConfigBool("users"); ok {
+		cfg.Users = users
+	}
+	if chats, ok := task.GetConfigBool("chats"); ok {
+		cfg.Chats = chats
+	}
+	if sendEmpty, ok := task.GetConfigBool("send_empty"); ok {
+		cfg.SendEmpty = sendEmpty
+	}
+
+	return cfg, nil
+}
+
+// DigestService рассылает сводки релизов на сегодня и на неделю
+type DigestService struct {
+	release  *ReleaseService
+	settings *SettingsService
+	chats    *ChatSettingsService
+	locale   *LocaleService
+	notifier Notifier
+	config   *config.Config
+	logger   *zap.Logger
+}
+
+// NewDigestService создает новый сервис дайджестов
+func NewDigestService(releaseService *ReleaseService, settingsService *SettingsService, localeService *LocaleService, cfg *config.Config, logger *zap.Logger) *DigestService {
+	return &DigestService{
+		release:  releaseService,
+		settings: settingsService,
+		locale:   localeService,
+		config:   cfg,
+		logger:   logger,
+	}
+}
+
+// SetNotifier устанавливает отправителя дайджестов
+func (s *DigestService) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// SetChatSettingsService устанавливает сервис настроек групп, включивших дайджесты через /chat
+func (s *DigestService) SetChatSettingsService(chats *ChatSettingsService) {
+	s.chats = chats
+}
+
+// Send формирует и рассылает дайджест группам и подписанным пользователям
+func (s *DigestService) Send(ctx context.Context, cfg DigestConfig) (DigestResult, error) {
+	var result DigestResult
+	if s.notifier == nil {
+		return result, fmt.Errorf("notifier is not set")
+	}
+
+	start, end := s.digestRange(cfg.LookaheadDays)
+	releases, err := s.release.repo.GetByDateRange(start, end)
+	if err != nil {
+		return result, fmt.Errorf("failed to get releases for digest: %w", err)
+	}
+	releases = filterReleasesByGender(releases, cfg.Gender)
+	result.Releases = len(releases)
+
+	s.logger.Info("Sending release digest",
+		zap.Time("start", start),
+		zap.Time("end", end),
+		zap.Int("releases", len(releases)),
+		zap.Int("chats", len(cfg.ChatIDs)),
+		zap.Bool("users", cfg.Users))
+
+	// Группы получают дайджест на языке и в часовом поясе по умолчанию
+	groupSettings := *model.DefaultUserSettings(0)
+	groupSettings.Timezone = s.config.Timezone
+	for _, chatID := range cfg.ChatIDs {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		text, ok := s.formatDigest(releases, start, cfg, groupSettings, i18n.Default, false)
+		if !ok {
+			continue
+		}
+		if err := s.notifier.SendMessage(chatID, text); err != nil {
+			s.logger.Warn("Failed to send digest to chat", zap.Int64("chat_id", chatID), zap.Error(err))
+			result.Failed++
+			continue
+		}
+		result.ChatsSent++
+	}
+
+	if cfg.Chats && s.chats != nil {
+		if err := s.sendToChats(ctx, cfg, releases, start, groupSettings, &result); err != nil {
+			return result, err
+		}
+	}
+
+	if !cfg.Users {
+		return result, nil
+	}
+
+	subscribers, err := s.settings.GetDigestSubscribers()
+	if err != nil {
+		return result, err
+	}
+
+	for _, settings := range subscribers {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		// Личный фильтр из /settings сужает фильтр задачи
+		userReleases := releases
+		if cfg.Gender == "" && settings.GenderFilter != "" {
+			userReleases = filterReleasesByGender(releases, settings.GenderFilter)
+		}
+
+		text, ok := s.formatDigest(userReleases, start, cfg, settings, s.locale.ForUser(settings.UserID), true)
+		if !ok {
+			continue
+		}
+		// Личный чат с пользователем совпадает с его Telegram ID
+		if err := s.notifier.SendMessage(settings.UserID, text); err != nil {
+			s.logger.Warn("Failed to send digest to user", zap.Int64("user_id", settings.UserID), zap.Error(err))
+			result.Failed++
+			continue
+		}
+		result.UsersSent++
+	}
+
+	return result, nil
+}
+
+// sendToChats рассылает дайджест группам, включившим его через /chat, с языком и фильтром группы
+func (s *DigestService) sendToChats(ctx context.Context, cfg DigestConfig, releases []model.Release, start time.Time, groupSettings model.UserSettings, result *DigestResult) error {
+	chats, err := s.chats.GetDigestChats()
+	if err != nil {
+		return err
+	}
+
+	for _, chat := range chats {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Группа из chat_ids задачи уже получила дайджест
+		if slices.Contains(cfg.ChatIDs, chat.ChatID) {
+			continue
+		}
+
+		chatReleases := releases
+		if cfg.Gender == "" && chat.GenderFilter != "" {
+			chatReleases = filterReleasesByGender(releases, chat.GenderFilter)
+		}
+
+		lang, ok := i18n.Parse(chat.Language)
+		if !ok {
+			lang = i18n.Default
+		}
+
+		text, ok := s.formatDigest(chatReleases, start, cfg, groupSettings, lang, false)
+		if !ok {
+			continue
+		}
+		if err := s.notifier.SendMessage(chat.ChatID, text); err != nil {
+			s.logger.Warn("Failed to send digest to chat", zap.Int64("chat_id", chat.ChatID), zap.Error(err))
+			result.Failed++
+			continue
+		}
+		result.ChatsSent++
+	}
+
+	return nil
+}
+
+// digestRange возвращает диапазон дат дайджеста [start, end) по часовому поясу приложения
+func (s *DigestService) digestRange(days int) (time.Time, time.Time) {
+	loc, err := time.LoadLocation(s.config.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	now := time.Now().In(loc)
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, days)
+}
+
+// formatDigest форматирует дайджест. Возвращает false, если релизов нет и пустой дайджест не нужен
+func (s *DigestService) formatDigest(releases []model.Release, start time.Time, cfg DigestConfig, settings model.UserSettings, lang i18n.Lang, personal bool) (string, bool) {
+	if len(releases) == 0 && !cfg.SendEmpty {
+		return "", false
+	}
+
+	dateFormat := s.release.GetReleaseConfig().DateFormat()
+	last := start.AddDate(0, 0, cfg.LookaheadDays-1)
+
+	var text strings.Builder
+	switch cfg.LookaheadDays {
+	case 1:
+		text.WriteString(lang.T("digest.today", start.Format(dateFormat)))
+	case 7:
+		text.WriteString(lang.T("digest.week", start.Format(dateFormat), last.Format(dateFormat)))
+	default:
+		text.WriteString(lang.T("digest.days", cfg.LookaheadDays, start.Format(dateFormat), last.Format(dateFormat)))
+	}
+
+	if len(releases) == 0 {
+		text.WriteString(lang.T("releases.not_found"))
+		text.WriteString("\n")
+	}
+	for _, release := range releases {
+		text.WriteString(s.release.formatReleaseEntry(release, settings, lang))
+	}
+
+	if personal {
+		text.WriteString(lang.T("digest.footer"))
+	}
+
+	return text.String(), true
+}
+
+// filterReleasesByGender оставляет релизы артистов указанного пола, пустой фильтр оставляет все
+func filterReleasesByGender(releases []model.Release, gender model.Gender) []model.Release {
+	if gender == "" {
+		return releases
+	}
+
+	filtered := make([]model.Release, 0, len(releases))
+	for _, release := range releases {
+		if release.Artist != nil && release.Artist.Gender == gender {
+			filtered = append(filtered, release)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,279 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gemfactory/internal/external/scraper"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// maxDiscoverySamples количество примеров релизов, хранимых для найденного артиста
+const maxDiscoverySamples = 3
+
+// ErrDiscoveryResolved решение по найденному артисту уже принято
+var ErrDiscoveryResolved = errors.New("discovered artist already resolved")
+
+// DiscoveryAction решение администратора по найденному артисту
+type DiscoveryAction string
+
+// Решения по найденным артистам
+const (
+	DiscoveryAddFemale DiscoveryAction = "female" // Добавить в женский список
+	DiscoveryAddMale   DiscoveryAction = "male"   // Добавить в мужской список
+	DiscoveryIgnore    DiscoveryAction = "ignore" // Скрыть из отчета
+)
+
+// DiscoveryService ведет отчет об артистах из расписания, которых нет в списке
+type DiscoveryService struct {
+	repo       model.DiscoveredArtistRepository
+	artistRepo model.ArtistRepository
+	aliasRepo  model.ArtistAliasRepository
+	artists    *ArtistService
+	logger     *zap.Logger
+	utils      *model.ReleaseUtils
+}
+
+// NewDiscoveryService создает сервис отчета о новых артистах
+func NewDiscoveryService(db *bun.DB, artists *ArtistService, logger *zap.Logger) *DiscoveryService {
+	return &DiscoveryService{
+		repo:       repository.NewDiscoveredArtistRepository(db, logger),
+		artistRepo: repository.NewArtistRepository(db, logger),
+		aliasRepo:  repository.NewArtistAliasRepository(db, logger),
+		artists:    artists,
+		logger:     logger,
+		utils:      model.NewReleaseUtils(),
+	}
+}
+
+// discoveredRows строки расписания одного артиста за парсинг
+type discoveredRows struct {
+	name        string
+	sightings   []string
+	releaseDate *time.Time
+	samples     []string
+	url         string
+}
+
+// Record сохраняет строки расписания с артистами не из списка.
+// Артисты из списка (в том числе неактивные) и их псевдонимы пропускаются.
+// Возвращает имена артистов, ожидающих решения администратора
+func (s *DiscoveryService) Record(rows []scraper.UnmatchedArtist) ([]string, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	artists, err := s.artistRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artists: %w", err)
+	}
+	aliases, err := s.aliasRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artist aliases: %w", err)
+	}
+	known := model.NewArtistMatcher(artists, aliases)
+
+	var order []string
+	grouped := make(map[string]*discoveredRows)
+	for _, row := range rows {
+		key := model.NormalizeArtistName(row.Artist)
+		if key == "" {
+			continue
+		}
+		if _, ok := known.Match(row.Artist); ok {
+			continue
+		}
+
+		group, ok := grouped[key]
+		if !ok {
+			group = &discoveredRows{name: row.Artist}
+			grouped[key] = group
+			order = append(order, key)
+		}
+
+		group.sightings = appendSighting(group.sightings, discoverySighting(row))
+		group.url = row.URL
+		group.samples = appendSample(group.samples, row.Release)
+		if row.Date != "" {
+			if date, err := s.utils.ParseReleaseDate(row.Date); err == nil && (group.releaseDate == nil || date.After(*group.releaseDate)) {
+				group.releaseDate = &date
+			}
+		}
+	}
+
+	now := time.Now()
+	var pending []string
+	for _, key := range order {
+		group := grouped[key]
+
+		existing, err := s.repo.GetByNormalized(key)
+		if err != nil {
+			return pending, err
+		}
+
+		if existing == nil {
+			existing = &model.DiscoveredArtist{
+				Name:            group.name,
+				Normalized:      key,
+				Status:          model.DiscoveryStatusNew,
+				SeenCount:       len(group.sightings),
+				Sightings:       group.sightings,
+				FirstSeenAt:     now,
+				LastSeenAt:      now,
+				LastReleaseDate: group.releaseDate,
+				SampleReleases:  group.samples,
+				SourceURL:       group.url,
+			}
+			if existing.SampleReleases == nil {
+				existing.SampleReleases = []string{}
+			}
+			if err := s.repo.Create(existing); err != nil {
+				return pending, err
+			}
+		} else {
+			existing.Name = group.name
+			// Повторный парсинг той же страницы добавляет только новые строки
+			sightings := existing.Sightings
+			for _, sighting := range group.sightings {
+				sightings = appendSighting(sightings, sighting)
+			}
+			existing.Sightings = sightings
+			existing.SeenCount = len(sightings)
+			existing.LastSeenAt = now
+			existing.SourceURL = group.url
+			if group.releaseDate != nil && (existing.LastReleaseDate == nil || group.releaseDate.After(*existing.LastReleaseDate)) {
+				existing.LastReleaseDate = group.releaseDate
+			}
+			samples := group.samples
+			for _, sample := range existing.SampleReleases {
+				samples = appendSample(samples, sample)
+			}
+			if samples != nil {
+				existing.SampleReleases = samples
+			}
+			if err := s.repo.Update(existing); err != nil {
+				return pending, err
+			}
+		}
+
+		if existing.Status == model.DiscoveryStatusNew {
+			pending = append(pending, existing.Name)
+		}
+	}
+
+	s.logger.Info("Recorded unmatched artists",
+		zap.Int("rows", len(rows)),
+		zap.Int("artists", len(order)),
+		zap.Int("pending", len(pending)))
+
+	return pending, nil
+}
+
+// discoverySighting возвращает ключ строки расписания: страница, дата и релиз
+func discoverySighting(row scraper.UnmatchedArtist) string {
+	return row.URL + "|" + row.Date + "|" + strings.ToLower(strings.TrimSpace(row.Release))
+}
+
+// appendSighting добавляет строку расписания без повторов
+func appendSighting(sightings []string, sighting string) []string {
+	for _, existing := range sightings {
+		if existing == sighting {
+			return sightings
+		}
+	}
+	return append(sightings, sighting)
+}
+
+// appendSample добавляет пример релиза без повторов, не больше maxDiscoverySamples
+func appendSample(samples []string, sample string) []string {
+	sample = strings.TrimSpace(sample)
+	if sample == "" || len(samples) >= maxDiscoverySamples {
+		return samples
+	}
+	for _, existing := range samples {
+		if strings.EqualFold(existing, sample) {
+			return samples
+		}
+	}
+	return append(samples, sample)
+}
+
+// GetPending возвращает найденных артистов, ожидающих решения, и их общее количество
+func (s *DiscoveryService) GetPending(limit int) ([]model.DiscoveredArtist, int, error) {
+	artists, err := s.repo.GetByStatus(model.DiscoveryStatusNew, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByStatus(model.DiscoveryStatusNew)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return artists, total, nil
+}
+
+// Resolve применяет решение администратора: добавляет артиста в список или скрывает из отчета.
+// Возвращает nil, если артист не найден
+func (s *DiscoveryService) Resolve(discoveredID int, action DiscoveryAction) (*model.DiscoveredArtist, error) {
+	discovered, err := s.repo.GetByID(discoveredID)
+	if err != nil || discovered == nil {
+		return nil, err
+	}
+	if discovered.Status != model.DiscoveryStatusNew {
+		return discovered, ErrDiscoveryResolved
+	}
+
+	status := model.DiscoveryStatusAdded
+	switch action {
+	case DiscoveryAddFemale, DiscoveryAddMale:
+		if _, err := s.artists.AddArtists([]string{discovered.Name}, action == DiscoveryAddFemale); err != nil {
+			return discovered, fmt.Errorf("failed to add discovered artist %s: %w", discovered.Name, err)
+		}
+	case DiscoveryIgnore:
+		status = model.DiscoveryStatusIgnored
+	default:
+		return discovered, fmt.Errorf("unknown discovery action: %s", action)
+	}
+
+	if err := s.repo.UpdateStatus(discoveredID, status); err != nil {
+		return discovered, err
+	}
+	discovered.Status = status
+
+	s.logger.Info("Resolved discovered artist",
+		zap.String("artist", discovered.Name),
+		zap.String("action", string(action)))
+
+	return discovered, nil
+}
+
+// FormatPending форматирует отчет о найденных артистах для /discover
+func (s *DiscoveryService) FormatPending(artists []model.DiscoveredArtist, total int) string {
+	if len(artists) == 0 {
+		return "🔎 Новых артистов в расписании нет"
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🔎 <b>Артисты не из списка</b> (%d из %d):\n", len(artists), total))
+	for i, artist := range artists {
+		text.WriteString(fmt.Sprintf("\n%d. <b>%s</b> — встречался %d раз", i+1, html.EscapeString(artist.Name), artist.SeenCount))
+		if artist.LastReleaseDate != nil {
+			text.WriteString(fmt.Sprintf(", релиз %s", artist.LastReleaseDate.Format("02.01.2006")))
+		}
+		text.WriteString("\n")
+		for _, sample := range artist.SampleReleases {
+			text.WriteString(fmt.Sprintf("   • %s\n", html.EscapeString(sample)))
+		}
+	}
+	text.WriteString("\n♀ / ♂ - добавить в женский или мужской список, 🚫 - скрыть")
+
+	return text.String()
+}
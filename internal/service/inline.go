@@ -0,0 +1,114 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxInlineResults максимальное число результатов в ответе на inline запрос
+	MaxInlineResults = 50
+	// inlineArtistLimit число артистов, релизы которых попадают в ответ на inline запрос
+	inlineArtistLimit = 5
+	// inlineUpcomingDays горизонт ближайших релизов для пустого inline запроса
+	inlineUpcomingDays = 7
+)
+
+// ReleaseCard представляет карточку релиза для отправки через inline режим
+type ReleaseCard struct {
+	Title       string // Заголовок результата: артист и альбом
+	Description string // Подпись результата: дата и трек
+	Text        string // HTML текст сообщения с карточкой релиза
+}
+
+// SearchInline возвращает релизы для inline запроса: релизы подходящих артистов,
+// сначала предстоящие. Пустой запрос возвращает релизы ближайшей недели
+func (s *ReleaseService) SearchInline(query string) ([]model.Release, error) {
+	query = strings.TrimSpace(query)
+	today := s.today()
+
+	if query == "" {
+		releases, err := s.repo.GetByDateRange(today, today.AddDate(0, 0, inlineUpcomingDays))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get upcoming releases: %w", err)
+		}
+		return releases[:min(len(releases), MaxInlineResults)], nil
+	}
+
+	artists, err := s.artistRepo.SearchByName(query, inlineArtistLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search artists for %q: %w", query, err)
+	}
+
+	var upcoming, past []model.Release
+	for _, artist := range artists {
+		releases, err := s.repo.GetByArtistName(artist.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get releases for artist %s: %w", artist.Name, err)
+		}
+		for _, release := range releases {
+			if release.ReleaseDate != nil && release.ReleaseDate.Before(today) {
+				past = append(past, release)
+			} else {
+				upcoming = append(upcoming, release)
+			}
+		}
+	}
+
+	// Прошедшие релизы показываются после предстоящих, от последнего к первому
+	slices.Reverse(past)
+	releases := append(upcoming, past...)
+
+	s.logger.Debug("Inline search results",
+		zap.String("query", query),
+		zap.Int("artists", len(artists)),
+		zap.Int("releases", len(releases)))
+
+	return releases[:min(len(releases), MaxInlineResults)], nil
+}
+
+// FormatReleaseCard форматирует карточку релиза в часовом поясе пользователя
+func (s *ReleaseService) FormatReleaseCard(release model.Release, userID int64, lang i18n.Lang) ReleaseCard {
+	settings := s.userSettings(userID)
+	settings.Layout = model.LayoutVerbose
+
+	var artistName string
+	if release.Artist != nil {
+		artistName = release.Artist.Name
+	}
+
+	title := artistName
+	if release.AlbumName != "" && release.AlbumName != "N/A" {
+		title += " — " + release.AlbumName
+	} else if release.Title != "" && release.Title != "N/A" {
+		title += " — " + release.Title
+	}
+
+	date, releaseTime := s.releaseDateTime(release, settings)
+	description := date
+	if releaseTime != "" {
+		description += ", " + releaseTime
+	}
+	if trackName := strings.TrimSpace(strings.ReplaceAll(release.TitleTrack, "Title Track:", "")); trackName != "" && trackName != "N/A" {
+		description += " · " + trackName
+	}
+
+	return ReleaseCard{
+		Title:       title,
+		Description: description,
+		Text:        strings.TrimSpace(s.formatReleaseEntry(release, settings, lang)),
+	}
+}
+
+// today возвращает текущую дату по MSK в виде даты релиза
+func (s *ReleaseService) today() time.Time {
+	now := time.Now().In(s.GetReleaseConfig().MSKLocation())
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -42,3 +42,8 @@ type SchedulerInterface interface {
 	RegisterExecutor(taskType model.TaskType, executor TaskExecutor)
 	ReloadTasks() error
 }
+
+// Notifier определяет интерфейс для отправки уведомлений пользователям
+type Notifier interface {
+	SendMessage(chatID int64, text string) error
+}
@@ -0,0 +1,68 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gemfactory/internal/external/llm"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// LLMResponseCache хранит ответы LLM в базе данных
+type LLMResponseCache struct {
+	repo   model.LLMCacheRepository
+	logger *zap.Logger
+}
+
+// NewLLMResponseCache создает кэш ответов LLM
+func NewLLMResponseCache(db *bun.DB, logger *zap.Logger) *LLMResponseCache {
+	return &LLMResponseCache{
+		repo:   repository.NewLLMCacheRepository(db, logger),
+		logger: logger,
+	}
+}
+
+// Get возвращает закэшированный ответ LLM
+func (c *LLMResponseCache) Get(ctx context.Context, key string) (*llm.MultiReleaseResponse, bool, error) {
+	entry, err := c.repo.Get(key)
+	if err != nil {
+		return nil, false, err
+	}
+	if entry == nil {
+		return nil, false, nil
+	}
+
+	var response llm.MultiReleaseResponse
+	if err := json.Unmarshal([]byte(entry.Response), &response); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal cached response: %w", err)
+	}
+
+	if err := c.repo.MarkHit(key); err != nil {
+		c.logger.Warn("Failed to mark llm cache hit", zap.String("key", key), zap.Error(err))
+	}
+
+	return &response, true, nil
+}
+
+// Set сохраняет ответ LLM в кэш
+func (c *LLMResponseCache) Set(ctx context.Context, key, month, modelName string, response *llm.MultiReleaseResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response for cache: %w", err)
+	}
+
+	return c.repo.Set(&model.LLMCacheEntry{
+		CacheKey:      key,
+		Month:         month,
+		PromptVersion: llm.PromptVersion,
+		Model:         modelName,
+		Response:      string(data),
+	})
+}
+
+var _ llm.ResponseCache = (*LLMResponseCache)(nil)
@@ -0,0 +1,108 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"sync"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// LocaleService определяет язык интерфейса пользователей
+type LocaleService struct {
+	repo   model.UserRepository
+	logger *zap.Logger
+
+	mu sync.RWMutex
+	// chosen языки, выбранные через /lang; пустое значение - выбор не сделан
+	chosen map[int64]i18n.Lang
+	// detected языки Telegram клиентов, чтобы писать пользователю без входящего сообщения
+	detected map[int64]i18n.Lang
+}
+
+// NewLocaleService создает новый сервис языка интерфейса
+func NewLocaleService(db *bun.DB, logger *zap.Logger) *LocaleService {
+	return &LocaleService{
+		repo:     repository.NewUserRepository(db, logger),
+		logger:   logger,
+		chosen:   make(map[int64]i18n.Lang),
+		detected: make(map[int64]i18n.Lang),
+	}
+}
+
+// Resolve возвращает язык пользователя: выбранный через /lang или язык Telegram клиента
+func (s *LocaleService) Resolve(userID int64, languageCode string) i18n.Lang {
+	if lang, ok := s.chosenLanguage(userID); ok {
+		return lang
+	}
+
+	lang := i18n.Detect(languageCode)
+	if languageCode != "" {
+		s.mu.Lock()
+		s.detected[userID] = lang
+		s.mu.Unlock()
+	}
+	return lang
+}
+
+// ForUser возвращает язык пользователя без входящего сообщения, например для уведомлений
+func (s *LocaleService) ForUser(userID int64) i18n.Lang {
+	if lang, ok := s.chosenLanguage(userID); ok {
+		return lang
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if lang, ok := s.detected[userID]; ok {
+		return lang
+	}
+	return i18n.Default
+}
+
+// SetLanguage сохраняет выбранный пользователем язык
+func (s *LocaleService) SetLanguage(userID int64, username string, lang i18n.Lang) error {
+	if err := s.repo.SetLanguage(userID, username, lang.String()); err != nil {
+		return fmt.Errorf("failed to save language for user %d: %w", userID, err)
+	}
+
+	s.mu.Lock()
+	s.chosen[userID] = lang
+	s.mu.Unlock()
+
+	s.logger.Info("User language changed",
+		zap.Int64("user_id", userID),
+		zap.String("language", lang.String()))
+	return nil
+}
+
+// chosenLanguage возвращает выбранный пользователем язык, загружая его из базы при первом обращении
+func (s *LocaleService) chosenLanguage(userID int64) (i18n.Lang, bool) {
+	s.mu.RLock()
+	lang, cached := s.chosen[userID]
+	s.mu.RUnlock()
+	if cached {
+		return lang, lang != ""
+	}
+
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		// Не кэшируем ошибку, чтобы повторить попытку при следующем сообщении
+		s.logger.Warn("Failed to load user language", zap.Int64("user_id", userID), zap.Error(err))
+		return "", false
+	}
+
+	if user != nil {
+		if parsed, ok := i18n.Parse(user.Language); ok {
+			lang = parsed
+		}
+	}
+
+	s.mu.Lock()
+	s.chosen[userID] = lang
+	s.mu.Unlock()
+	return lang, lang != ""
+}
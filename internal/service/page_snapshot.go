@@ -0,0 +1,164 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"gemfactory/internal/external/llm"
+	"gemfactory/internal/external/scraper"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// maxSnapshotsPerPage количество хранимых снимков одной страницы расписания
+const maxSnapshotsPerPage = 10
+
+// SnapshotService хранит сжатые снимки страниц расписания для воспроизведения парсинга
+type SnapshotService struct {
+	repo   model.PageSnapshotRepository
+	logger *zap.Logger
+}
+
+// NewSnapshotService создает сервис снимков страниц
+func NewSnapshotService(db *bun.DB, logger *zap.Logger) *SnapshotService {
+	return &SnapshotService{
+		repo:   repository.NewPageSnapshotRepository(db, logger),
+		logger: logger,
+	}
+}
+
+// Get возвращает снимок по ID, nil - снимок не найден
+func (s *SnapshotService) Get(snapshotID int) (*model.PageSnapshot, error) {
+	return s.repo.GetByID(snapshotID)
+}
+
+// Latest возвращает последний снимок страницы, nil - страница еще не сохранялась
+func (s *SnapshotService) Latest(url string) (*model.PageSnapshot, error) {
+	return s.repo.GetLatestByURL(url)
+}
+
+// GetRecent возвращает последние снимки страниц без тела страницы
+func (s *SnapshotService) GetRecent(limit int) ([]model.PageSnapshot, error) {
+	return s.repo.GetRecent(limit)
+}
+
+// Save сжимает и сохраняет полученную страницу, старые снимки страницы удаляются
+func (s *SnapshotService) Save(page *scraper.PageSnapshot, month, year string) (*model.PageSnapshot, error) {
+	var body bytes.Buffer
+	writer := gzip.NewWriter(&body)
+	if _, err := writer.Write(page.Body); err != nil {
+		return nil, fmt.Errorf("failed to compress page %s: %w", page.URL, err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress page %s: %w", page.URL, err)
+	}
+
+	snapshot := &model.PageSnapshot{
+		Source:       page.Source,
+		URL:          page.URL,
+		Month:        month,
+		Year:         year,
+		FetchedAt:    page.FetchedAt,
+		ETag:         page.ETag,
+		LastModified: page.LastModified,
+		ContentHash:  page.ContentHash,
+		Size:         len(page.Body),
+		Body:         body.Bytes(),
+	}
+	if err := s.repo.Create(snapshot); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeleteOld(page.URL, maxSnapshotsPerPage); err != nil {
+		s.logger.Warn("Failed to delete old page snapshots", zap.String("url", page.URL), zap.Error(err))
+	}
+
+	s.logger.Info("Saved page snapshot",
+		zap.Int("snapshot_id", snapshot.SnapshotID),
+		zap.String("url", page.URL),
+		zap.Int("size", snapshot.Size),
+		zap.Int("compressed", len(snapshot.Body)))
+
+	return snapshot, nil
+}
+
+// Page распаковывает снимок в страницу для парсера
+func (s *SnapshotService) Page(snapshot *model.PageSnapshot) (*scraper.PageSnapshot, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(snapshot.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress snapshot %d: %w", snapshot.SnapshotID, err)
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress snapshot %d: %w", snapshot.SnapshotID, err)
+	}
+
+	return &scraper.PageSnapshot{
+		Source:       snapshot.Source,
+		URL:          snapshot.URL,
+		FetchedAt:    snapshot.FetchedAt,
+		ETag:         snapshot.ETag,
+		LastModified: snapshot.LastModified,
+		ContentHash:  snapshot.ContentHash,
+		Body:         body,
+	}, nil
+}
+
+// MarkParsed запоминает отпечаток списка артистов, с которым снимок успешно разобран
+func (s *SnapshotService) MarkParsed(snapshotID int, filterHash string) {
+	if err := s.repo.UpdateFilterHash(snapshotID, filterHash); err != nil {
+		s.logger.Warn("Failed to mark page snapshot as parsed", zap.Int("snapshot_id", snapshotID), zap.Error(err))
+	}
+}
+
+// FormatRecent форматирует список снимков для /snapshots
+func (s *SnapshotService) FormatRecent(snapshots []model.PageSnapshot) string {
+	if len(snapshots) == 0 {
+		return "📦 Снимков страниц пока нет"
+	}
+
+	var text strings.Builder
+	text.WriteString("📦 <b>Снимки страниц расписания:</b>\n")
+	for _, snapshot := range snapshots {
+		text.WriteString(fmt.Sprintf("\n<b>#%d</b> %s %s, %s — %d КБ\n",
+			snapshot.SnapshotID, snapshot.Month, snapshot.Year,
+			snapshot.FetchedAt.Format("02.01.2006 15:04"), (snapshot.Size+1023)/1024))
+		text.WriteString(fmt.Sprintf("   %s: %s\n", html.EscapeString(snapshot.Source), html.EscapeString(snapshot.URL)))
+	}
+	text.WriteString("\nПовторить разбор снимка: /replay [номер]")
+
+	return text.String()
+}
+
+// artistFilterHash возвращает отпечаток списка артистов, псевдонимов и версии промпта.
+// Неизменная страница пропускается, только если прошлый разбор был с тем же отпечатком
+func artistFilterHash(artists []model.Artist, aliases []model.ArtistAlias) string {
+	names := make([]string, 0, len(artists)+len(aliases))
+	for _, artist := range artists {
+		names = append(names, fmt.Sprintf("artist:%d:%s", artist.ArtistID, model.NormalizeArtistName(artist.Name)))
+	}
+	for _, alias := range aliases {
+		names = append(names, fmt.Sprintf("alias:%d:%s", alias.ArtistID, model.NormalizeArtistName(alias.Alias)))
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	hash.Write([]byte(llm.PromptVersion))
+	for _, name := range names {
+		hash.Write([]byte{0})
+		hash.Write([]byte(name))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
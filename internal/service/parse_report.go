@@ -0,0 +1,137 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"gemfactory/internal/external/scraper"
+	"gemfactory/internal/model"
+	"sync"
+)
+
+// maxNearMissCandidates количество похожих артистов для одного несопоставленного имени
+const maxNearMissCandidates = 3
+
+// ParseReport итог парсинга релизов
+type ParseReport struct {
+	Parsed     int        // Релизов получено из источников
+	Saved      int        // Релизов сохранено
+	NearMisses []NearMiss // Имена из источников, похожие на артистов из списка, но не сопоставленные с ними
+	Discovered []string   // Артисты не из списка, ожидающие решения в /discover
+	Unchanged  int        // Страниц источников, пропущенных без изменений с прошлого парсинга
+}
+
+// NearMiss имя артиста из источника и похожие артисты из списка
+type NearMiss struct {
+	Name       string
+	Candidates []model.ArtistMatch
+}
+
+// Merge добавляет к отчету результаты парсинга другого месяца
+func (r *ParseReport) Merge(other *ParseReport) {
+	if other == nil {
+		return
+	}
+
+	r.Parsed += other.Parsed
+	r.Saved += other.Saved
+	r.Unchanged += other.Unchanged
+
+	seen := make(map[string]bool, len(r.NearMisses))
+	for _, nearMiss := range r.NearMisses {
+		seen[model.NormalizeArtistName(nearMiss.Name)] = true
+	}
+	for _, nearMiss := range other.NearMisses {
+		if key := model.NormalizeArtistName(nearMiss.Name); !seen[key] {
+			seen[key] = true
+			r.NearMisses = append(r.NearMisses, nearMiss)
+		}
+	}
+
+	for _, name := range r.Discovered {
+		seen[model.NormalizeArtistName(name)] = true
+	}
+	for _, name := range other.Discovered {
+		if key := model.NormalizeArtistName(name); !seen[key] {
+			seen[key] = true
+			r.Discovered = append(r.Discovered, name)
+		}
+	}
+}
+
+// nearMissCollector сопоставляет имена артистов и запоминает похожие, но не прошедшие порог имена,
+// а также строки с артистами не из списка.
+// Реализует scraper.ArtistFilter и scraper.UnmatchedArtistObserver, методы вызываются из обработчиков colly
+type nearMissCollector struct {
+	matcher *model.ArtistMatcher
+
+	mu         sync.Mutex
+	checked    map[string]bool
+	nearMisses []NearMiss
+	unmatched  []scraper.UnmatchedArtist
+}
+
+// newNearMissCollector создает фильтр артистов для парсинга
+func newNearMissCollector(matcher *model.ArtistMatcher) *nearMissCollector {
+	return &nearMissCollector{
+		matcher: matcher,
+		checked: make(map[string]bool),
+	}
+}
+
+// Allow пропускает строки артистов из списка, для остальных запоминает похожих артистов
+func (c *nearMissCollector) Allow(artist string) bool {
+	_, ok := c.Match(artist)
+	return ok
+}
+
+// Match сопоставляет имя с артистом, несопоставленные имена проверяются на сходство
+func (c *nearMissCollector) Match(name string) (model.ArtistMatch, bool) {
+	match, ok := c.matcher.Match(name)
+	if ok {
+		return match, true
+	}
+
+	key := model.NormalizeArtistName(name)
+	if key == "" {
+		return model.ArtistMatch{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.checked[key] {
+		return model.ArtistMatch{}, false
+	}
+	c.checked[key] = true
+
+	if candidates := c.matcher.Candidates(name, maxNearMissCandidates); len(candidates) > 0 {
+		c.nearMisses = append(c.nearMisses, NearMiss{Name: name, Candidates: candidates})
+	}
+
+	return model.ArtistMatch{}, false
+}
+
+// NearMisses возвращает несопоставленные имена, у которых есть похожие артисты
+func (c *nearMissCollector) NearMisses() []NearMiss {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nearMisses := make([]NearMiss, len(c.nearMisses))
+	copy(nearMisses, c.nearMisses)
+	return nearMisses
+}
+
+// ObserveUnmatched запоминает строку расписания с артистом не из списка
+func (c *nearMissCollector) ObserveUnmatched(row scraper.UnmatchedArtist) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unmatched = append(c.unmatched, row)
+}
+
+// Unmatched возвращает строки расписания с артистами не из списка
+func (c *nearMissCollector) Unmatched() []scraper.UnmatchedArtist {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unmatched := make([]scraper.UnmatchedArtist, len(c.unmatched))
+	copy(unmatched, c.unmatched)
+	return unmatched
+}
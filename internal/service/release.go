@@ -3,8 +3,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"gemfactory/internal/external/scraper"
+	"gemfactory/internal/i18n"
 	"gemfactory/internal/model"
 	"gemfactory/internal/storage/repository"
 	"html"
@@ -18,31 +20,76 @@ import (
 
 // ReleaseService содержит бизнес-логику для работы с релизами
 type ReleaseService struct {
-	repo       model.ReleaseRepository
-	artistRepo model.ArtistRepository
-	scraper    scraper.Fetcher
-	logger     *zap.Logger
-	utils      *model.ReleaseUtils
+	repo          model.ReleaseRepository
+	artistRepo    model.ArtistRepository
+	aliasRepo     model.ArtistAliasRepository
+	relationRepo  model.ArtistRelationRepository
+	revisionRepo  model.ReleaseRevisionRepository
+	scraper       scraper.Fetcher
+	subscriptions *SubscriptionService
+	settings      *SettingsService
+	reminders     *ReminderService
+	discovery     *DiscoveryService
+	snapshots     *SnapshotService
+	logger        *zap.Logger
+	utils         *model.ReleaseUtils
 }
 
 // NewReleaseService создает новый сервис релизов
 func NewReleaseService(db *bun.DB, scraper scraper.Fetcher, logger *zap.Logger) *ReleaseService {
 	return &ReleaseService{
-		repo:       repository.NewReleaseRepository(db, logger),
-		artistRepo: repository.NewArtistRepository(db, logger),
-		scraper:    scraper,
-		logger:     logger,
-		utils:      model.NewReleaseUtils(),
+		repo:         repository.NewReleaseRepository(db, logger),
+		artistRepo:   repository.NewArtistRepository(db, logger),
+		aliasRepo:    repository.NewArtistAliasRepository(db, logger),
+		relationRepo: repository.NewArtistRelationRepository(db, logger),
+		revisionRepo: repository.NewReleaseRevisionRepository(db, logger),
+		scraper:      scraper,
+		logger:       logger,
+		utils:        model.NewReleaseUtils(),
 	}
 }
 
+// SetSubscriptionService устанавливает сервис подписок для уведомлений о новых релизах
+func (s *ReleaseService) SetSubscriptionService(subscriptions *SubscriptionService) {
+	s.subscriptions = subscriptions
+}
+
+// SetSettingsService устанавливает сервис пользовательских настроек отображения релизов
+func (s *ReleaseService) SetSettingsService(settings *SettingsService) {
+	s.settings = settings
+}
+
+// SetReminderService устанавливает сервис напоминаний, пересчитываемых при изменении времени релиза
+func (s *ReleaseService) SetReminderService(reminders *ReminderService) {
+	s.reminders = reminders
+}
+
+// SetDiscoveryService устанавливает сервис отчета об артистах из расписания, которых нет в списке
+func (s *ReleaseService) SetDiscoveryService(discovery *DiscoveryService) {
+	s.discovery = discovery
+}
+
+// SetSnapshotService устанавливает сервис снимков страниц расписания
+func (s *ReleaseService) SetSnapshotService(snapshots *SnapshotService) {
+	s.snapshots = snapshots
+}
+
+// userSettings возвращает настройки отображения пользователя или настройки по умолчанию
+func (s *ReleaseService) userSettings(userID int64) model.UserSettings {
+	if s.settings == nil {
+		return *model.DefaultUserSettings(userID)
+	}
+	return s.settings.Get(userID)
+}
+
 // GetLLMMetrics возвращает метрики LLM
 func (s *ReleaseService) GetLLMMetrics() map[string]interface{} {
 	return s.scraper.GetLLMMetrics()
 }
 
-// GetReleasesForMonth возвращает релизы за месяц с фильтром
-func (s *ReleaseService) GetReleasesForMonth(month string, femaleOnly, maleOnly bool) (string, error) {
+// GetReleasesForMonth возвращает релизы за месяц с фильтром в часовом поясе и формате пользователя.
+// Пустой releaseType - релизы любого типа
+func (s *ReleaseService) GetReleasesForMonth(month string, femaleOnly, maleOnly bool, releaseType model.ReleaseType, userID int64, lang i18n.Lang) (string, error) {
 	// Нормализуем месяц
 	month = strings.ToLower(month)
 
@@ -70,52 +117,31 @@ func (s *ReleaseService) GetReleasesForMonth(month string, femaleOnly, maleOnly
 		gender = "male"
 	}
 
-	// Получаем релизы
-	// Получаем активные релизы с артистами и фильтруем по месяцу
-	allReleases, err := s.repo.GetWithRelations()
+	// Определяем границы месяца
+	var releases []model.Release
+	monthStart, err := time.Parse("January 2006", fmt.Sprintf("%s %d", month, year))
 	if err != nil {
-		return "", fmt.Errorf("failed to get all releases: %w", err)
-	}
+		s.logger.Warn("Invalid month requested", zap.String("month", month), zap.Error(err))
+	} else {
+		monthEnd := monthStart.AddDate(0, 1, 0)
 
-	s.logger.Info("Retrieved releases for filtering",
-		zap.String("month", month),
-		zap.Int("year", year),
-		zap.String("gender", gender),
-		zap.Int("total_releases", len(allReleases)))
+		// Получаем релизы за месяц одним запросом по release_date
+		monthReleases, err := s.repo.GetByDateRange(monthStart, monthEnd)
+		if err != nil {
+			return "", fmt.Errorf("failed to get releases for month: %w", err)
+		}
 
-	// Фильтруем релизы по месяцу и году
-	var releases []model.Release
-	for _, release := range allReleases {
-		// Парсим дату релиза
-		if parsedDate, err := s.utils.ParseReleaseDate(release.Date); err == nil {
-			releaseMonth := strings.ToLower(parsedDate.Month().String())
-			releaseYear := parsedDate.Year()
-
-			s.logger.Debug("Parsing release date",
-				zap.String("original_date", release.Date),
-				zap.String("parsed_month", releaseMonth),
-				zap.Int("parsed_year", releaseYear),
-				zap.String("requested_month", month),
-				zap.Int("requested_year", year))
-
-			// Проверяем соответствие месяцу
-			if releaseMonth == month {
-				// Если указан год, проверяем и его
-				if year == 0 || releaseYear == year {
-					// Если указан пол, проверяем и его
-					if gender == "" || (release.Artist != nil && strings.ToLower(string(release.Artist.Gender)) == gender) {
-						releases = append(releases, release)
-						s.logger.Debug("Added release to results",
-							zap.String("artist", release.Artist.Name),
-							zap.String("date", release.Date),
-							zap.String("title", release.Title))
-					}
-				}
+		s.logger.Info("Retrieved releases for month",
+			zap.String("month", month),
+			zap.Int("year", year),
+			zap.String("gender", gender),
+			zap.Int("month_releases", len(monthReleases)))
+
+		// Фильтруем релизы по полу и типу
+		for _, release := range filterReleasesByType(monthReleases, releaseType) {
+			if gender == "" || (release.Artist != nil && strings.ToLower(string(release.Artist.Gender)) == gender) {
+				releases = append(releases, release)
 			}
-		} else {
-			s.logger.Warn("Failed to parse release date",
-				zap.String("date", release.Date),
-				zap.Error(err))
 		}
 	}
 
@@ -123,57 +149,26 @@ func (s *ReleaseService) GetReleasesForMonth(month string, femaleOnly, maleOnly
 		zap.String("month", month),
 		zap.Int("year", year),
 		zap.String("gender", gender),
+		zap.String("type", releaseType.String()),
 		zap.Int("filtered_count", len(releases)))
 
 	// Форматируем ответ
+	settings := s.userSettings(userID)
 	var result strings.Builder
 
-	// Переводим месяц на русский и формируем заголовок
-	russianMonth := translateMonthToRussian(month)
-	if year > 0 {
-		result.WriteString(fmt.Sprintf("🎵 Релизы за %s %d:\n\n", russianMonth, year))
-	} else {
-		result.WriteString(fmt.Sprintf("🎵 Релизы за %s:\n\n", russianMonth))
+	// Переводим месяц на язык пользователя и формируем заголовок
+	result.WriteString(lang.T("releases.month_title", lang.MonthName(month), strconv.Itoa(year)))
+	if releaseType != "" {
+		result.WriteString(lang.T("releases.type_filter", lang.T("release_type."+releaseType.String())))
 	}
 
 	if len(releases) == 0 {
-		result.WriteString("Релизы не найдены")
+		result.WriteString(lang.T("releases.not_found"))
 		return result.String(), nil
 	}
 
 	for _, release := range releases {
-		var artistName string
-		if release.Artist != nil {
-			artistName = release.Artist.Name
-		}
-
-		line := fmt.Sprintf("%s | <b>%s</b>", release.Date, html.EscapeString(artistName))
-
-		// Добавляем название релиза
-		if release.Title != "" && release.Title != "N/A" {
-			line += fmt.Sprintf(" | %s", html.EscapeString(release.Title))
-		}
-
-		if release.MV != "" && release.MV != "N/A" {
-			// Очищаем TitleTrack
-			cleanedTitleTrack := strings.ReplaceAll(release.TitleTrack, "Title Track:", "")
-			cleanedTitleTrack = strings.TrimSpace(cleanedTitleTrack)
-
-			if cleanedTitleTrack != "" && cleanedTitleTrack != "N/A" {
-				trackName := html.EscapeString(cleanedTitleTrack)
-				line += fmt.Sprintf(" | <a href=\"%s\">%s</a>", release.MV, trackName)
-			} else {
-				// Если нет названия трека, добавляем просто ссылку
-				line += fmt.Sprintf(" | <a href=\"%s\">Link</a>", release.MV)
-			}
-		} else if release.TitleTrack != "" && release.TitleTrack != "N/A" {
-			// Если нет ссылки, но есть название трека, добавляем его
-			cleanedTitleTrack := strings.ReplaceAll(release.TitleTrack, "Title Track:", "")
-			cleanedTitleTrack = strings.TrimSpace(cleanedTitleTrack)
-			line += fmt.Sprintf(" | %s", html.EscapeString(cleanedTitleTrack))
-		}
-
-		result.WriteString(line + "\n")
+		result.WriteString(s.formatReleaseEntry(release, settings, lang))
 	}
 
 	return result.String(), nil
@@ -181,9 +176,24 @@ func (s *ReleaseService) GetReleasesForMonth(month string, femaleOnly, maleOnly
 
 // CreateOrUpdateRelease создает новый релиз или обновляет существующий
 func (s *ReleaseService) CreateOrUpdateRelease(release *model.Release) error {
+	created, err := s.CreateOrUpdateReleaseFromSource(release, "", false)
+	if err != nil {
+		return err
+	}
+	if created {
+		s.notifyNewReleases([]*model.Release{release})
+	}
+	return nil
+}
+
+// CreateOrUpdateReleaseFromSource создает или обновляет релиз, записывая изменения полей в историю.
+// soleOnDate - в текущем парсинге это единственный релиз артиста на эту дату,
+// только тогда релиз на ту же дату с другим треком считается переименованным.
+// Возвращает true, если релиз создан; уведомления подписчикам отправляет вызывающий код
+func (s *ReleaseService) CreateOrUpdateReleaseFromSource(release *model.Release, sourceURL string, soleOnDate bool) (bool, error) {
 	// Валидируем релиз
 	if err := s.utils.ValidateRelease(release); err != nil {
-		return fmt.Errorf("release validation failed: %w", err)
+		return false, fmt.Errorf("release validation failed: %w", err)
 	}
 
 	// Очищаем данные
@@ -191,10 +201,18 @@ func (s *ReleaseService) CreateOrUpdateRelease(release *model.Release) error {
 	release.AlbumName = s.utils.CleanReleaseTitle(release.AlbumName)
 	release.TitleTrack = s.utils.CleanReleaseTitle(release.TitleTrack)
 
-	// Проверяем, существует ли релиз по артисту, дате и треку
-	existingRelease, err := s.repo.GetByArtistDateAndTrack(release.ArtistID, release.Date, release.TitleTrack)
+	// Заполняем типизированные дату и момент релиза
+	if err := s.utils.FillReleaseDates(release); err != nil {
+		s.logger.Warn("Failed to fill typed release dates",
+			zap.String("date", release.Date),
+			zap.String("time_msk", release.TimeMSK),
+			zap.Error(err))
+	}
+
+	// Ищем существующий релиз: по артисту, дате и треку, либо перенесенный или переименованный
+	existingRelease, err := s.findExistingRelease(release, soleOnDate)
 	if err != nil {
-		return fmt.Errorf("failed to check for existing release: %w", err)
+		return false, fmt.Errorf("failed to check for existing release: %w", err)
 	}
 
 	if existingRelease != nil {
@@ -206,11 +224,19 @@ func (s *ReleaseService) CreateOrUpdateRelease(release *model.Release) error {
 			zap.String("old_youtube", existingRelease.MV),
 			zap.String("new_youtube", release.MV))
 
+		revisions := diffReleaseFields(existingRelease, release, sourceURL)
+
 		// Обновляем поля существующего релиза
+		existingRelease.Date = release.Date
 		existingRelease.AlbumName = release.AlbumName
 		existingRelease.TitleTrack = release.TitleTrack
 		existingRelease.MV = release.MV
+		if release.Type != "" {
+			existingRelease.Type = release.Type
+		}
 		existingRelease.TimeMSK = release.TimeMSK
+		existingRelease.ReleaseDate = release.ReleaseDate
+		existingRelease.ReleaseAt = release.ReleaseAt
 		existingRelease.UpdatedAt = time.Now()
 
 		s.logger.Info("Updated release fields",
@@ -221,7 +247,17 @@ func (s *ReleaseService) CreateOrUpdateRelease(release *model.Release) error {
 			zap.String("old_youtube", existingRelease.MV),
 			zap.String("new_youtube", release.MV))
 
-		return s.repo.Update(existingRelease)
+		if err := s.repo.Update(existingRelease); err != nil {
+			return false, err
+		}
+
+		s.saveRevisions(revisions)
+
+		// Перенос релиза переносит и напоминания подписчиков
+		if s.reminders != nil {
+			s.reminders.SyncRelease(existingRelease)
+		}
+		return false, nil
 	} else {
 		// Релиз не существует, создаем новый
 		s.logger.Info("Release not found, creating new",
@@ -231,7 +267,25 @@ func (s *ReleaseService) CreateOrUpdateRelease(release *model.Release) error {
 			zap.String("album", release.AlbumName),
 			zap.String("youtube", release.MV))
 
-		return s.repo.Create(release)
+		if err := s.repo.Create(release); err != nil {
+			return false, err
+		}
+
+		if s.reminders != nil {
+			s.reminders.SyncRelease(release)
+		}
+
+		return true, nil
+	}
+}
+
+// notifyNewReleases уведомляет подписчиков артистов о новых релизах
+func (s *ReleaseService) notifyNewReleases(releases []*model.Release) {
+	if s.subscriptions == nil {
+		return
+	}
+	for _, release := range releases {
+		s.subscriptions.NotifyNewRelease(release)
 	}
 }
 
@@ -247,6 +301,12 @@ func (s *ReleaseService) UpdateRelease(release *model.Release) error {
 	release.AlbumName = s.utils.CleanReleaseTitle(release.AlbumName)
 	release.TitleTrack = s.utils.CleanReleaseTitle(release.TitleTrack)
 
+	if err := s.utils.FillReleaseDates(release); err != nil {
+		s.logger.Warn("Failed to fill typed release dates",
+			zap.String("date", release.Date),
+			zap.Error(err))
+	}
+
 	return s.repo.Update(release)
 }
 
@@ -312,6 +372,12 @@ func (s *ReleaseService) AddRelease(release *model.Release) error {
 		}
 	}
 
+	if err := s.utils.FillReleaseDates(release); err != nil {
+		s.logger.Warn("Failed to fill typed release dates",
+			zap.String("date", release.Date),
+			zap.Error(err))
+	}
+
 	// Создаем релиз
 	err = s.repo.Create(release)
 	if err != nil {
@@ -371,30 +437,119 @@ func (s *ReleaseService) GetAllReleases() ([]model.Release, error) {
 	return releases, nil
 }
 
-// ParseReleasesForMonth парсит релизы за указанный месяц
-func (s *ReleaseService) ParseReleasesForMonth(ctx context.Context, month string) (int, error) {
-	s.logger.Info("Starting to parse releases", zap.String("month", month))
+// sourcePage страница расписания источника за месяц и ее сохраненный снимок
+type sourcePage struct {
+	source    scraper.Source
+	page      *scraper.PageSnapshot
+	snapshot  *model.PageSnapshot // nil - снимки не сохраняются
+	unchanged bool                // Страница не изменилась с прошлого разбора с тем же списком артистов
+}
 
-	artists, err := s.artistRepo.GetActive()
+// fetchSource загружает страницу расписания источника за месяц и сохраняет ее снимок.
+// Если прошлый снимок разобран с тем же списком артистов, отправляется условный запрос
+func (s *ReleaseService) fetchSource(ctx context.Context, source scraper.Source, month, year, filterHash string, force bool) (*sourcePage, error) {
+	links, err := source.MonthlyLinks(ctx, month, year)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get artists: %w", err)
+		return nil, fmt.Errorf("failed to fetch monthly links: %w", err)
 	}
 
-	// Создаем карту артистов для быстрого поиска
-	artistMap := make(map[string]bool)
-	for _, artist := range artists {
-		artistMap[strings.ToLower(artist.Name)] = true
+	if len(links) == 0 {
+		s.logger.Warn("No links found for month",
+			zap.String("source", source.Name()),
+			zap.String("month", month))
+		return nil, nil
+	}
+
+	// Парсим первую найденную ссылку
+	url := links[0]
+	s.logger.Info("Found monthly page URL",
+		zap.String("source", source.Name()),
+		zap.String("month", month),
+		zap.String("url", url))
+
+	if s.snapshots == nil {
+		page, err := s.scraper.FetchSourcePage(ctx, source, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch monthly page: %w", err)
+		}
+		return &sourcePage{source: source, page: page}, nil
+	}
+
+	previous, err := s.snapshots.Latest(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous page snapshot: %w", err)
+	}
+
+	conditional := !force && previous != nil && previous.FilterHash == filterHash
+	var previousPage *scraper.PageSnapshot
+	if conditional {
+		previousPage = &scraper.PageSnapshot{ETag: previous.ETag, LastModified: previous.LastModified}
+	}
+
+	page, err := s.scraper.FetchSourcePage(ctx, source, url, previousPage)
+	if errors.Is(err, scraper.ErrNotModified) {
+		// Тело нужно, если страница другого источника изменилась и месяц разбирается заново
+		page, err = s.snapshots.Page(previous)
+		if err != nil {
+			return nil, err
+		}
+		return &sourcePage{source: source, page: page, snapshot: previous, unchanged: true}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch monthly page: %w", err)
+	}
+
+	// Сервер может не поддерживать условные запросы, поэтому сравниваем и содержимое
+	if previous != nil && previous.ContentHash == page.ContentHash {
+		return &sourcePage{source: source, page: page, snapshot: previous, unchanged: conditional}, nil
 	}
 
-	s.logger.Info("Found artists for filtering", zap.Int("count", len(artistMap)))
+	snapshot, err := s.snapshots.Save(page, month, year)
+	if err != nil {
+		// Без снимка страница все равно разбирается
+		s.logger.Warn("Failed to save page snapshot", zap.String("url", url), zap.Error(err))
+	}
+	return &sourcePage{source: source, page: page, snapshot: snapshot}, nil
+}
+
+// parseMatcher возвращает фильтр артистов для парсинга и отпечаток списка артистов
+func (s *ReleaseService) parseMatcher() (*nearMissCollector, string, error) {
+	// Активные артисты и связанные с группами, у которых включены солисты и юниты
+	artists, err := whitelistedArtists(s.artistRepo, s.relationRepo)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get artists: %w", err)
+	}
+
+	aliases, err := s.aliasRepo.GetAll()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get artist aliases: %w", err)
+	}
+
+	s.logger.Info("Found artists for filtering",
+		zap.Int("count", len(artists)),
+		zap.Int("aliases", len(aliases)))
 
 	// Логируем список активных артистов для отладки
 	var artistNames []string
-	for artistName := range artistMap {
-		artistNames = append(artistNames, artistName)
+	for _, artist := range artists {
+		artistNames = append(artistNames, artist.Name)
 	}
 	s.logger.Info("Active artists list", zap.Strings("artists", artistNames))
 
+	// Имена из источников сопоставляются с артистами по именам и псевдонимам, похожие имена попадают в отчет
+	return newNearMissCollector(model.NewArtistMatcher(artists, aliases)), artistFilterHash(artists, aliases), nil
+}
+
+// ParseReleasesForMonth парсит релизы за указанный месяц.
+// Если страницы всех источников не изменились с прошлого парсинга, месяц пропускается; force - разобрать заново
+func (s *ReleaseService) ParseReleasesForMonth(ctx context.Context, month string, force bool) (*ParseReport, error) {
+	s.logger.Info("Starting to parse releases", zap.String("month", month), zap.Bool("force", force))
+
+	matcher, filterHash, err := s.parseMatcher()
+	if err != nil {
+		return nil, err
+	}
+
 	// Извлекаем год из строки месяца (формат: "september-2025" или "september")
 	year := time.Now().Format("2006") // По умолчанию текущий год
 	if strings.Contains(month, "-") {
@@ -405,42 +560,115 @@ func (s *ReleaseService) ParseReleasesForMonth(ctx context.Context, month string
 		}
 	}
 
-	// Сначала получаем ссылки на месячные страницы
-	months := []string{month}
-	links, err := s.scraper.FetchMonthlyLinks(ctx, months, year)
-	if err != nil {
-		return 0, fmt.Errorf("failed to fetch monthly links: %w", err)
+	// Загружаем страницы всех источников по убыванию приоритета
+	var pages []*sourcePage
+	var lastErr error
+	unchanged := 0
+	for _, source := range s.scraper.Sources() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		page, err := s.fetchSource(ctx, source, month, year, filterHash, force)
+		if err != nil {
+			lastErr = err
+			s.logger.Warn("Failed to fetch releases page from source",
+				zap.String("source", source.Name()),
+				zap.String("month", month),
+				zap.Error(err))
+			continue
+		}
+		if page != nil {
+			pages = append(pages, page)
+			if page.unchanged {
+				unchanged++
+			}
+		}
 	}
 
-	if len(links) == 0 {
+	// Неизменные страницы не разбираются, релизы по ним уже сохранены
+	if len(pages) > 0 && unchanged == len(pages) {
+		s.logger.Info("Schedule pages not modified, skipping month",
+			zap.String("month", month),
+			zap.String("year", year),
+			zap.Int("sources", len(pages)))
+		return &ParseReport{Unchanged: unchanged}, nil
+	}
+
+	// Если изменилась хотя бы одна страница, разбираются все, чтобы сохранить приоритет источников
+	var results []scraper.SourceResult
+	var parsed []*sourcePage
+	for _, page := range pages {
+		releases, err := s.scraper.ParseSnapshot(ctx, page.source, page.page, month, year, matcher)
+
+		// Релизы из разобранных блоков сохраняются, но страница не отмечается разобранной,
+		// чтобы следующий парсинг повторил блоки, на которых LLM не ответил
+		var incomplete *scraper.IncompleteParseError
+		if errors.As(err, &incomplete) {
+			s.logger.Warn("Releases page parsed partially",
+				zap.String("source", page.source.Name()),
+				zap.String("month", month),
+				zap.Int("failed_blocks", incomplete.FailedBlocks),
+				zap.Int("total_blocks", incomplete.TotalBlocks))
+			err = nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = fmt.Errorf("failed to parse monthly page: %w", err)
+			s.logger.Warn("Failed to parse releases from source",
+				zap.String("source", page.source.Name()),
+				zap.String("month", month),
+				zap.Error(err))
+			continue
+		}
+		results = append(results, scraper.SourceResult{
+			Source:   page.source,
+			URL:      page.page.URL,
+			Releases: releases,
+		})
+		if incomplete == nil {
+			parsed = append(parsed, page)
+		}
+	}
+
+	report := &ParseReport{}
+	if len(results) == 0 {
+		if lastErr != nil {
+			return nil, fmt.Errorf("failed to parse releases from all sources: %w", lastErr)
+		}
 		s.logger.Warn("No links found for month", zap.String("month", month))
-		return 0, nil
+		return report, nil
 	}
 
-	// Парсим первую найденную ссылку
-	url := links[0]
-	s.logger.Info("Found monthly page URL", zap.String("month", month), zap.String("url", url))
+	scrapedReleases := scraper.MergeReleases(results)
 
-	scrapedReleases, err := s.scraper.ParseMonthlyPage(ctx, url, month, year, artistMap)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse monthly page: %w", err)
+	sourceURLs := make(map[string]string, len(results))
+	for _, result := range results {
+		sourceURLs[result.Source.Name()] = result.URL
 	}
 
-	s.logger.Info("Parsed releases from scraper", zap.Int("count", len(scrapedReleases)))
+	s.logger.Info("Parsed releases from scraper",
+		zap.Int("sources", len(results)),
+		zap.Int("count", len(scrapedReleases)))
+
+	// Несколько релизов артиста на одну дату (пре-релиз и альбом) не считаются переименованием друг друга
+	releasesOnDate := make(map[string]int)
+	for _, scrapedRelease := range scrapedReleases {
+		if match, ok := matcher.Match(scrapedRelease.Artist); ok {
+			releasesOnDate[fmt.Sprintf("%d|%s", match.Artist.ArtistID, scrapedRelease.Date)]++
+		}
+	}
 
 	// Конвертируем и сохраняем релизы только для существующих артистов
 	savedCount := 0
+	var created []*model.Release
 	for _, scrapedRelease := range scrapedReleases {
-		artist, err := s.artistRepo.GetByName(scrapedRelease.Artist)
-		if err != nil {
-			s.logger.Warn("Failed to get artist from database",
-				zap.String("artist", scrapedRelease.Artist),
-				zap.Error(err))
-			continue
-		}
+		match, ok := matcher.Match(scrapedRelease.Artist)
 
-		// Если артист не найден в базе, пропускаем релиз
-		if artist == nil {
+		// Если артист не сопоставлен ни с именем, ни с псевдонимом, пропускаем релиз
+		if !ok {
 			s.logger.Info("Artist not found in database, skipping release",
 				zap.String("artist", scrapedRelease.Artist),
 				zap.String("track", scrapedRelease.TitleTrack),
@@ -448,8 +676,17 @@ func (s *ReleaseService) ParseReleasesForMonth(ctx context.Context, month string
 			continue
 		}
 
-		// Обновляем имя артиста если оно изменилось
-		if artist.Name != scrapedRelease.Artist {
+		artist := &match.Artist
+		if !match.Exact || match.Matched != artist.Name {
+			s.logger.Info("Artist matched by alias or similarity",
+				zap.String("scraped", scrapedRelease.Artist),
+				zap.String("artist", artist.Name),
+				zap.String("matched", match.Matched),
+				zap.Float64("score", match.Score))
+		}
+
+		// Обновляем имя артиста, если на сайте изменился только регистр
+		if artist.Name != scrapedRelease.Artist && strings.EqualFold(artist.Name, scrapedRelease.Artist) {
 			s.logger.Info("Updating artist name",
 				zap.String("old_name", artist.Name),
 				zap.String("new_name", scrapedRelease.Artist))
@@ -473,13 +710,15 @@ func (s *ReleaseService) ParseReleasesForMonth(ctx context.Context, month string
 			TitleTrack: scrapedRelease.TitleTrack,
 			AlbumName:  scrapedRelease.AlbumName,
 			MV:         scrapedRelease.MV,
+			Type:       scrapedRelease.Type,
 			Date:       scrapedRelease.Date,
 			TimeMSK:    scrapedRelease.TimeMSK,
 			IsActive:   true,
 		}
 
 		// Сохраняем релиз
-		err = s.CreateOrUpdateRelease(release)
+		soleOnDate := releasesOnDate[fmt.Sprintf("%d|%s", artist.ArtistID, scrapedRelease.Date)] == 1
+		isNew, err := s.CreateOrUpdateReleaseFromSource(release, sourceURLs[scrapedRelease.Source], soleOnDate)
 		if err != nil {
 			s.logger.Warn("Failed to save release",
 				zap.String("artist", scrapedRelease.Artist),
@@ -487,104 +726,349 @@ func (s *ReleaseService) ParseReleasesForMonth(ctx context.Context, month string
 				zap.Error(err))
 			continue
 		}
+		if isNew {
+			created = append(created, release)
+		}
 
 		savedCount++
 	}
 
+	// Уведомления отправляются после сохранения всех релизов, чтобы отправка в Telegram не задерживала парсинг
+	s.notifyNewReleases(created)
+
+	report.Parsed = len(scrapedReleases)
+	report.Saved = savedCount
+	report.NearMisses = matcher.NearMisses()
+
+	// Артисты не из списка попадают в отчет /discover
+	if s.discovery != nil {
+		discovered, err := s.discovery.Record(matcher.Unmatched())
+		if err != nil {
+			s.logger.Warn("Failed to record unmatched artists", zap.Error(err))
+		}
+		report.Discovered = discovered
+	}
+
+	// Следующий парсинг может пропустить эти страницы, если они не изменятся
+	for _, page := range parsed {
+		if page.snapshot != nil {
+			s.snapshots.MarkParsed(page.snapshot.SnapshotID, filterHash)
+		}
+	}
+
 	s.logger.Info("Completed parsing releases",
 		zap.String("month", month),
-		zap.Int("parsed", len(scrapedReleases)),
-		zap.Int("saved", savedCount))
+		zap.Int("parsed", report.Parsed),
+		zap.Int("saved", report.Saved),
+		zap.Int("near_misses", len(report.NearMisses)),
+		zap.Int("discovered", len(report.Discovered)))
 
-	return savedCount, nil
+	return report, nil
 }
 
-// GetReleasesByArtistName возвращает релизы по имени артиста (только активные)
-func (s *ReleaseService) GetReleasesByArtistName(artistName string) (string, error) {
-	// Получаем релизы по имени артиста (только активные)
-	releases, err := s.repo.GetByArtistName(artistName)
+// GetReleasesByArtistName возвращает релизы по имени артиста (только активные).
+// Имя сопоставляется с артистами по псевдонимам и сходству, при неудаче предлагаются похожие артисты.
+// Релизы солистов, юнитов и совместных проектов выводятся после релизов артиста, сгруппированные по ним
+func (s *ReleaseService) GetReleasesByArtistName(artistName string, releaseType model.ReleaseType, userID int64, lang i18n.Lang) (string, error) {
+	matcher, err := newActiveArtistMatcher(s.artistRepo, s.aliasRepo, s.relationRepo)
 	if err != nil {
-		return "", fmt.Errorf("failed to get releases for artist %s: %w", artistName, err)
+		return "", fmt.Errorf("failed to match artist %s: %w", artistName, err)
+	}
+
+	var releases []model.Release
+	var related []relatedReleases
+	var suggestions []model.ArtistMatch
+	title := artistName
+	if match, ok := matcher.Match(artistName); ok {
+		title = match.Artist.Name
+		releases, err = s.repo.GetActiveByArtist(match.Artist.ArtistID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get releases for artist %s: %w", artistName, err)
+		}
+		releases = filterReleasesByType(releases, releaseType)
+		related, err = s.getRelatedReleases(match.Artist.ArtistID, releaseType)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		suggestions = matcher.Candidates(artistName, maxNearMissCandidates)
 	}
 
 	// Логируем результат поиска
 	s.logger.Info("Search results for artist",
 		zap.String("artist", artistName),
-		zap.Int("count", len(releases)))
+		zap.String("matched", title),
+		zap.String("type", releaseType.String()),
+		zap.Int("count", len(releases)),
+		zap.Int("related", len(related)),
+		zap.Int("suggestions", len(suggestions)))
 
 	// Форматируем ответ
+	settings := s.userSettings(userID)
 	var result strings.Builder
-	result.WriteString(fmt.Sprintf("🎵 Релизы артиста %s:\n\n", artistName))
+	result.WriteString(lang.T("releases.artist_title", html.EscapeString(title)))
+	if releaseType != "" {
+		result.WriteString(lang.T("releases.type_filter", lang.T("release_type."+releaseType.String())))
+	}
 
-	if len(releases) == 0 {
-		result.WriteString("Релизы не найдены")
+	if len(releases) == 0 && len(related) == 0 {
+		result.WriteString(lang.T("releases.not_found"))
+		if len(suggestions) > 0 {
+			names := make([]string, 0, len(suggestions))
+			for _, suggestion := range suggestions {
+				names = append(names, "<code>"+html.EscapeString(suggestion.Artist.Name)+"</code>")
+			}
+			result.WriteString(lang.T("releases.did_you_mean", strings.Join(names, ", ")))
+		}
 		return result.String(), nil
 	}
 
 	for _, release := range releases {
-		var artistName string
-		if release.Artist != nil {
-			artistName = release.Artist.Name
+		result.WriteString(s.formatReleaseEntry(release, settings, lang))
+	}
+
+	for _, group := range related {
+		result.WriteString(lang.T("releases.related_title",
+			html.EscapeString(group.relation.Artist.Name), lang.T("relation."+string(group.relation.Type))))
+		for _, release := range group.releases {
+			result.WriteString(s.formatReleaseEntry(release, settings, lang))
 		}
+	}
 
-		line := fmt.Sprintf("%s | <b>%s</b>", release.Date, html.EscapeString(artistName))
+	return result.String(), nil
+}
 
-		// Добавляем название релиза
-		if release.Title != "" && release.Title != "N/A" {
-			line += fmt.Sprintf(" | %s", html.EscapeString(release.Title))
-		}
+// relatedReleases релизы солиста, юнита или совместного проекта артиста
+type relatedReleases struct {
+	relation model.ArtistRelation
+	releases []model.Release
+}
 
-		if release.MV != "" && release.MV != "N/A" {
-			// Очищаем TitleTrack
-			cleanedTitleTrack := strings.ReplaceAll(release.TitleTrack, "Title Track:", "")
-			cleanedTitleTrack = strings.TrimSpace(cleanedTitleTrack)
+// getRelatedReleases возвращает активные релизы связанных с артистом солистов, юнитов и совместных проектов
+func (s *ReleaseService) getRelatedReleases(parentID int, releaseType model.ReleaseType) ([]relatedReleases, error) {
+	relations, err := s.relationRepo.GetChildren(parentID)
+	if err != nil {
+		return nil, err
+	}
 
-			if cleanedTitleTrack != "" && cleanedTitleTrack != "N/A" {
-				trackName := html.EscapeString(cleanedTitleTrack)
-				line += fmt.Sprintf(" | <a href=\"%s\">%s</a>", release.MV, trackName)
-			} else {
-				// Если нет названия трека, добавляем просто ссылку
-				line += fmt.Sprintf(" | <a href=\"%s\">Link</a>", release.MV)
-			}
-		} else if release.TitleTrack != "" && release.TitleTrack != "N/A" {
-			// Если нет ссылки, но есть название трека, добавляем его
-			cleanedTitleTrack := strings.ReplaceAll(release.TitleTrack, "Title Track:", "")
-			cleanedTitleTrack = strings.TrimSpace(cleanedTitleTrack)
-			line += fmt.Sprintf(" | %s", html.EscapeString(cleanedTitleTrack))
+	var related []relatedReleases
+	for _, relation := range relations {
+		if relation.Artist == nil {
+			continue
 		}
+		releases, err := s.repo.GetActiveByArtist(relation.ArtistID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get releases for related artist %s: %w", relation.Artist.Name, err)
+		}
+		releases = filterReleasesByType(releases, releaseType)
+		if len(releases) > 0 {
+			related = append(related, relatedReleases{relation: relation, releases: releases})
+		}
+	}
 
-		result.WriteString(line + "\n")
+	return related, nil
+}
+
+// filterReleasesByType оставляет релизы указанного типа, пустой тип - все релизы
+func filterReleasesByType(releases []model.Release, releaseType model.ReleaseType) []model.Release {
+	if releaseType == "" {
+		return releases
 	}
 
-	return result.String(), nil
+	var filtered []model.Release
+	for _, release := range releases {
+		if release.Type == releaseType {
+			filtered = append(filtered, release)
+		}
+	}
+	return filtered
 }
 
-// translateMonthToRussian переводит английское название месяца на русский
-func translateMonthToRussian(month string) string {
-	monthMap := map[string]string{
-		"january":   "январь",
-		"february":  "февраль",
-		"march":     "март",
-		"april":     "апрель",
-		"may":       "май",
-		"june":      "июнь",
-		"july":      "июль",
-		"august":    "август",
-		"september": "сентябрь",
-		"october":   "октябрь",
-		"november":  "ноябрь",
-		"december":  "декабрь",
+// formatReleaseEntry форматирует релиз для списка: строкой или карточкой, с датой в часовом поясе пользователя
+func (s *ReleaseService) formatReleaseEntry(release model.Release, settings model.UserSettings, lang i18n.Lang) string {
+	var artistName string
+	if release.Artist != nil {
+		artistName = release.Artist.Name
+	}
+
+	date, releaseTime := s.releaseDateTime(release, settings)
+
+	trackName := strings.TrimSpace(strings.ReplaceAll(release.TitleTrack, "Title Track:", ""))
+	hasTrack := trackName != "" && trackName != "N/A"
+
+	if settings.Layout == model.LayoutVerbose {
+		var entry strings.Builder
+		entry.WriteString(fmt.Sprintf("<b>%s</b>\n", html.EscapeString(artistName)))
+		if releaseTime != "" {
+			entry.WriteString(lang.T("releases.entry_datetime", date, releaseTime))
+		} else {
+			entry.WriteString(lang.T("releases.entry_date", date))
+		}
+		if release.AlbumName != "" && release.AlbumName != "N/A" {
+			entry.WriteString(lang.T("releases.entry_album", html.EscapeString(release.AlbumName)))
+		} else if release.Title != "" && release.Title != "N/A" {
+			entry.WriteString(lang.T("releases.entry_album", html.EscapeString(release.Title)))
+		}
+		if hasTrack {
+			entry.WriteString(lang.T("releases.entry_track", html.EscapeString(trackName)))
+		}
+		if release.HasMV() {
+			entry.WriteString(fmt.Sprintf("🎬 <a href=\"%s\">MV</a>\n", html.EscapeString(release.MV)))
+		}
+		return entry.String() + "\n"
+	}
+
+	line := fmt.Sprintf("%s | <b>%s</b>", date, html.EscapeString(artistName))
+
+	// Добавляем название релиза
+	if release.Title != "" && release.Title != "N/A" {
+		line += fmt.Sprintf(" | %s", html.EscapeString(release.Title))
 	}
 
-	if russianMonth, exists := monthMap[strings.ToLower(month)]; exists {
-		return russianMonth
+	if release.HasMV() {
+		if hasTrack {
+			line += fmt.Sprintf(" | <a href=\"%s\">%s</a>", html.EscapeString(release.MV), html.EscapeString(trackName))
+		} else {
+			// Если нет названия трека, добавляем просто ссылку
+			line += fmt.Sprintf(" | <a href=\"%s\">Link</a>", html.EscapeString(release.MV))
+		}
+	} else if hasTrack {
+		// Если нет ссылки, но есть название трека, добавляем его
+		line += fmt.Sprintf(" | %s", html.EscapeString(trackName))
 	}
 
-	// Если месяц не найден, возвращаем оригинальное название
-	return month
+	return line + "\n"
+}
+
+// releaseDateTime возвращает дату и время релиза в часовом поясе пользователя.
+// Дата из источника - календарная дата KST, она остается как есть, если в поясе пользователя день тот же
+func (s *ReleaseService) releaseDateTime(release model.Release, settings model.UserSettings) (date, releaseTime string) {
+	date = release.Date
+	if release.ReleaseAt != nil {
+		releaseAt := release.ReleaseAt.In(settings.Location())
+		if release.ReleaseDate == nil || releaseAt.Format(time.DateOnly) != release.ReleaseDate.Format(time.DateOnly) {
+			date = s.utils.FormatReleaseDate(releaseAt)
+		}
+		releaseTime = releaseAt.Format("15:04 MST")
+	}
+	return date, releaseTime
 }
 
 // GetTotalReleaseCount возвращает общее количество релизов в базе данных
 func (s *ReleaseService) GetTotalReleaseCount() (int, error) {
 	return s.repo.GetTotalCount()
 }
+
+// findExistingRelease ищет сохраненный релиз, соответствующий полученному при парсинге.
+// Сначала ищется точное совпадение по артисту, дате и треку. Если его нет, то
+// единственный активный релиз артиста с тем же треком не дальше releaseRescheduleWindow считается перенесенным,
+// а единственный активный релиз артиста на ту же дату - с переименованным треком, если в парсинге
+// у артиста на эту дату тоже один релиз (soleOnDate). Иначе релиз считается новым
+func (s *ReleaseService) findExistingRelease(release *model.Release, soleOnDate bool) (*model.Release, error) {
+	existing, err := s.repo.GetByArtistDateAndTrack(release.ArtistID, release.Date, release.TitleTrack)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	if release.TitleTrack != "" {
+		sameTrack, err := s.repo.GetActiveByArtistAndTrack(release.ArtistID, release.TitleTrack)
+		if err != nil {
+			return nil, err
+		}
+		if len(sameTrack) == 1 && withinRescheduleWindow(sameTrack[0], release) {
+			s.logger.Info("Release date changed",
+				zap.Int("release_id", sameTrack[0].ReleaseID),
+				zap.String("track", release.TitleTrack),
+				zap.String("old_date", sameTrack[0].Date),
+				zap.String("new_date", release.Date))
+			return &sameTrack[0], nil
+		}
+	}
+
+	if !soleOnDate {
+		return nil, nil
+	}
+
+	sameDate, err := s.repo.GetActiveByArtistAndDate(release.ArtistID, release.Date)
+	if err != nil {
+		return nil, err
+	}
+	if len(sameDate) == 1 {
+		s.logger.Info("Release title track changed",
+			zap.Int("release_id", sameDate[0].ReleaseID),
+			zap.String("date", release.Date),
+			zap.String("old_track", sameDate[0].TitleTrack),
+			zap.String("new_track", release.TitleTrack))
+		return &sameDate[0], nil
+	}
+
+	return nil, nil
+}
+
+// releaseRescheduleWindow наибольший перенос даты, при котором релиз с тем же треком считается перенесенным,
+// а не переизданием или репакейджем
+const releaseRescheduleWindow = 14 * 24 * time.Hour
+
+// withinRescheduleWindow проверяет, что даты сохраненного и полученного релизов отличаются не больше releaseRescheduleWindow
+func withinRescheduleWindow(existing model.Release, release *model.Release) bool {
+	if existing.ReleaseDate == nil || release.ReleaseDate == nil {
+		return false
+	}
+	diff := release.ReleaseDate.Sub(*existing.ReleaseDate)
+	return diff <= releaseRescheduleWindow && diff >= -releaseRescheduleWindow
+}
+
+// diffReleaseFields возвращает изменения полей между сохраненным и новым релизом.
+// Время релиза сравнивается, только если оно указано в источнике: "N/A" означает, что времени нет, а не что оно изменилось
+func diffReleaseFields(existing, updated *model.Release, sourceURL string) []model.ReleaseRevision {
+	fields := []struct {
+		name     string
+		oldValue string
+		newValue string
+	}{
+		{model.RevisionFieldDate, existing.Date, updated.Date},
+		{model.RevisionFieldTitleTrack, existing.TitleTrack, updated.TitleTrack},
+		{model.RevisionFieldAlbumName, existing.AlbumName, updated.AlbumName},
+		{model.RevisionFieldMV, existing.MV, updated.MV},
+		{model.RevisionFieldTimeMSK, existing.TimeMSK, updated.TimeMSK},
+	}
+
+	var revisions []model.ReleaseRevision
+	for _, field := range fields {
+		if field.oldValue == field.newValue {
+			continue
+		}
+		if field.name == model.RevisionFieldTimeMSK && isEmptyValue(field.newValue) {
+			continue
+		}
+		revisions = append(revisions, model.ReleaseRevision{
+			ReleaseID: existing.ReleaseID,
+			ArtistID:  existing.ArtistID,
+			Field:     field.name,
+			OldValue:  field.oldValue,
+			NewValue:  field.newValue,
+			SourceURL: sourceURL,
+		})
+	}
+
+	return revisions
+}
+
+// saveRevisions сохраняет историю изменений релиза (ошибка не прерывает обновление)
+func (s *ReleaseService) saveRevisions(revisions []model.ReleaseRevision) {
+	if len(revisions) == 0 {
+		return
+	}
+
+	if err := s.revisionRepo.CreateBatch(revisions); err != nil {
+		s.logger.Warn("Failed to save release revisions",
+			zap.Int("release_id", revisions[0].ReleaseID),
+			zap.Int("changes", len(revisions)),
+			zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Saved release revisions",
+		zap.Int("release_id", revisions[0].ReleaseID),
+		zap.Int("changes", len(revisions)))
+}
@@ -0,0 +1,141 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"fmt"
+	"gemfactory/internal/model"
+	"html"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxChangesPerSection ограничивает количество строк в разделе сводки изменений
+const maxChangesPerSection = 30
+
+// GetChangesSummary возвращает сводку изменений релизов за последние days дней
+func (s *ReleaseService) GetChangesSummary(days int) (string, error) {
+	since := time.Now().AddDate(0, 0, -days)
+
+	revisions, err := s.revisionRepo.GetSince(since)
+	if err != nil {
+		return "", fmt.Errorf("failed to get release revisions: %w", err)
+	}
+
+	s.logger.Info("Loaded release revisions",
+		zap.Int("days", days),
+		zap.Int("count", len(revisions)))
+
+	return formatChangesSummary(revisions, days), nil
+}
+
+// formatChangesSummary группирует изменения: переносы, появившиеся MV, переименования
+func formatChangesSummary(revisions []model.ReleaseRevision, days int) string {
+	var rescheduled, newMVs, renamed, albums []string
+	otherCount := 0
+
+	for _, revision := range revisions {
+		label := revisionLabel(revision)
+
+		switch revision.Field {
+		case model.RevisionFieldDate:
+			rescheduled = append(rescheduled, fmt.Sprintf("• %s: %s → <b>%s</b>",
+				label, html.EscapeString(revision.OldValue), html.EscapeString(revision.NewValue)))
+		case model.RevisionFieldTimeMSK:
+			rescheduled = append(rescheduled, fmt.Sprintf("• %s: %s → <b>%s</b> МСК",
+				label, displayValue(revision.OldValue), displayValue(revision.NewValue)))
+		case model.RevisionFieldMV:
+			if isEmptyValue(revision.NewValue) {
+				otherCount++
+				continue
+			}
+			status := "новый MV"
+			if !isEmptyValue(revision.OldValue) {
+				status = "ссылка обновлена"
+			}
+			newMVs = append(newMVs, fmt.Sprintf("• %s: <a href=\"%s\">%s</a>",
+				label, html.EscapeString(revision.NewValue), status))
+		case model.RevisionFieldTitleTrack:
+			renamed = append(renamed, fmt.Sprintf("• %s: %s → <b>%s</b>",
+				revisionArtistName(revision), displayValue(revision.OldValue), displayValue(revision.NewValue)))
+		case model.RevisionFieldAlbumName:
+			albums = append(albums, fmt.Sprintf("• %s: %s → <b>%s</b>",
+				label, displayValue(revision.OldValue), displayValue(revision.NewValue)))
+		default:
+			otherCount++
+		}
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📝 <b>Изменения релизов за %d дн.</b>\n", days))
+
+	if len(rescheduled)+len(newMVs)+len(renamed)+len(albums) == 0 && otherCount == 0 {
+		text.WriteString("\nИзменений не найдено.")
+		return text.String()
+	}
+
+	writeChangesSection(&text, "📅 Перенесены", rescheduled)
+	writeChangesSection(&text, "🎬 MV", newMVs)
+	writeChangesSection(&text, "✏️ Переименованы треки", renamed)
+	writeChangesSection(&text, "💿 Изменены альбомы", albums)
+
+	if otherCount > 0 {
+		text.WriteString(fmt.Sprintf("\n🔄 Прочих изменений (удаленные ссылки): %d\n", otherCount))
+	}
+
+	return text.String()
+}
+
+// writeChangesSection добавляет раздел сводки, если в нем есть строки
+func writeChangesSection(text *strings.Builder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	text.WriteString(fmt.Sprintf("\n<b>%s (%d):</b>\n", title, len(lines)))
+	for i, line := range lines {
+		if i == maxChangesPerSection {
+			text.WriteString(fmt.Sprintf("… и еще %d\n", len(lines)-maxChangesPerSection))
+			break
+		}
+		text.WriteString(line)
+		text.WriteString("\n")
+	}
+}
+
+// revisionLabel возвращает подпись релиза: артист и трек
+func revisionLabel(revision model.ReleaseRevision) string {
+	artist := revisionArtistName(revision)
+	if revision.Release == nil {
+		return artist
+	}
+
+	track := strings.TrimSpace(strings.ReplaceAll(revision.Release.GetDisplayTrack(), "Title Track:", ""))
+	if isEmptyValue(track) {
+		return artist
+	}
+	return fmt.Sprintf("%s — %s", artist, html.EscapeString(track))
+}
+
+// revisionArtistName возвращает имя артиста изменения
+func revisionArtistName(revision model.ReleaseRevision) string {
+	if revision.Artist != nil {
+		return fmt.Sprintf("<b>%s</b>", html.EscapeString(revision.Artist.Name))
+	}
+	return fmt.Sprintf("артист #%d", revision.ArtistID)
+}
+
+// displayValue возвращает значение поля для отображения
+func displayValue(value string) string {
+	if isEmptyValue(value) {
+		return "—"
+	}
+	return html.EscapeString(value)
+}
+
+// isEmptyValue проверяет, что значение поля отсутствует
+func isEmptyValue(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == "" || value == "N/A"
+}
@@ -0,0 +1,126 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gemfactory/internal/external/scraper"
+	"gemfactory/internal/model"
+	"html"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// maxReplayLines ограничивает количество релизов в ответе /replay
+const maxReplayLines = 40
+
+// ErrSnapshotsDisabled снимки страниц не сохраняются
+var ErrSnapshotsDisabled = errors.New("page snapshots are disabled")
+
+// ReplayResult результат повторного разбора снимка страницы
+type ReplayResult struct {
+	Snapshot   *model.PageSnapshot
+	Releases   []scraper.Release
+	NearMisses []NearMiss
+	Unmatched  int // Строк с артистами не из списка
+	Failed     int // Блоков, которые не удалось разобрать LLM
+}
+
+// ReplaySnapshot повторно разбирает сохраненный снимок страницы без обращения к сайту источника.
+// Релизы не сохраняются; ответы LLM берутся из кэша, если блоки уже разбирались.
+// Возвращает nil, если снимок не найден
+func (s *ReleaseService) ReplaySnapshot(ctx context.Context, snapshotID int) (*ReplayResult, error) {
+	if s.snapshots == nil {
+		return nil, ErrSnapshotsDisabled
+	}
+
+	snapshot, err := s.snapshots.Get(snapshotID)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+
+	var source scraper.Source
+	for _, candidate := range s.scraper.Sources() {
+		if candidate.Name() == snapshot.Source {
+			source = candidate
+			break
+		}
+	}
+	if source == nil {
+		return nil, fmt.Errorf("source %s of snapshot %d is not registered", snapshot.Source, snapshotID)
+	}
+
+	page, err := s.snapshots.Page(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher, _, err := s.parseMatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Replaying page snapshot",
+		zap.Int("snapshot_id", snapshotID),
+		zap.String("source", snapshot.Source),
+		zap.String("url", snapshot.URL),
+		zap.String("month", snapshot.Month),
+		zap.String("year", snapshot.Year))
+
+	releases, err := s.scraper.ParseSnapshot(ctx, source, page, snapshot.Month, snapshot.Year, matcher)
+	failed := 0
+	var incomplete *scraper.IncompleteParseError
+	if errors.As(err, &incomplete) {
+		failed = incomplete.FailedBlocks
+		err = nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay snapshot %d: %w", snapshotID, err)
+	}
+
+	return &ReplayResult{
+		Snapshot:   snapshot,
+		Releases:   releases,
+		NearMisses: matcher.NearMisses(),
+		Unmatched:  len(matcher.Unmatched()),
+		Failed:     failed,
+	}, nil
+}
+
+// FormatReplay форматирует результат повторного разбора для /replay
+func (s *ReleaseService) FormatReplay(result *ReplayResult) string {
+	snapshot := result.Snapshot
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🔁 <b>Снимок #%d</b> (%s %s, %s)\n%s\n\n",
+		snapshot.SnapshotID, snapshot.Month, snapshot.Year,
+		snapshot.FetchedAt.Format("02.01.2006 15:04"), html.EscapeString(snapshot.URL)))
+	text.WriteString(fmt.Sprintf("Разобрано релизов: %d, строк с артистами не из списка: %d\n\n",
+		len(result.Releases), result.Unmatched))
+	if result.Failed > 0 {
+		text.WriteString(fmt.Sprintf("⚠️ Блоков, не разобранных LLM: %d\n\n", result.Failed))
+	}
+
+	for i, release := range result.Releases {
+		if i == maxReplayLines {
+			text.WriteString(fmt.Sprintf("… и еще %d\n", len(result.Releases)-i))
+			break
+		}
+
+		line := fmt.Sprintf("%s <b>%s</b>", release.Date, html.EscapeString(release.Artist))
+		if release.TitleTrack != "" {
+			line += " — " + html.EscapeString(release.TitleTrack)
+		}
+		if release.AlbumName != "" {
+			line += fmt.Sprintf(" (%s)", html.EscapeString(release.AlbumName))
+		}
+		if release.Type != "" {
+			line += fmt.Sprintf(" [%s]", release.Type)
+		}
+		text.WriteString(line + "\n")
+	}
+
+	return text.String()
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"gemfactory/internal/model"
+	"testing"
+	"time"
+)
+
+func TestReleaseDateTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeMSK  string
+		timezone string
+		wantDate string
+		wantTime string
+	}{
+		{"midnight KST in Seoul", "18:00", "Asia/Seoul", "05.11.25", "00:00 KST"},
+		{"midnight KST in Moscow", "18:00", "Europe/Moscow", "04.11.25", "18:00 MSK"},
+		{"midnight KST in New York", "18:00", "America/New_York", "04.11.25", "10:00 EST"},
+		{"evening KST in Seoul", "12:00", "Asia/Seoul", "05.11.25", "18:00 KST"},
+		{"evening KST in New York", "12:00", "America/New_York", "05.11.25", "04:00 EST"},
+	}
+
+	service := &ReleaseService{utils: model.NewReleaseUtils()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := time.LoadLocation(tt.timezone); err != nil {
+				t.Skipf("timezone %s is not available: %v", tt.timezone, err)
+			}
+
+			release := model.Release{Date: "05.11.25", TimeMSK: tt.timeMSK}
+			if err := service.utils.FillReleaseDates(&release); err != nil {
+				t.Fatalf("FillReleaseDates() error = %v", err)
+			}
+
+			date, releaseTime := service.releaseDateTime(release, model.UserSettings{Timezone: tt.timezone})
+			if date != tt.wantDate || releaseTime != tt.wantTime {
+				t.Errorf("releaseDateTime() = %s %s, want %s %s", date, releaseTime, tt.wantDate, tt.wantTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"html"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// reminderArmHorizon горизонт, на который заводятся таймеры напоминаний.
+// Более поздние напоминания заводятся при следующей проверке планировщика
+const reminderArmHorizon = 24 * time.Hour
+
+// armedReminder таймер заведенного напоминания
+type armedReminder struct {
+	timer    *time.Timer
+	remindAt time.Time
+}
+
+// ReminderService планирует одноразовые напоминания за N минут до релизов артистов из подписок
+type ReminderService struct {
+	repo             model.ReleaseReminderRepository
+	releaseRepo      model.ReleaseRepository
+	subscriptionRepo model.SubscriptionRepository
+	relationRepo     model.ArtistRelationRepository
+	settings         *SettingsService
+	locale           *LocaleService
+	notifier         Notifier
+	logger           *zap.Logger
+
+	mu    sync.Mutex
+	ctx   context.Context
+	armed map[int]armedReminder
+}
+
+// NewReminderService создает новый сервис напоминаний о релизах
+func NewReminderService(db *bun.DB, settingsService *SettingsService, localeService *LocaleService, logger *zap.Logger) *ReminderService {
+	return &ReminderService{
+		repo:             repository.NewReleaseReminderRepository(db, logger),
+		releaseRepo:      repository.NewReleaseRepository(db, logger),
+		subscriptionRepo: repository.NewSubscriptionRepository(db, logger),
+		relationRepo:     repository.NewArtistRelationRepository(db, logger),
+		settings:         settingsService,
+		locale:           localeService,
+		logger:           logger,
+		armed:            make(map[int]armedReminder),
+	}
+}
+
+// SetNotifier устанавливает отправителя напоминаний
+func (s *ReminderService) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// Rearm заводит таймеры ожидающих напоминаний из базы. Вызывается при запуске планировщика и периодически
+func (s *ReminderService) Rearm(ctx context.Context) error {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
+	now := time.Now()
+	if expired, err := s.repo.ExpireReleased(now); err != nil {
+		s.logger.Warn("Failed to expire reminders of released releases", zap.Error(err))
+	} else if expired > 0 {
+		s.logger.Info("Expired reminders of released releases", zap.Int("count", expired))
+	}
+
+	reminders, err := s.repo.GetPendingBefore(now.Add(reminderArmHorizon))
+	if err != nil {
+		return fmt.Errorf("failed to load pending reminders: %w", err)
+	}
+
+	for _, reminder := range reminders {
+		s.arm(reminder.ReminderID, reminder.RemindAt)
+	}
+
+	s.logger.Debug("Reminders re-armed", zap.Int("pending", len(reminders)))
+	return nil
+}
+
+// Stop останавливает заведенные таймеры. Напоминания остаются в базе и заводятся при следующем запуске
+func (s *ReminderService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for reminderID, armed := range s.armed {
+		armed.timer.Stop()
+		delete(s.armed, reminderID)
+	}
+}
+
+// SyncRelease пересчитывает напоминания подписчиков о релизе после его сохранения.
+// Без времени релиза из источника (TimeMSK "N/A") ReleaseAt не заполнен и напоминания не планируются
+func (s *ReminderService) SyncRelease(release *model.Release) {
+	if release.ReleaseAt == nil || !release.ReleaseAt.After(time.Now()) {
+		if _, err := s.repo.ExpirePendingByRelease(release.ReleaseID); err != nil {
+			s.logger.Warn("Failed to expire release reminders", zap.Int("release_id", release.ReleaseID), zap.Error(err))
+		}
+		return
+	}
+
+	subscriptions, err := s.subscriptionRepo.GetByArtist(release.ArtistID)
+	if err != nil {
+		s.logger.Error("Failed to get subscribers for reminders",
+			zap.Int("artist_id", release.ArtistID),
+			zap.Error(err))
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		minutes := s.settings.Get(subscription.UserID).ReminderMinutes
+		if minutes <= 0 {
+			continue
+		}
+		s.schedule(subscription, release, minutes)
+	}
+}
+
+// SyncUser пересчитывает напоминания пользователя после изменения настроек или подписок
+func (s *ReminderService) SyncUser(userID int64) error {
+	minutes := s.settings.Get(userID).ReminderMinutes
+	if minutes <= 0 {
+		if _, err := s.repo.ExpirePendingByUser(userID); err != nil {
+			return fmt.Errorf("failed to disable reminders for user %d: %w", userID, err)
+		}
+		return nil
+	}
+
+	subscriptions, err := s.subscriptionRepo.GetByUser(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get subscriptions for user %d: %w", userID, err)
+	}
+
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		artistIDs, err := followedArtistIDs(subscription, s.relationRepo)
+		if err != nil {
+			return err
+		}
+		for _, artistID := range artistIDs {
+			releases, err := s.releaseRepo.GetUpcomingByArtist(artistID, now)
+			if err != nil {
+				return fmt.Errorf("failed to get upcoming releases for artist %d: %w", artistID, err)
+			}
+			for i := range releases {
+				s.schedule(subscription, &releases[i], minutes)
+			}
+		}
+	}
+
+	return nil
+}
+
+// schedule сохраняет напоминание и заводит таймер, если оно скоро
+func (s *ReminderService) schedule(subscription model.Subscription, release *model.Release, minutes int) {
+	reminder := &model.ReleaseReminder{
+		UserID:    subscription.UserID,
+		ChatID:    subscription.ChatID,
+		ReleaseID: release.ReleaseID,
+		ReleaseAt: *release.ReleaseAt,
+		RemindAt:  release.ReleaseAt.Add(-time.Duration(minutes) * time.Minute),
+	}
+
+	if err := s.repo.Schedule(reminder); err != nil {
+		s.logger.Error("Failed to schedule reminder",
+			zap.Int64("user_id", subscription.UserID),
+			zap.Int("release_id", release.ReleaseID),
+			zap.Error(err))
+		return
+	}
+
+	if reminder.Status == model.ReminderStatusPending && reminder.RemindAt.Before(time.Now().Add(reminderArmHorizon)) {
+		s.arm(reminder.ReminderID, reminder.RemindAt)
+	}
+}
+
+// arm заводит таймер напоминания. Перенесенное напоминание заводится заново
+func (s *ReminderService) arm(reminderID int, remindAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// До запуска планировщика таймеры не заводятся: Rearm поднимет напоминание из базы
+	if s.ctx == nil || s.ctx.Err() != nil {
+		return
+	}
+
+	if armed, ok := s.armed[reminderID]; ok {
+		if armed.remindAt.Equal(remindAt) {
+			return
+		}
+		armed.timer.Stop()
+	}
+
+	// Пропущенные за время простоя напоминания отправляются сразу
+	delay := max(time.Until(remindAt), 0)
+	s.armed[reminderID] = armedReminder{
+		timer: time.AfterFunc(delay, func() {
+			s.fire(reminderID, remindAt)
+		}),
+		remindAt: remindAt,
+	}
+}
+
+// fire отправляет напоминание, если оно еще актуально и не было отправлено
+func (s *ReminderService) fire(reminderID int, remindAt time.Time) {
+	s.mu.Lock()
+	if armed, ok := s.armed[reminderID]; ok && armed.remindAt.Equal(remindAt) {
+		delete(s.armed, reminderID)
+	}
+	ctx := s.ctx
+	s.mu.Unlock()
+
+	if ctx == nil || ctx.Err() != nil {
+		return
+	}
+
+	reminder, err := s.repo.GetByID(reminderID)
+	if err != nil {
+		s.logger.Error("Failed to load reminder", zap.Int("reminder_id", reminderID), zap.Error(err))
+		return
+	}
+	if reminder == nil || reminder.Status != model.ReminderStatusPending || !reminder.RemindAt.Equal(remindAt) {
+		return
+	}
+
+	if !s.isRelevant(reminder) {
+		if err := s.repo.Expire(reminderID); err != nil {
+			s.logger.Warn("Failed to expire reminder", zap.Int("reminder_id", reminderID), zap.Error(err))
+		}
+		return
+	}
+
+	if s.notifier == nil {
+		s.logger.Debug("Notifier not set, skipping reminder", zap.Int("reminder_id", reminderID))
+		return
+	}
+
+	// Отметка об отправке ставится до отправки, чтобы напоминание не ушло дважды
+	claimed, err := s.repo.Claim(reminderID, remindAt)
+	if err != nil {
+		s.logger.Error("Failed to claim reminder", zap.Int("reminder_id", reminderID), zap.Error(err))
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	settings := s.settings.Get(reminder.UserID)
+	text := formatReleaseReminder(reminder, settings, s.locale.ForUser(reminder.UserID))
+	if err := s.notifier.SendMessage(reminder.ChatID, text); err != nil {
+		s.logger.Warn("Failed to send release reminder",
+			zap.Int("reminder_id", reminderID),
+			zap.Int64("chat_id", reminder.ChatID),
+			zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Sent release reminder",
+		zap.Int("reminder_id", reminderID),
+		zap.Int64("user_id", reminder.UserID),
+		zap.Int("release_id", reminder.ReleaseID))
+}
+
+// isRelevant проверяет, что релиз еще не вышел, не перенесен и пользователь подписан на артиста или его группу
+func (s *ReminderService) isRelevant(reminder *model.ReleaseReminder) bool {
+	release := reminder.Release
+	if release == nil || !release.IsActive || release.ReleaseAt == nil {
+		return false
+	}
+	if !release.ReleaseAt.Equal(reminder.ReleaseAt) || !release.ReleaseAt.After(time.Now()) {
+		return false
+	}
+
+	following, err := s.subscriptionRepo.IsFollowing(reminder.UserID, release.ArtistID)
+	if err != nil {
+		s.logger.Warn("Failed to check subscription for reminder", zap.Int("reminder_id", reminder.ReminderID), zap.Error(err))
+		return false
+	}
+	return following
+}
+
+// formatReleaseReminder форматирует напоминание о скором релизе
+func formatReleaseReminder(reminder *model.ReleaseReminder, settings model.UserSettings, lang i18n.Lang) string {
+	release := reminder.Release
+
+	var artistName string
+	if release.Artist != nil {
+		artistName = release.Artist.Name
+	}
+
+	minutesLeft := max(int(time.Until(reminder.ReleaseAt).Round(time.Minute).Minutes()), 1)
+	releaseAt := reminder.ReleaseAt.In(settings.Location()).Format("15:04 MST")
+
+	var text strings.Builder
+	text.WriteString(lang.T("reminder.title", html.EscapeString(artistName), minutesLeft, releaseAt))
+
+	if release.AlbumName != "" && release.AlbumName != "N/A" {
+		text.WriteString(lang.T("notification.album", html.EscapeString(release.AlbumName)))
+	}
+
+	titleTrack := strings.TrimSpace(strings.ReplaceAll(release.TitleTrack, "Title Track:", ""))
+	if titleTrack != "" && titleTrack != "N/A" {
+		text.WriteString(lang.T("notification.track", html.EscapeString(titleTrack)))
+	}
+
+	if release.MV != "" && release.MV != "N/A" {
+		text.WriteString(fmt.Sprintf("🎬 <a href=\"%s\">MV</a>\n", html.EscapeString(release.MV)))
+	}
+
+	return text.String()
+}
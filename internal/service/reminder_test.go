@@ -0,0 +1,113 @@
+package service
+
+import (
+	"gemfactory/internal/model"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// fakeReminderRepo хранит напоминания в памяти по правилам upsert репозитория
+type fakeReminderRepo struct {
+	model.ReleaseReminderRepository
+
+	reminders map[int64]*model.ReleaseReminder
+	expired   int
+}
+
+func (r *fakeReminderRepo) Schedule(reminder *model.ReleaseReminder) error {
+	existing, ok := r.reminders[reminder.UserID]
+	if !ok {
+		reminder.ReminderID = len(r.reminders) + 1
+		reminder.Status = model.ReminderStatusPending
+		stored := *reminder
+		r.reminders[reminder.UserID] = &stored
+		return nil
+	}
+	if !existing.ReleaseAt.Equal(reminder.ReleaseAt) || existing.Status != model.ReminderStatusSent {
+		existing.ReleaseAt = reminder.ReleaseAt
+		existing.RemindAt = reminder.RemindAt
+		existing.Status = model.ReminderStatusPending
+	}
+	*reminder = *existing
+	return nil
+}
+
+func (r *fakeReminderRepo) ExpirePendingByRelease(int) (int, error) {
+	r.expired++
+	return 0, nil
+}
+
+type fakeSubscriptionRepo struct {
+	model.SubscriptionRepository
+
+	subscriptions []model.Subscription
+}
+
+func (r *fakeSubscriptionRepo) GetByArtist(int) ([]model.Subscription, error) {
+	return r.subscriptions, nil
+}
+
+func newTestReminderService(repo *fakeReminderRepo) *ReminderService {
+	settings := &SettingsService{
+		logger: zap.NewNop(),
+		cache: map[int64]*model.UserSettings{
+			1: {UserID: 1, ReminderMinutes: 30},
+		},
+	}
+	return &ReminderService{
+		repo:             repo,
+		subscriptionRepo: &fakeSubscriptionRepo{subscriptions: []model.Subscription{{UserID: 1, ChatID: 1, ArtistID: 7}}},
+		settings:         settings,
+		logger:           zap.NewNop(),
+		armed:            make(map[int]armedReminder),
+	}
+}
+
+// parsedRelease собирает релиз так же, как его сохраняет разбор страницы
+func parsedRelease(t *testing.T, date, timeMSK string) *model.Release {
+	t.Helper()
+	release := &model.Release{ReleaseID: 42, ArtistID: 7, Date: date, TimeMSK: timeMSK, IsActive: true}
+	if err := model.NewReleaseUtils().FillReleaseDates(release); err != nil {
+		t.Fatalf("FillReleaseDates() error = %v", err)
+	}
+	return release
+}
+
+func TestSyncReleaseReparseKeepsReminder(t *testing.T) {
+	repo := &fakeReminderRepo{reminders: make(map[int64]*model.ReleaseReminder)}
+	service := newTestReminderService(repo)
+	date := time.Now().AddDate(0, 0, 10).Format("02.01.06")
+
+	service.SyncRelease(parsedRelease(t, date, "12:00"))
+	first := *repo.reminders[1]
+
+	// Повторный разбор той же страницы не меняет момент релиза
+	service.SyncRelease(parsedRelease(t, date, "12:00"))
+	second := *repo.reminders[1]
+
+	if !second.RemindAt.Equal(first.RemindAt) || !second.ReleaseAt.Equal(first.ReleaseAt) {
+		t.Errorf("reparse moved reminder from %v to %v", first.RemindAt, second.RemindAt)
+	}
+	if second.Status != model.ReminderStatusPending {
+		t.Errorf("reminder status = %s, want %s", second.Status, model.ReminderStatusPending)
+	}
+	if want := first.ReleaseAt.Add(-30 * time.Minute); !first.RemindAt.Equal(want) {
+		t.Errorf("RemindAt = %v, want %v", first.RemindAt, want)
+	}
+	if repo.expired != 0 {
+		t.Errorf("reparse expired reminders %d times, want 0", repo.expired)
+	}
+}
+
+func TestSyncReleaseWithoutTime(t *testing.T) {
+	repo := &fakeReminderRepo{reminders: make(map[int64]*model.ReleaseReminder)}
+	service := newTestReminderService(repo)
+
+	service.SyncRelease(parsedRelease(t, time.Now().AddDate(0, 0, 10).Format("02.01.06"), "N/A"))
+
+	if len(repo.reminders) != 0 {
+		t.Errorf("scheduled %d reminders for release without time, want 0", len(repo.reminders))
+	}
+}
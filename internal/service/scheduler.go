@@ -3,7 +3,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"gemfactory/internal/metrics"
 	"gemfactory/internal/model"
 	"sync"
 	"time"
@@ -16,6 +18,7 @@ import (
 type Scheduler struct {
 	taskService *TaskService
 	executors   map[model.TaskType]TaskExecutor
+	reminders   *ReminderService
 	cron        *cron.Cron
 	logger      *zap.Logger
 	mu          sync.RWMutex
@@ -46,6 +49,13 @@ func (s *Scheduler) RegisterExecutor(taskType model.TaskType, executor TaskExecu
 	s.logger.Info("Registered task executor", zap.String("task_type", taskType.String()))
 }
 
+// SetReminderService устанавливает сервис напоминаний, таймеры которого заводятся при запуске планировщика
+func (s *Scheduler) SetReminderService(reminders *ReminderService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reminders = reminders
+}
+
 // Start запускает планировщик
 func (s *Scheduler) Start() error {
 	s.mu.Lock()
@@ -57,6 +67,13 @@ func (s *Scheduler) Start() error {
 
 	s.logger.Info("Starting scheduler")
 
+	// Запуски, не завершившиеся до остановки приложения, больше не выполняются
+	if interrupted, err := s.taskService.MarkInterruptedRuns(); err != nil {
+		s.logger.Warn("Failed to mark interrupted task runs", zap.Error(err))
+	} else if interrupted > 0 {
+		s.logger.Info("Marked interrupted task runs", zap.Int("count", interrupted))
+	}
+
 	// Загружаем активные задачи и добавляем их в cron
 	tasks, err := s.taskService.GetActiveTasks()
 	if err != nil {
@@ -84,6 +101,9 @@ func (s *Scheduler) Start() error {
 
 	s.logger.Info("Scheduler started successfully", zap.Int("tasks_count", len(tasks)))
 
+	// Напоминания о релизах хранятся в базе и переживают перезапуск
+	s.rearmReminders()
+
 	// Запускаем горутину для проверки просроченных задач
 	go s.runDueTasksChecker()
 
@@ -103,6 +123,9 @@ func (s *Scheduler) Stop() {
 
 	s.cancel()
 	s.cron.Stop()
+	if s.reminders != nil {
+		s.reminders.Stop()
+	}
 	s.running = false
 
 	s.logger.Info("Scheduler stopped")
@@ -136,8 +159,11 @@ func (s *Scheduler) executeTask(task *model.Task, executor TaskExecutor) {
 
 	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Minute)
 	defer cancel()
+
+	start := time.Now()
 	defer func() {
 		if r := recover(); r != nil {
+			metrics.ObserveTaskRun(task.Name, metrics.StatusPanic, time.Since(start))
 			s.logger.Error("Panic in scheduled task execution",
 				zap.String("task_name", task.Name),
 				zap.Any("panic", r))
@@ -145,6 +171,11 @@ func (s *Scheduler) executeTask(task *model.Task, executor TaskExecutor) {
 	}()
 
 	err := s.taskService.ExecuteTask(ctx, task, executor)
+	status := metrics.Status(err)
+	if errors.Is(err, ErrTaskPanicked) {
+		status = metrics.StatusPanic
+	}
+	metrics.ObserveTaskRun(task.Name, status, time.Since(start))
 	if err != nil {
 		s.logger.Error("Scheduled task execution failed",
 			zap.String("task_name", task.Name),
@@ -165,10 +196,22 @@ func (s *Scheduler) runDueTasksChecker() {
 			return
 		case <-ticker.C:
 			s.checkAndExecuteDueTasks()
+			s.rearmReminders()
 		}
 	}
 }
 
+// rearmReminders заводит таймеры напоминаний, наступающих в ближайшее время
+func (s *Scheduler) rearmReminders() {
+	if s.reminders == nil {
+		return
+	}
+
+	if err := s.reminders.Rearm(s.ctx); err != nil {
+		s.logger.Error("Failed to re-arm release reminders", zap.Error(err))
+	}
+}
+
 // checkAndExecuteDueTasks проверяет и выполняет просроченные задачи
 func (s *Scheduler) checkAndExecuteDueTasks() {
 	tasks, err := s.taskService.GetDueTasks()
@@ -284,6 +327,10 @@ func (e *UpdatePlaylistTaskExecutor) Execute(ctx context.Context, task *model.Ta
 		return fmt.Errorf("failed to update playlist: %w", err)
 	}
 
+	if tracks, err := e.playlistService.GetPlaylistTracks(); err == nil {
+		SetTaskRunResult(ctx, "tracks", len(tracks))
+	}
+
 	e.logger.Info("Playlist update task completed successfully",
 		zap.String("task_name", task.Name),
 		zap.String("task_type", string(task.TaskType)))
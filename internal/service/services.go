@@ -3,6 +3,7 @@ package service
 
 import (
 	"gemfactory/internal/config"
+	"gemfactory/internal/external/llm"
 	"gemfactory/internal/external/scraper"
 	"gemfactory/internal/external/spotify"
 	"gemfactory/internal/model"
@@ -18,10 +19,21 @@ type Services struct {
 	Release       *ReleaseService
 	Homework      *HomeworkService
 	Playlist      *PlaylistService
+	Subscription  *SubscriptionService
 	Config        *ConfigService
 	ConfigWatcher *ConfigWatcher
 	Task          *TaskService
 	Scheduler     *Scheduler
+	Access        *AccessService
+	Audit         *AuditService
+	Calendar      *CalendarService
+	Locale        *LocaleService
+	Settings      *SettingsService
+	Digest        *DigestService
+	Reminder      *ReminderService
+	ChatSettings  *ChatSettingsService
+	Discovery     *DiscoveryService
+	Snapshot      *SnapshotService
 }
 
 // NewServices создает все сервисы
@@ -31,13 +43,35 @@ func NewServices(db *storage.Postgres, cfg *config.Config, logger *zap.Logger) *
 	configLoader.LoadConfigFromDB(cfg)
 
 	spotifyClient := NewSpotifyClient(cfg, logger)
-	scraperClient := NewScraperClient(cfg, logger)
+	scraperClient := NewScraperClient(cfg, NewLLMResponseCache(db.GetDB(), logger), logger)
 	playlistService := NewPlaylistServiceWithClient(db.GetDB(), spotifyClient, cfg.PlaylistURL, logger)
 
 	coreServices := NewCoreServices(db, logger)
 	coreServices.Release = NewReleaseService(db.GetDB(), scraperClient, logger)
 	coreServices.Homework = NewHomeworkService(db.GetDB(), playlistService, coreServices.Task, logger)
 
+	localeService := NewLocaleService(db.GetDB(), logger)
+	subscriptionService := NewSubscriptionService(db.GetDB(), logger)
+	subscriptionService.SetLocale(localeService)
+	coreServices.Release.SetSubscriptionService(subscriptionService)
+
+	settingsService := NewSettingsService(db.GetDB(), logger)
+	coreServices.Release.SetSettingsService(settingsService)
+	chatSettingsService := NewChatSettingsService(db.GetDB(), logger)
+	coreServices.Digest = NewDigestService(coreServices.Release, settingsService, localeService, cfg, logger)
+	coreServices.Digest.SetChatSettingsService(chatSettingsService)
+
+	reminderService := NewReminderService(db.GetDB(), settingsService, localeService, logger)
+	coreServices.Release.SetReminderService(reminderService)
+	subscriptionService.SetReminderService(reminderService)
+	coreServices.Scheduler.SetReminderService(reminderService)
+
+	discoveryService := NewDiscoveryService(db.GetDB(), coreServices.Artist, logger)
+	coreServices.Release.SetDiscoveryService(discoveryService)
+
+	snapshotService := NewSnapshotService(db.GetDB(), logger)
+	coreServices.Release.SetSnapshotService(snapshotService)
+
 	RegisterTaskExecutors(coreServices, configService, playlistService, logger)
 
 	configWatcher := NewConfigWatcher(configService, coreServices.Task, coreServices.Scheduler, logger)
@@ -47,10 +81,21 @@ func NewServices(db *storage.Postgres, cfg *config.Config, logger *zap.Logger) *
 		Release:       coreServices.Release,
 		Homework:      coreServices.Homework,
 		Playlist:      playlistService,
+		Subscription:  subscriptionService,
 		Config:        configService,
 		ConfigWatcher: configWatcher,
 		Task:          coreServices.Task,
 		Scheduler:     coreServices.Scheduler,
+		Access:        NewAccessService(db.GetDB(), cfg, logger),
+		Audit:         NewAuditService(db.GetDB(), logger),
+		Calendar:      NewCalendarService(db.GetDB(), cfg, logger),
+		Locale:        localeService,
+		Settings:      settingsService,
+		Digest:        coreServices.Digest,
+		Reminder:      reminderService,
+		ChatSettings:  chatSettingsService,
+		Discovery:     discoveryService,
+		Snapshot:      snapshotService,
 	}
 }
 
@@ -75,8 +120,8 @@ func NewSpotifyClient(cfg *config.Config, logger *zap.Logger) *spotify.Client {
 	return client
 }
 
-// NewScraperClient создает скрейпер
-func NewScraperClient(cfg *config.Config, logger *zap.Logger) scraper.Fetcher {
+// NewScraperClient создает скрейпер с кэшем ответов LLM
+func NewScraperClient(cfg *config.Config, cache llm.ResponseCache, logger *zap.Logger) scraper.Fetcher {
 	scraperConfig := scraper.Config{
 		HTTPClientConfig: scraper.HTTPClientConfig{
 			MaxIdleConns:          cfg.ScraperConfig.HTTPClientConfig.MaxIdleConns,
@@ -94,13 +139,22 @@ func NewScraperClient(cfg *config.Config, logger *zap.Logger) scraper.Fetcher {
 		},
 		RequestDelay: cfg.ScraperConfig.RequestDelay,
 		LLMConfig: scraper.LLMConfig{
-			BaseURL: cfg.LLMConfig.BaseURL,
-			APIKey:  cfg.LLMConfig.APIKey,
-			Timeout: cfg.LLMConfig.Timeout,
-			Delay:   cfg.LLMConfig.Delay,
+			Provider:    cfg.LLMConfig.Provider,
+			BaseURL:     cfg.LLMConfig.BaseURL,
+			APIKey:      cfg.LLMConfig.APIKey,
+			Model:       cfg.LLMConfig.Model,
+			Temperature: cfg.LLMConfig.Temperature,
+			TopP:        cfg.LLMConfig.TopP,
+			MaxTokens:   cfg.LLMConfig.MaxTokens,
+			Timeout:     cfg.LLMConfig.Timeout,
+			Delay:       cfg.LLMConfig.Delay,
 		},
 	}
-	return scraper.NewFetcher(scraperConfig, logger)
+	llmClient := scraper.NewLLMClient(scraperConfig.LLMConfig, logger)
+	if cache != nil {
+		llmClient.SetCache(cache)
+	}
+	return scraper.NewFetcherWithLLMClient(scraperConfig, logger, llmClient)
 }
 
 // NewPlaylistServiceWithClient создает сервис плейлиста с клиентом
@@ -117,6 +171,7 @@ type CoreServices struct {
 	Artist    *ArtistService
 	Release   *ReleaseService
 	Homework  *HomeworkService
+	Digest    *DigestService
 	Task      *TaskService
 	Scheduler *Scheduler
 }
@@ -139,6 +194,11 @@ func RegisterTaskExecutors(coreServices *CoreServices, configService *ConfigServ
 	homeworkResetExecutor := NewHomeworkResetTaskExecutor(coreServices.Homework, configService, logger)
 	coreServices.Scheduler.RegisterExecutor(model.TaskTypeHomeworkReset, homeworkResetExecutor)
 
+	if coreServices.Digest != nil {
+		digestExecutor := NewDigestTaskExecutor(coreServices.Digest, logger)
+		coreServices.Scheduler.RegisterExecutor(model.TaskTypeDigest, digestExecutor)
+	}
+
 	if playlistService != nil {
 		updatePlaylistExecutor := NewUpdatePlaylistTaskExecutor(playlistService, logger)
 		coreServices.Scheduler.RegisterExecutor(model.TaskTypeUpdatePlaylist, updatePlaylistExecutor)
@@ -0,0 +1,182 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"html"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidTimezone возвращается, если часовой пояс не найден в базе IANA
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+// SettingsTimezones часовые пояса, предлагаемые в меню /settings
+var SettingsTimezones = []string{
+	"Europe/Moscow",
+	"Asia/Seoul",
+	"UTC",
+	"Europe/London",
+	"Europe/Berlin",
+	"Asia/Almaty",
+	"Asia/Tokyo",
+	"America/New_York",
+	"America/Los_Angeles",
+}
+
+// SettingsService управляет настройками отображения релизов пользователей
+type SettingsService struct {
+	repo   model.UserSettingsRepository
+	logger *zap.Logger
+
+	mu    sync.RWMutex
+	cache map[int64]*model.UserSettings
+}
+
+// NewSettingsService создает новый сервис пользовательских настроек
+func NewSettingsService(db *bun.DB, logger *zap.Logger) *SettingsService {
+	return &SettingsService{
+		repo:   repository.NewUserSettingsRepository(db, logger),
+		logger: logger,
+		cache:  make(map[int64]*model.UserSettings),
+	}
+}
+
+// Get возвращает копию настроек пользователя. Если настройки не заданы или недоступны, возвращает значения по умолчанию
+func (s *SettingsService) Get(userID int64) model.UserSettings {
+	s.mu.RLock()
+	settings, ok := s.cache[userID]
+	s.mu.RUnlock()
+	if ok {
+		return *settings
+	}
+
+	settings, err := s.repo.GetByUserID(userID)
+	if err != nil {
+		// Не кэшируем ошибку, чтобы повторить попытку при следующем запросе
+		s.logger.Warn("Failed to load user settings", zap.Int64("user_id", userID), zap.Error(err))
+		return *model.DefaultUserSettings(userID)
+	}
+	if settings == nil {
+		settings = model.DefaultUserSettings(userID)
+	}
+
+	s.mu.Lock()
+	s.cache[userID] = settings
+	s.mu.Unlock()
+	return *settings
+}
+
+// DefaultGenderFilter возвращает фильтр /month по умолчанию в виде флагов -f/-m
+func (s *SettingsService) DefaultGenderFilter(userID int64) (femaleOnly, maleOnly bool) {
+	settings := s.Get(userID)
+	return settings.GenderFilter == model.GenderFemale, settings.GenderFilter == model.GenderMale
+}
+
+// SetTimezone сохраняет часовой пояс пользователя
+func (s *SettingsService) SetTimezone(userID int64, timezone string) error {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" || timezone == "Local" {
+		return fmt.Errorf("%w: %s", ErrInvalidTimezone, timezone)
+	}
+
+	return s.update(userID, func(settings *model.UserSettings) {
+		settings.Timezone = loc.String()
+	})
+}
+
+// SetGenderFilter сохраняет фильтр /month по умолчанию, пустое значение отключает фильтр
+func (s *SettingsService) SetGenderFilter(userID int64, gender model.Gender) error {
+	if gender != "" && gender != model.GenderFemale && gender != model.GenderMale {
+		return fmt.Errorf("invalid gender filter: %s", gender)
+	}
+
+	return s.update(userID, func(settings *model.UserSettings) {
+		settings.GenderFilter = gender
+	})
+}
+
+// SetLayout сохраняет формат вывода релизов
+func (s *SettingsService) SetLayout(userID int64, layout model.Layout) error {
+	if !layout.IsValid() {
+		return fmt.Errorf("invalid layout: %s", layout)
+	}
+
+	return s.update(userID, func(settings *model.UserSettings) {
+		settings.Layout = layout
+	})
+}
+
+// SetDigest включает или отключает рассылку дайджестов релизов пользователю
+func (s *SettingsService) SetDigest(userID int64, enabled bool) error {
+	return s.update(userID, func(settings *model.UserSettings) {
+		settings.Digest = enabled
+	})
+}
+
+// SetReminderMinutes сохраняет, за сколько минут напоминать о релизах подписок. 0 отключает напоминания
+func (s *SettingsService) SetReminderMinutes(userID int64, minutes int) error {
+	if minutes < 0 || minutes > model.MaxReminderMinutes {
+		return fmt.Errorf("reminder minutes must be between 0 and %d, got %d", model.MaxReminderMinutes, minutes)
+	}
+
+	return s.update(userID, func(settings *model.UserSettings) {
+		settings.ReminderMinutes = minutes
+	})
+}
+
+// GetDigestSubscribers возвращает настройки пользователей, подписанных на дайджесты
+func (s *SettingsService) GetDigestSubscribers() ([]model.UserSettings, error) {
+	subscribers, err := s.repo.GetDigestSubscribers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest subscribers: %w", err)
+	}
+	return subscribers, nil
+}
+
+// FormatSettings форматирует текущие настройки пользователя для меню /settings
+func (s *SettingsService) FormatSettings(settings model.UserSettings, lang i18n.Lang) string {
+	gender := lang.T("settings.gender_all")
+	switch settings.GenderFilter {
+	case model.GenderFemale:
+		gender = lang.T("settings.gender_female")
+	case model.GenderMale:
+		gender = lang.T("settings.gender_male")
+	}
+
+	layout := lang.T("settings.layout_compact")
+	if settings.Layout == model.LayoutVerbose {
+		layout = lang.T("settings.layout_verbose")
+	}
+
+	now := time.Now().In(settings.Location()).Format("15:04 MST")
+	return lang.T("settings.text", html.EscapeString(settings.Timezone), now, gender, layout)
+}
+
+// update изменяет и сохраняет настройки пользователя
+func (s *SettingsService) update(userID int64, apply func(settings *model.UserSettings)) error {
+	settings := s.Get(userID)
+	apply(&settings)
+
+	if err := s.repo.Upsert(&settings); err != nil {
+		return fmt.Errorf("failed to update settings for user %d: %w", userID, err)
+	}
+
+	s.mu.Lock()
+	s.cache[userID] = &settings
+	s.mu.Unlock()
+
+	s.logger.Info("User settings changed",
+		zap.Int64("user_id", userID),
+		zap.String("timezone", settings.Timezone),
+		zap.String("gender_filter", settings.GenderFilter.String()),
+		zap.String("layout", string(settings.Layout)))
+	return nil
+}
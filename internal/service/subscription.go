@@ -0,0 +1,262 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"fmt"
+	"gemfactory/internal/i18n"
+	"gemfactory/internal/model"
+	"gemfactory/internal/storage/repository"
+	"html"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// SubscriptionService содержит бизнес-логику для подписок на артистов
+type SubscriptionService struct {
+	repo       model.SubscriptionRepository
+	artistRepo model.ArtistRepository
+	notifier   Notifier
+	locale     *LocaleService
+	reminders  *ReminderService
+	logger     *zap.Logger
+}
+
+// NewSubscriptionService создает новый сервис подписок
+func NewSubscriptionService(db *bun.DB, logger *zap.Logger) *SubscriptionService {
+	return &SubscriptionService{
+		repo:       repository.NewSubscriptionRepository(db, logger),
+		artistRepo: repository.NewArtistRepository(db, logger),
+		logger:     logger,
+	}
+}
+
+// SetNotifier устанавливает отправителя уведомлений
+func (s *SubscriptionService) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// SetLocale устанавливает сервис языка для уведомлений подписчикам
+func (s *SubscriptionService) SetLocale(locale *LocaleService) {
+	s.locale = locale
+}
+
+// SetReminderService устанавливает сервис напоминаний о релизах артистов из подписок
+func (s *SubscriptionService) SetReminderService(reminders *ReminderService) {
+	s.reminders = reminders
+}
+
+// Subscribe подписывает пользователя на артиста, с includeRelated - и на его солистов, юниты и совместные проекты.
+// Возвращает артиста и признак новой или измененной подписки
+func (s *SubscriptionService) Subscribe(userID, chatID int64, artistName string, includeRelated bool) (*model.Artist, bool, error) {
+	artist, err := s.artistRepo.GetByName(artistName)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get artist %s: %w", artistName, err)
+	}
+	if artist == nil {
+		return nil, false, nil
+	}
+
+	existing, err := s.repo.GetByUserAndArtist(userID, artist.ArtistID)
+	if err != nil {
+		return artist, false, fmt.Errorf("failed to check subscription: %w", err)
+	}
+
+	err = s.repo.Create(&model.Subscription{
+		UserID:         userID,
+		ChatID:         chatID,
+		ArtistID:       artist.ArtistID,
+		IncludeRelated: includeRelated,
+	})
+	if err != nil {
+		return artist, false, err
+	}
+
+	s.logger.Info("User subscribed to artist",
+		zap.Int64("user_id", userID),
+		zap.String("artist", artist.Name),
+		zap.Bool("include_related", includeRelated))
+
+	changed := existing == nil || existing.IncludeRelated != includeRelated
+	if changed && s.reminders != nil {
+		if err := s.reminders.SyncUser(userID); err != nil {
+			s.logger.Warn("Failed to schedule reminders for new subscription",
+				zap.Int64("user_id", userID),
+				zap.Error(err))
+		}
+	}
+
+	return artist, changed, nil
+}
+
+// followedArtistIDs возвращает артистов подписки: самого артиста и, с IncludeRelated,
+// его солистов, юниты и совместные проекты
+func followedArtistIDs(subscription model.Subscription, relationRepo model.ArtistRelationRepository) ([]int, error) {
+	artistIDs := []int{subscription.ArtistID}
+	if !subscription.IncludeRelated {
+		return artistIDs, nil
+	}
+
+	relations, err := relationRepo.GetChildren(subscription.ArtistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get related artists for artist %d: %w", subscription.ArtistID, err)
+	}
+	for _, relation := range relations {
+		artistIDs = append(artistIDs, relation.ArtistID)
+	}
+
+	return artistIDs, nil
+}
+
+// Unsubscribe отписывает пользователя от артиста по имени
+func (s *SubscriptionService) Unsubscribe(userID int64, artistName string) (*model.Artist, bool, error) {
+	artist, err := s.artistRepo.GetByName(artistName)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get artist %s: %w", artistName, err)
+	}
+	if artist == nil {
+		return nil, false, nil
+	}
+
+	removed, err := s.UnsubscribeByArtistID(userID, artist.ArtistID)
+	return artist, removed, err
+}
+
+// UnsubscribeByArtistID отписывает пользователя от артиста по ID
+func (s *SubscriptionService) UnsubscribeByArtistID(userID int64, artistID int) (bool, error) {
+	existing, err := s.repo.GetByUserAndArtist(userID, artistID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check subscription: %w", err)
+	}
+	if existing == nil {
+		return false, nil
+	}
+
+	if err := s.repo.Delete(userID, artistID); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("User unsubscribed from artist",
+		zap.Int64("user_id", userID),
+		zap.Int("artist_id", artistID))
+
+	return true, nil
+}
+
+// GetUserSubscriptions возвращает подписки пользователя
+func (s *SubscriptionService) GetUserSubscriptions(userID int64) ([]model.Subscription, error) {
+	subscriptions, err := s.repo.GetByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions for user %d: %w", userID, err)
+	}
+	return subscriptions, nil
+}
+
+// FormatSubscriptions форматирует список подписок пользователя
+func (s *SubscriptionService) FormatSubscriptions(subscriptions []model.Subscription, lang i18n.Lang) string {
+	if len(subscriptions) == 0 {
+		return lang.T("subscriptions.empty")
+	}
+
+	var text strings.Builder
+	text.WriteString(lang.T("subscriptions.title"))
+	for _, subscription := range subscriptions {
+		if subscription.Artist == nil {
+			continue
+		}
+		if subscription.IncludeRelated {
+			text.WriteString(fmt.Sprintf("• <b>%s</b>%s\n", html.EscapeString(subscription.Artist.Name), lang.T("subscriptions.with_related")))
+			continue
+		}
+		text.WriteString(fmt.Sprintf("• <b>%s</b>\n", html.EscapeString(subscription.Artist.Name)))
+	}
+	text.WriteString(lang.T("subscriptions.hint"))
+
+	return text.String()
+}
+
+// NotifyNewRelease рассылает уведомление о новом релизе подписчикам артиста
+func (s *SubscriptionService) NotifyNewRelease(release *model.Release) {
+	if s.notifier == nil {
+		s.logger.Debug("Notifier not set, skipping release notification",
+			zap.Int("artist_id", release.ArtistID))
+		return
+	}
+
+	subscriptions, err := s.repo.GetByArtist(release.ArtistID)
+	if err != nil {
+		s.logger.Error("Failed to get subscribers for artist",
+			zap.Int("artist_id", release.ArtistID),
+			zap.Error(err))
+		return
+	}
+
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	artist := release.Artist
+	if artist == nil {
+		artist, err = s.artistRepo.GetByID(release.ArtistID)
+		if err != nil || artist == nil {
+			s.logger.Error("Failed to get artist for notification",
+				zap.Int("artist_id", release.ArtistID),
+				zap.Error(err))
+			return
+		}
+	}
+
+	// Уведомление форматируется один раз для каждого языка подписчиков
+	texts := make(map[i18n.Lang]string)
+
+	sentCount := 0
+	for _, subscription := range subscriptions {
+		lang := i18n.Default
+		if s.locale != nil {
+			lang = s.locale.ForUser(subscription.UserID)
+		}
+		text, ok := texts[lang]
+		if !ok {
+			text = formatReleaseNotification(artist, release, lang)
+			texts[lang] = text
+		}
+
+		if err := s.notifier.SendMessage(subscription.ChatID, text); err != nil {
+			s.logger.Warn("Failed to send release notification",
+				zap.Int64("user_id", subscription.UserID),
+				zap.Int64("chat_id", subscription.ChatID),
+				zap.Error(err))
+			continue
+		}
+		sentCount++
+	}
+
+	s.logger.Info("Sent release notifications",
+		zap.String("artist", artist.Name),
+		zap.String("date", release.Date),
+		zap.Int("subscribers", len(subscriptions)),
+		zap.Int("sent", sentCount))
+}
+
+// formatReleaseNotification форматирует уведомление о новом релизе
+func formatReleaseNotification(artist *model.Artist, release *model.Release, lang i18n.Lang) string {
+	var text strings.Builder
+	text.WriteString(lang.T("notification.title", html.EscapeString(artist.Name)))
+	text.WriteString(lang.T("notification.date", release.Date))
+
+	if release.AlbumName != "" && release.AlbumName != "N/A" {
+		text.WriteString(lang.T("notification.album", html.EscapeString(release.AlbumName)))
+	}
+
+	titleTrack := strings.TrimSpace(strings.ReplaceAll(release.TitleTrack, "Title Track:", ""))
+	if titleTrack != "" && titleTrack != "N/A" {
+		text.WriteString(lang.T("notification.track", html.EscapeString(titleTrack)))
+	}
+
+	if release.MV != "" && release.MV != "N/A" {
+		text.WriteString(fmt.Sprintf("🎬 <a href=\"%s\">MV</a>\n", html.EscapeString(release.MV)))
+	}
+
+	return text.String()
+}
@@ -14,15 +14,17 @@ import (
 
 // TaskService содержит бизнес-логику для работы с задачами
 type TaskService struct {
-	repo   model.TaskRepository
-	logger *zap.Logger
+	repo    model.TaskRepository
+	runRepo model.TaskRunRepository
+	logger  *zap.Logger
 }
 
 // NewTaskService создает новый сервис задач
 func NewTaskService(db *bun.DB, logger *zap.Logger) *TaskService {
 	return &TaskService{
-		repo:   repository.NewTaskRepository(db, logger),
-		logger: logger,
+		repo:    repository.NewTaskRepository(db, logger),
+		runRepo: repository.NewTaskRunRepository(db, logger),
+		logger:  logger,
 	}
 }
 
@@ -90,10 +92,15 @@ func (s *TaskService) ExecuteTask(ctx context.Context, task *model.Task, executo
 		zap.String("task_name", task.Name),
 		zap.String("task_type", task.TaskType.String()))
 
+	run := s.startRun(task)
+	ctx, result := withTaskRunResult(ctx)
+
 	startTime := time.Now()
-	err := executor.Execute(ctx, task)
+	err := executeSafely(ctx, task, executor)
 	duration := time.Since(startTime)
 
+	s.finishRun(task, run, duration, result.snapshot(), err)
+
 	success := err == nil
 	updateErr := s.UpdateRunStats(task.TaskID, success, err)
 	if updateErr != nil {
@@ -142,7 +149,27 @@ func (e *ParseReleaseTaskExecutor) Execute(ctx context.Context, task *model.Task
 		return fmt.Errorf("failed to get months to parse: %w", err)
 	}
 
+	SetTaskRunResult(ctx, "months", months)
+
 	totalSaved := 0
+	unchangedMonths := 0
+	var failedMonths []string
+	var nearMisses []string
+	discovered := make(map[string]bool)
+	defer func() {
+		SetTaskRunResult(ctx, "releases_saved", totalSaved)
+		SetTaskRunResult(ctx, "months_failed", failedMonths)
+		if unchangedMonths > 0 {
+			SetTaskRunResult(ctx, "months_unchanged", unchangedMonths)
+		}
+		if len(nearMisses) > 0 {
+			SetTaskRunResult(ctx, "near_misses", nearMisses)
+		}
+		if len(discovered) > 0 {
+			SetTaskRunResult(ctx, "discovered_artists", len(discovered))
+		}
+	}()
+
 	for i, month := range months {
 		e.logger.Info("Parsing releases for month",
 			zap.String("task_name", task.Name),
@@ -150,18 +177,29 @@ func (e *ParseReleaseTaskExecutor) Execute(ctx context.Context, task *model.Task
 			zap.Int("month_index", i+1),
 			zap.Int("total_months", len(months)))
 
-		count, err := e.releaseService.ParseReleasesForMonth(ctx, month)
+		report, err := e.releaseService.ParseReleasesForMonth(ctx, month, false)
 		if err != nil {
 			e.logger.Error("Failed to parse releases for month",
 				zap.String("month", month),
 				zap.Error(err))
+			failedMonths = append(failedMonths, month)
 			continue
 		}
 
-		totalSaved += count
+		totalSaved += report.Saved
+		if report.Unchanged > 0 && report.Parsed == 0 {
+			unchangedMonths++
+		}
+		for _, nearMiss := range report.NearMisses {
+			nearMisses = append(nearMisses, fmt.Sprintf("%s ~ %s", nearMiss.Name, nearMiss.Candidates[0].Artist.Name))
+		}
+		for _, name := range report.Discovered {
+			discovered[model.NormalizeArtistName(name)] = true
+		}
 		e.logger.Info("Parsed releases for month",
 			zap.String("month", month),
-			zap.Int("count", count))
+			zap.Int("count", report.Saved),
+			zap.Int("near_misses", len(report.NearMisses)))
 
 		// Добавляем паузу между месяцами (кроме последнего)
 		if i < len(months)-1 {
@@ -283,3 +321,42 @@ func (e *HomeworkResetTaskExecutor) Execute(ctx context.Context, task *model.Tas
 	e.logger.Info("Homework reset task completed successfully")
 	return nil
 }
+
+// DigestTaskExecutor выполняет задачи рассылки дайджестов релизов
+type DigestTaskExecutor struct {
+	digestService *DigestService
+	logger        *zap.Logger
+}
+
+// NewDigestTaskExecutor создает новый исполнитель задач рассылки дайджестов
+func NewDigestTaskExecutor(digestService *DigestService, logger *zap.Logger) *DigestTaskExecutor {
+	return &DigestTaskExecutor{
+		digestService: digestService,
+		logger:        logger,
+	}
+}
+
+// Execute выполняет задачу рассылки дайджеста
+func (e *DigestTaskExecutor) Execute(ctx context.Context, task *model.Task) error {
+	cfg, err := DigestConfigFromTask(task)
+	if err != nil {
+		return fmt.Errorf("invalid digest configuration: %w", err)
+	}
+
+	result, err := e.digestService.Send(ctx, cfg)
+	SetTaskRunResult(ctx, "releases", result.Releases)
+	SetTaskRunResult(ctx, "users_sent", result.UsersSent)
+	SetTaskRunResult(ctx, "chats_sent", result.ChatsSent)
+	SetTaskRunResult(ctx, "failed", result.Failed)
+	if err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+
+	e.logger.Info("Digest task completed",
+		zap.String("task_name", task.Name),
+		zap.Int("releases", result.Releases),
+		zap.Int("users_sent", result.UsersSent),
+		zap.Int("chats_sent", result.ChatsSent),
+		zap.Int("failed", result.Failed))
+	return nil
+}
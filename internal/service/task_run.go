@@ -0,0 +1,167 @@
+// Package service содержит бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gemfactory/internal/model"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultTaskRunRetentionDays срок хранения истории запусков, если в конфигурации задачи
+// не указан run_retention_days
+const defaultTaskRunRetentionDays = 90
+
+// ErrTaskPanicked исполнитель задачи завершился паникой
+var ErrTaskPanicked = errors.New("task panicked")
+
+// taskRunResultKey ключ контекста с результатом текущего запуска
+type taskRunResultKey struct{}
+
+// taskRunResult собирает данные о результате запуска, которые сообщает исполнитель
+type taskRunResult struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// withTaskRunResult добавляет в контекст хранилище результата запуска
+func withTaskRunResult(ctx context.Context) (context.Context, *taskRunResult) {
+	result := &taskRunResult{values: make(map[string]interface{})}
+	return context.WithValue(ctx, taskRunResultKey{}, result), result
+}
+
+// snapshot возвращает копию собранных данных или nil, если данных нет
+func (r *taskRunResult) snapshot() map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.values) == 0 {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(r.values))
+	for key, value := range r.values {
+		values[key] = value
+	}
+	return values
+}
+
+// SetTaskRunResult сохраняет значение в результат текущего запуска задачи.
+// Вне запуска через TaskService.ExecuteTask ничего не делает
+func SetTaskRunResult(ctx context.Context, key string, value interface{}) {
+	result, ok := ctx.Value(taskRunResultKey{}).(*taskRunResult)
+	if !ok {
+		return
+	}
+
+	result.mu.Lock()
+	defer result.mu.Unlock()
+	result.values[key] = value
+}
+
+// executeSafely выполняет задачу, превращая панику исполнителя в ошибку запуска, обернутую в ErrTaskPanicked
+func executeSafely(ctx context.Context, task *model.Task, executor TaskExecutor) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrTaskPanicked, r)
+		}
+	}()
+
+	return executor.Execute(ctx, task)
+}
+
+// startRun сохраняет начало запуска задачи
+func (s *TaskService) startRun(task *model.Task) *model.TaskRun {
+	run := &model.TaskRun{
+		TaskID:    task.TaskID,
+		TaskName:  task.Name,
+		Status:    model.TaskRunStatusRunning,
+		StartedAt: time.Now(),
+	}
+
+	if err := s.runRepo.Create(run); err != nil {
+		s.logger.Error("Failed to record task run start",
+			zap.String("task_name", task.Name),
+			zap.Error(err))
+		return nil
+	}
+
+	return run
+}
+
+// finishRun сохраняет результат запуска и удаляет устаревшую историю задачи
+func (s *TaskService) finishRun(task *model.Task, run *model.TaskRun, duration time.Duration, result map[string]interface{}, err error) {
+	if run == nil {
+		return
+	}
+
+	finishedAt := run.StartedAt.Add(duration)
+	run.FinishedAt = &finishedAt
+	run.DurationMs = duration.Milliseconds()
+	run.Result = result
+	run.Status = model.TaskRunStatusSuccess
+	if err != nil {
+		run.Status = model.TaskRunStatusError
+		run.Error = err.Error()
+	}
+
+	if finishErr := s.runRepo.Finish(run); finishErr != nil {
+		s.logger.Error("Failed to record task run result",
+			zap.String("task_name", task.Name),
+			zap.Int("run_id", run.RunID),
+			zap.Error(finishErr))
+	}
+
+	s.pruneRuns(task)
+}
+
+// pruneRuns удаляет запуски задачи старше срока хранения
+func (s *TaskService) pruneRuns(task *model.Task) {
+	retentionDays := defaultTaskRunRetentionDays
+	if days, ok := task.GetConfigInt("run_retention_days"); ok && days > 0 {
+		retentionDays = days
+	}
+
+	deleted, err := s.runRepo.DeleteOlderThan(task.TaskID, time.Now().AddDate(0, 0, -retentionDays))
+	if err != nil {
+		s.logger.Warn("Failed to prune task run history",
+			zap.String("task_name", task.Name),
+			zap.Error(err))
+		return
+	}
+
+	if deleted > 0 {
+		s.logger.Info("Pruned task run history",
+			zap.String("task_name", task.Name),
+			zap.Int("deleted", deleted),
+			zap.Int("retention_days", retentionDays))
+	}
+}
+
+// MarkInterruptedRuns помечает запуски, оставшиеся незавершенными после остановки приложения
+func (s *TaskService) MarkInterruptedRuns() (int, error) {
+	return s.runRepo.MarkInterrupted()
+}
+
+// GetTaskHistory возвращает задачу и ее последние запуски
+func (s *TaskService) GetTaskHistory(name string, limit int) (*model.Task, []model.TaskRun, error) {
+	task, err := s.repo.GetByName(name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	runs, err := s.runRepo.GetRecentByTask(task.TaskID, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get task runs: %w", err)
+	}
+
+	return task, runs, nil
+}
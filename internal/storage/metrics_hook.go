@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"gemfactory/internal/metrics"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// MetricsQueryHook собирает метрики выполнения запросов к базе данных
+type MetricsQueryHook struct{}
+
+var _ bun.QueryHook = (*MetricsQueryHook)(nil)
+
+// NewMetricsQueryHook создает hook для метрик запросов
+func NewMetricsQueryHook() *MetricsQueryHook {
+	return &MetricsQueryHook{}
+}
+
+// BeforeQuery вызывается перед выполнением запроса
+func (h *MetricsQueryHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery учитывает время выполнения и ошибки запроса
+func (h *MetricsQueryHook) AfterQuery(_ context.Context, event *bun.QueryEvent) {
+	operation := strings.ToLower(event.Operation())
+	metrics.DBQueryDuration.WithLabelValues(operation).Observe(time.Since(event.StartTime).Seconds())
+
+	// Отсутствие строк - штатный результат, а не ошибка базы данных
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		metrics.DBQueryErrorsTotal.WithLabelValues(operation).Inc()
+	}
+}
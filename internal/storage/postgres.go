@@ -52,6 +52,9 @@ func NewPostgres(databaseURL string, logger *zap.Logger) (*Postgres, error) {
 			logger.Warn("Failed to set search_path", zap.Error(err))
 		}
 
+		// Собираем метрики запросов
+		db.AddQueryHook(NewMetricsQueryHook())
+
 		// Добавляем отладку в режиме разработки
 		if logger.Core().Enabled(zap.DebugLevel) {
 			db.AddQueryHook(bundebug.NewQueryHook(
@@ -137,6 +140,36 @@ func (p *Postgres) GetConfigRepository() model.ConfigRepository {
 	return repository.NewConfigRepository(p.db, p.logger)
 }
 
+// GetSubscriptionRepository возвращает репозиторий подписок
+func (p *Postgres) GetSubscriptionRepository() model.SubscriptionRepository {
+	return repository.NewSubscriptionRepository(p.db, p.logger)
+}
+
+// GetReleaseRevisionRepository возвращает репозиторий истории изменений релизов
+func (p *Postgres) GetReleaseRevisionRepository() model.ReleaseRevisionRepository {
+	return repository.NewReleaseRevisionRepository(p.db, p.logger)
+}
+
+// GetTaskRunRepository возвращает репозиторий истории запусков задач
+func (p *Postgres) GetTaskRunRepository() model.TaskRunRepository {
+	return repository.NewTaskRunRepository(p.db, p.logger)
+}
+
+// GetUserRepository возвращает репозиторий пользователей и ролей
+func (p *Postgres) GetUserRepository() model.UserRepository {
+	return repository.NewUserRepository(p.db, p.logger)
+}
+
+// GetAuditLogRepository возвращает репозиторий журнала аудита
+func (p *Postgres) GetAuditLogRepository() model.AuditLogRepository {
+	return repository.NewAuditLogRepository(p.db, p.logger)
+}
+
+// GetLLMCacheRepository возвращает репозиторий кэша LLM
+func (p *Postgres) GetLLMCacheRepository() model.LLMCacheRepository {
+	return repository.NewLLMCacheRepository(p.db, p.logger)
+}
+
 // Ping проверяет соединение с базой данных
 func (p *Postgres) Ping() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -103,6 +103,31 @@ func (r *ArtistRepository) GetByName(name string) (*model.Artist, error) {
 	return artist, nil
 }
 
+// SearchByName возвращает активных артистов, имя которых содержит строку запроса.
+// Артисты, имя которых начинается с запроса, идут первыми
+func (r *ArtistRepository) SearchByName(query string, limit int) ([]model.Artist, error) {
+	ctx := context.Background()
+	var artists []model.Artist
+
+	// Экранируем спецсимволы LIKE, чтобы запрос искался как обычный текст
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.ToLower(strings.TrimSpace(query)))
+
+	err := r.db.NewSelect().
+		Model(&artists).
+		Where("is_active = ?", true).
+		Where("LOWER(name) LIKE ?", "%"+escaped+"%").
+		OrderExpr("LOWER(name) LIKE ? DESC", escaped+"%").
+		Order("name ASC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to search artists: %w", err)
+	}
+
+	return artists, nil
+}
+
 // Create создает нового артиста
 func (r *ArtistRepository) Create(artist *model.Artist) error {
 	ctx := context.Background()
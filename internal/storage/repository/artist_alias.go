@@ -0,0 +1,114 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// ArtistAliasRepository реализует интерфейс для работы с псевдонимами артистов
+type ArtistAliasRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewArtistAliasRepository создает новый репозиторий псевдонимов артистов
+func NewArtistAliasRepository(db *bun.DB, logger *zap.Logger) *ArtistAliasRepository {
+	return &ArtistAliasRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetAll возвращает все псевдонимы вместе с артистами
+func (r *ArtistAliasRepository) GetAll() ([]model.ArtistAlias, error) {
+	ctx := context.Background()
+	var aliases []model.ArtistAlias
+
+	err := r.db.NewSelect().
+		Model(&aliases).
+		Relation("Artist").
+		Order("artist.name ASC", "artist_alias.alias ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query artist aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// GetByArtist возвращает псевдонимы артиста
+func (r *ArtistAliasRepository) GetByArtist(artistID int) ([]model.ArtistAlias, error) {
+	ctx := context.Background()
+	var aliases []model.ArtistAlias
+
+	err := r.db.NewSelect().
+		Model(&aliases).
+		Where("artist_id = ?", artistID).
+		Order("alias ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query artist aliases by artist: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// GetByNormalized возвращает псевдоним по нормализованному написанию вместе с артистом
+func (r *ArtistAliasRepository) GetByNormalized(normalized string) (*model.ArtistAlias, error) {
+	ctx := context.Background()
+	alias := new(model.ArtistAlias)
+
+	err := r.db.NewSelect().
+		Model(alias).
+		Relation("Artist").
+		Where("artist_alias.normalized = ?", normalized).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query artist alias: %w", err)
+	}
+
+	return alias, nil
+}
+
+// Create создает новый псевдоним
+func (r *ArtistAliasRepository) Create(alias *model.ArtistAlias) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewInsert().
+		Model(alias).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create artist alias: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет псевдоним артиста, возвращает false, если псевдонима не было
+func (r *ArtistAliasRepository) Delete(artistID int, normalized string) (bool, error) {
+	ctx := context.Background()
+
+	result, err := r.db.NewDelete().
+		Model((*model.ArtistAlias)(nil)).
+		Where("artist_id = ? AND normalized = ?", artistID, normalized).
+		Exec(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to delete artist alias: %w", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	return affected > 0, nil
+}
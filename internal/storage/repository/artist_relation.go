@@ -0,0 +1,117 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// ArtistRelationRepository реализует интерфейс для работы со связями артистов
+type ArtistRelationRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewArtistRelationRepository создает новый репозиторий связей артистов
+func NewArtistRelationRepository(db *bun.DB, logger *zap.Logger) *ArtistRelationRepository {
+	return &ArtistRelationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetAll возвращает все связи вместе с артистами
+func (r *ArtistRelationRepository) GetAll() ([]model.ArtistRelation, error) {
+	ctx := context.Background()
+	var relations []model.ArtistRelation
+
+	err := r.db.NewSelect().
+		Model(&relations).
+		Relation("Artist").
+		Relation("Parent").
+		Order("parent.name ASC", "artist.name ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query artist relations: %w", err)
+	}
+
+	return relations, nil
+}
+
+// GetByArtist возвращает связи, в которых артист родитель или связанный
+func (r *ArtistRelationRepository) GetByArtist(artistID int) ([]model.ArtistRelation, error) {
+	ctx := context.Background()
+	var relations []model.ArtistRelation
+
+	err := r.db.NewSelect().
+		Model(&relations).
+		Relation("Artist").
+		Relation("Parent").
+		Where("artist_relation.artist_id = ? OR artist_relation.parent_id = ?", artistID, artistID).
+		Order("parent.name ASC", "artist.name ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query artist relations by artist: %w", err)
+	}
+
+	return relations, nil
+}
+
+// GetChildren возвращает солистов, юниты и совместные проекты родителя
+func (r *ArtistRelationRepository) GetChildren(parentID int) ([]model.ArtistRelation, error) {
+	ctx := context.Background()
+	var relations []model.ArtistRelation
+
+	err := r.db.NewSelect().
+		Model(&relations).
+		Relation("Artist").
+		Where("artist_relation.parent_id = ?", parentID).
+		Order("artist_relation.relation_type ASC", "artist.name ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query artist relation children: %w", err)
+	}
+
+	return relations, nil
+}
+
+// Create создает связь, для существующей пары артистов обновляет тип
+func (r *ArtistRelationRepository) Create(relation *model.ArtistRelation) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewInsert().
+		Model(relation).
+		On("CONFLICT (artist_id, parent_id) DO UPDATE").
+		Set("relation_type = EXCLUDED.relation_type").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create artist relation: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет связь артиста с родителем, возвращает false, если связи не было
+func (r *ArtistRelationRepository) Delete(artistID, parentID int) (bool, error) {
+	ctx := context.Background()
+
+	result, err := r.db.NewDelete().
+		Model((*model.ArtistRelation)(nil)).
+		Where("artist_id = ? AND parent_id = ?", artistID, parentID).
+		Exec(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to delete artist relation: %w", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	return affected > 0, nil
+}
@@ -0,0 +1,61 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// AuditLogRepository реализует интерфейс для работы с журналом аудита
+type AuditLogRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewAuditLogRepository создает новый репозиторий журнала аудита
+func NewAuditLogRepository(db *bun.DB, logger *zap.Logger) *AuditLogRepository {
+	return &AuditLogRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create сохраняет запись журнала
+func (r *AuditLogRepository) Create(entry *model.AuditEntry) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewInsert().
+		Model(entry).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecent возвращает последние записи журнала, опционально по одной команде
+func (r *AuditLogRepository) GetRecent(limit int, command string) ([]model.AuditEntry, error) {
+	ctx := context.Background()
+	var entries []model.AuditEntry
+
+	query := r.db.NewSelect().
+		Model(&entries).
+		Order("created_at DESC", "audit_id DESC").
+		Limit(limit)
+
+	if command != "" {
+		query = query.Where("command = ?", command)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,95 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// ChatSettingsRepository реализует интерфейс для работы с настройками групповых чатов
+type ChatSettingsRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewChatSettingsRepository создает новый репозиторий настроек групповых чатов
+func NewChatSettingsRepository(db *bun.DB, logger *zap.Logger) *ChatSettingsRepository {
+	return &ChatSettingsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetByChatID возвращает настройки чата или nil, если их не меняли
+func (r *ChatSettingsRepository) GetByChatID(chatID int64) (*model.ChatSettings, error) {
+	ctx := context.Background()
+	settings := new(model.ChatSettings)
+
+	err := r.db.NewSelect().
+		Model(settings).
+		Where("chat_id = ?", chatID).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get chat settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Upsert сохраняет настройки чата
+func (r *ChatSettingsRepository) Upsert(settings *model.ChatSettings) error {
+	ctx := context.Background()
+
+	now := time.Now()
+	if settings.CreatedAt.IsZero() {
+		settings.CreatedAt = now
+	}
+	settings.UpdatedAt = now
+	if settings.AllowedCommands == nil {
+		settings.AllowedCommands = []string{}
+	}
+
+	_, err := r.db.NewInsert().
+		Model(settings).
+		On("CONFLICT (chat_id) DO UPDATE").
+		Set("language = EXCLUDED.language").
+		Set("gender_filter = EXCLUDED.gender_filter").
+		Set("digest_enabled = EXCLUDED.digest_enabled").
+		Set("quiet_mode = EXCLUDED.quiet_mode").
+		Set("allowed_commands = EXCLUDED.allowed_commands").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to save chat settings: %w", err)
+	}
+
+	return nil
+}
+
+// GetDigestChats возвращает настройки групп, включивших дайджесты
+func (r *ChatSettingsRepository) GetDigestChats() ([]model.ChatSettings, error) {
+	ctx := context.Background()
+	var settings []model.ChatSettings
+
+	err := r.db.NewSelect().
+		Model(&settings).
+		Where("digest_enabled = ?", true).
+		Order("chat_id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest chats: %w", err)
+	}
+
+	return settings, nil
+}
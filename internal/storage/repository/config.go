@@ -168,6 +168,12 @@ func (r *ConfigRepository) GetDefaultConfig() map[string]string {
 		"HEALTH_PORT":           "8080",
 		"LLM_API_KEY":           "",
 		"LLM_DELAY":             "1500",
+		"LLM_PROVIDER":          "openai",
+		"LLM_BASE_URL":          "",
+		"LLM_MODEL":             "",
+		"LLM_TEMPERATURE":       "0.2",
+		"LLM_TOP_P":             "0.7",
+		"LLM_MAX_TOKENS":        "8192",
 	}
 }
 
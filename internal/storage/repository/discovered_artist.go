@@ -0,0 +1,156 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// DiscoveredArtistRepository реализует интерфейс для работы с найденными артистами
+type DiscoveredArtistRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewDiscoveredArtistRepository создает новый репозиторий найденных артистов
+func NewDiscoveredArtistRepository(db *bun.DB, logger *zap.Logger) *DiscoveredArtistRepository {
+	return &DiscoveredArtistRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetByID возвращает найденного артиста по ID
+func (r *DiscoveredArtistRepository) GetByID(discoveredID int) (*model.DiscoveredArtist, error) {
+	ctx := context.Background()
+	artist := new(model.DiscoveredArtist)
+
+	err := r.db.NewSelect().
+		Model(artist).
+		Where("discovered_id = ?", discoveredID).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query discovered artist: %w", err)
+	}
+
+	return artist, nil
+}
+
+// GetByNormalized возвращает найденного артиста по нормализованному имени
+func (r *DiscoveredArtistRepository) GetByNormalized(normalized string) (*model.DiscoveredArtist, error) {
+	ctx := context.Background()
+	artist := new(model.DiscoveredArtist)
+
+	err := r.db.NewSelect().
+		Model(artist).
+		Where("normalized = ?", normalized).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query discovered artist by name: %w", err)
+	}
+
+	return artist, nil
+}
+
+// GetByStatus возвращает найденных артистов со статусом, сначала встречавшихся чаще и недавно
+func (r *DiscoveredArtistRepository) GetByStatus(status string, limit int) ([]model.DiscoveredArtist, error) {
+	ctx := context.Background()
+	var artists []model.DiscoveredArtist
+
+	err := r.db.NewSelect().
+		Model(&artists).
+		Where("status = ?", status).
+		Order("seen_count DESC", "last_seen_at DESC", "discovered_id ASC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query discovered artists: %w", err)
+	}
+
+	return artists, nil
+}
+
+// CountByStatus возвращает количество найденных артистов со статусом
+func (r *DiscoveredArtistRepository) CountByStatus(status string) (int, error) {
+	ctx := context.Background()
+
+	count, err := r.db.NewSelect().
+		Model((*model.DiscoveredArtist)(nil)).
+		Where("status = ?", status).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count discovered artists: %w", err)
+	}
+
+	return count, nil
+}
+
+// Create создает запись о найденном артисте
+func (r *DiscoveredArtistRepository) Create(artist *model.DiscoveredArtist) error {
+	ctx := context.Background()
+
+	now := time.Now()
+	artist.CreatedAt = now
+	artist.UpdatedAt = now
+
+	_, err := r.db.NewInsert().
+		Model(artist).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create discovered artist: %w", err)
+	}
+
+	return nil
+}
+
+// Update обновляет статистику найденного артиста
+func (r *DiscoveredArtistRepository) Update(artist *model.DiscoveredArtist) error {
+	ctx := context.Background()
+
+	artist.UpdatedAt = time.Now()
+
+	_, err := r.db.NewUpdate().
+		Model(artist).
+		WherePK().
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update discovered artist: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStatus меняет статус найденного артиста
+func (r *DiscoveredArtistRepository) UpdateStatus(discoveredID int, status string) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewUpdate().
+		Model((*model.DiscoveredArtist)(nil)).
+		Set("status = ?", status).
+		Set("updated_at = ?", time.Now()).
+		Where("discovered_id = ?", discoveredID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update discovered artist status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// LLMCacheRepository реализует интерфейс для работы с кэшем ответов LLM
+type LLMCacheRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewLLMCacheRepository создает новый репозиторий кэша LLM
+func NewLLMCacheRepository(db *bun.DB, logger *zap.Logger) *LLMCacheRepository {
+	return &LLMCacheRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Get возвращает закэшированный ответ по ключу
+func (r *LLMCacheRepository) Get(cacheKey string) (*model.LLMCacheEntry, error) {
+	ctx := context.Background()
+	entry := new(model.LLMCacheEntry)
+
+	err := r.db.NewSelect().
+		Model(entry).
+		Where("cache_key = ?", cacheKey).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get llm cache entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Set сохраняет ответ в кэш (существующая запись перезаписывается)
+func (r *LLMCacheRepository) Set(entry *model.LLMCacheEntry) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewInsert().
+		Model(entry).
+		On("CONFLICT (cache_key) DO UPDATE").
+		Set("response = EXCLUDED.response").
+		Set("model = EXCLUDED.model").
+		Set("created_at = CURRENT_TIMESTAMP").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to save llm cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// MarkHit увеличивает счетчик попаданий записи
+func (r *LLMCacheRepository) MarkHit(cacheKey string) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewUpdate().
+		Model((*model.LLMCacheEntry)(nil)).
+		Set("hit_count = hit_count + 1").
+		Set("last_hit_at = CURRENT_TIMESTAMP").
+		Where("cache_key = ?", cacheKey).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update llm cache hit: %w", err)
+	}
+
+	return nil
+}
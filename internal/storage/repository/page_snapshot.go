@@ -0,0 +1,146 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// PageSnapshotRepository реализует интерфейс для работы со снимками страниц расписания
+type PageSnapshotRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewPageSnapshotRepository создает новый репозиторий снимков страниц
+func NewPageSnapshotRepository(db *bun.DB, logger *zap.Logger) *PageSnapshotRepository {
+	return &PageSnapshotRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetByID возвращает снимок страницы по ID
+func (r *PageSnapshotRepository) GetByID(snapshotID int) (*model.PageSnapshot, error) {
+	ctx := context.Background()
+	snapshot := new(model.PageSnapshot)
+
+	err := r.db.NewSelect().
+		Model(snapshot).
+		Where("snapshot_id = ?", snapshotID).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query page snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// GetLatestByURL возвращает последний снимок страницы
+func (r *PageSnapshotRepository) GetLatestByURL(url string) (*model.PageSnapshot, error) {
+	ctx := context.Background()
+	snapshot := new(model.PageSnapshot)
+
+	err := r.db.NewSelect().
+		Model(snapshot).
+		Where("url = ?", url).
+		Order("fetched_at DESC", "snapshot_id DESC").
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query latest page snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// GetRecent возвращает последние снимки страниц без тела страницы
+func (r *PageSnapshotRepository) GetRecent(limit int) ([]model.PageSnapshot, error) {
+	ctx := context.Background()
+	var snapshots []model.PageSnapshot
+
+	err := r.db.NewSelect().
+		Model(&snapshots).
+		ExcludeColumn("body").
+		Order("fetched_at DESC", "snapshot_id DESC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query page snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// Create сохраняет снимок страницы
+func (r *PageSnapshotRepository) Create(snapshot *model.PageSnapshot) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewInsert().
+		Model(snapshot).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create page snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFilterHash запоминает отпечаток списка артистов, с которым снимок успешно разобран
+func (r *PageSnapshotRepository) UpdateFilterHash(snapshotID int, filterHash string) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewUpdate().
+		Model((*model.PageSnapshot)(nil)).
+		Set("filter_hash = ?", filterHash).
+		Where("snapshot_id = ?", snapshotID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update page snapshot filter hash: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOld удаляет снимки страницы, кроме keep последних
+func (r *PageSnapshotRepository) DeleteOld(url string, keep int) error {
+	ctx := context.Background()
+
+	recent := r.db.NewSelect().
+		Model((*model.PageSnapshot)(nil)).
+		Column("snapshot_id").
+		Where("url = ?", url).
+		Order("fetched_at DESC", "snapshot_id DESC").
+		Limit(keep)
+
+	result, err := r.db.NewDelete().
+		Model((*model.PageSnapshot)(nil)).
+		Where("url = ?", url).
+		Where("snapshot_id NOT IN (?)", recent).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete old page snapshots: %w", err)
+	}
+
+	if deleted, _ := result.RowsAffected(); deleted > 0 {
+		r.logger.Debug("Deleted old page snapshots", zap.String("url", url), zap.Int64("deleted", deleted))
+	}
+
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"gemfactory/internal/model"
-	"sort"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -104,6 +103,42 @@ func (r *ReleaseRepository) GetByArtistDateAndTrack(artistID int, date, titleTra
 	return &release, nil
 }
 
+// GetActiveByArtistAndTrack возвращает активные релизы артиста с указанным треком
+func (r *ReleaseRepository) GetActiveByArtistAndTrack(artistID int, titleTrack string) ([]model.Release, error) {
+	ctx := context.Background()
+	var releases []model.Release
+
+	err := r.db.NewSelect().
+		Model(&releases).
+		Where("artist_id = ? AND title_track = ?", artistID, titleTrack).
+		Where("is_active = ?", true).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query releases by artist and track: %w", err)
+	}
+
+	return releases, nil
+}
+
+// GetActiveByArtistAndDate возвращает активные релизы артиста на указанную дату
+func (r *ReleaseRepository) GetActiveByArtistAndDate(artistID int, date string) ([]model.Release, error) {
+	ctx := context.Background()
+	var releases []model.Release
+
+	err := r.db.NewSelect().
+		Model(&releases).
+		Where("artist_id = ? AND date = ?", artistID, date).
+		Where("is_active = ?", true).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query releases by artist and date: %w", err)
+	}
+
+	return releases, nil
+}
+
 // GetByGender возвращает релизы по полу
 func (r *ReleaseRepository) GetByGender(gender model.Gender) ([]model.Release, error) {
 	ctx := context.Background()
@@ -201,31 +236,37 @@ func (r *ReleaseRepository) GetByArtistName(artistName string) ([]model.Release,
 		Where("LOWER(artist.name) = LOWER(?)", artistName).
 		Where("release.is_active = ?", true).
 		Where("artist.is_active = ?", true).
-		Order("date ASC").
+		Order("release.release_date ASC NULLS LAST", "release.release_at ASC NULLS LAST").
 		Scan(ctx)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to query releases by artist name: %w", err)
 	}
 
-	// Сортируем релизы по дате в Go коде
-	sort.Slice(releases, func(i, j int) bool {
-		// Парсим даты в формате DD.MM.YY
-		dateI, errI := time.Parse("02.01.06", releases[i].Date)
-		dateJ, errJ := time.Parse("02.01.06", releases[j].Date)
+	return releases, nil
+}
+
+// GetActiveByArtist возвращает активные релизы артиста по ID
+func (r *ReleaseRepository) GetActiveByArtist(artistID int) ([]model.Release, error) {
+	ctx := context.Background()
+	var releases []model.Release
 
-		// Если не удалось распарсить, используем строковую сортировку
-		if errI != nil || errJ != nil {
-			return releases[i].Date < releases[j].Date
-		}
+	err := r.db.NewSelect().
+		Model(&releases).
+		Relation("Artist").
+		Where("release.artist_id = ?", artistID).
+		Where("release.is_active = ?", true).
+		Order("release.release_date ASC NULLS LAST", "release.release_at ASC NULLS LAST").
+		Scan(ctx)
 
-		return dateI.Before(dateJ)
-	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active releases by artist: %w", err)
+	}
 
 	return releases, nil
 }
 
-// GetByDateRange возвращает релизы в диапазоне дат
+// GetByDateRange возвращает активные релизы с датой в диапазоне [start, end)
 func (r *ReleaseRepository) GetByDateRange(start, end time.Time) ([]model.Release, error) {
 	ctx := context.Background()
 	var releases []model.Release
@@ -233,8 +274,10 @@ func (r *ReleaseRepository) GetByDateRange(start, end time.Time) ([]model.Releas
 	err := r.db.NewSelect().
 		Model(&releases).
 		Relation("Artist").
-		Where("release.date >= ? AND release.date <= ?", start, end).
-		Order("release.date ASC").
+		Where("release.release_date >= ? AND release.release_date < ?", start.Format("2006-01-02"), end.Format("2006-01-02")).
+		Where("release.is_active = ?", true).
+		Where("artist.is_active = ?", true).
+		Order("release.release_date ASC", "release.release_at ASC NULLS LAST", "artist.name ASC").
 		Scan(ctx)
 
 	if err != nil {
@@ -244,6 +287,27 @@ func (r *ReleaseRepository) GetByDateRange(start, end time.Time) ([]model.Releas
 	return releases, nil
 }
 
+// GetUpcomingByArtist возвращает активные релизы артиста с известным моментом выхода после from
+func (r *ReleaseRepository) GetUpcomingByArtist(artistID int, from time.Time) ([]model.Release, error) {
+	ctx := context.Background()
+	var releases []model.Release
+
+	err := r.db.NewSelect().
+		Model(&releases).
+		Relation("Artist").
+		Where("release.artist_id = ?", artistID).
+		Where("release.is_active = ?", true).
+		Where("release.release_at > ?", from).
+		Order("release.release_at ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query upcoming releases by artist: %w", err)
+	}
+
+	return releases, nil
+}
+
 // GetActive возвращает активные релизы
 func (r *ReleaseRepository) GetActive() ([]model.Release, error) {
 	ctx := context.Background()
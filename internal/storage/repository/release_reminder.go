@@ -0,0 +1,173 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// ReleaseReminderRepository реализует интерфейс для работы с напоминаниями о релизах
+type ReleaseReminderRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewReleaseReminderRepository создает новый репозиторий напоминаний о релизах
+func NewReleaseReminderRepository(db *bun.DB, logger *zap.Logger) *ReleaseReminderRepository {
+	return &ReleaseReminderRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Schedule создает или обновляет напоминание и возвращает его актуальное состояние в reminder.
+// Неотправленное напоминание пересчитывается всегда, отправленное - только при переносе релиза
+func (r *ReleaseReminderRepository) Schedule(reminder *model.ReleaseReminder) error {
+	ctx := context.Background()
+
+	now := time.Now()
+	reminder.Status = model.ReminderStatusPending
+	reminder.CreatedAt = now
+	reminder.UpdatedAt = now
+
+	_, err := r.db.NewInsert().
+		Model(reminder).
+		On("CONFLICT (user_id, release_id) DO UPDATE").
+		Set("chat_id = EXCLUDED.chat_id").
+		Set("remind_at = CASE WHEN rr.status <> ? OR rr.release_at <> EXCLUDED.release_at THEN EXCLUDED.remind_at ELSE rr.remind_at END", model.ReminderStatusSent).
+		Set("status = CASE WHEN rr.status <> ? OR rr.release_at <> EXCLUDED.release_at THEN ? ELSE rr.status END", model.ReminderStatusSent, model.ReminderStatusPending).
+		Set("sent_at = CASE WHEN rr.release_at <> EXCLUDED.release_at THEN NULL ELSE rr.sent_at END").
+		Set("release_at = EXCLUDED.release_at").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("*").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to schedule release reminder: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID возвращает напоминание с релизом и артистом
+func (r *ReleaseReminderRepository) GetByID(reminderID int) (*model.ReleaseReminder, error) {
+	ctx := context.Background()
+	reminder := new(model.ReleaseReminder)
+
+	err := r.db.NewSelect().
+		Model(reminder).
+		Relation("Release").
+		Relation("Release.Artist").
+		Where("rr.reminder_id = ?", reminderID).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get release reminder: %w", err)
+	}
+
+	return reminder, nil
+}
+
+// GetPendingBefore возвращает ожидающие напоминания со временем отправки раньше указанного момента
+func (r *ReleaseReminderRepository) GetPendingBefore(before time.Time) ([]model.ReleaseReminder, error) {
+	ctx := context.Background()
+	var reminders []model.ReleaseReminder
+
+	err := r.db.NewSelect().
+		Model(&reminders).
+		Where("rr.status = ?", model.ReminderStatusPending).
+		Where("rr.remind_at < ?", before).
+		Order("rr.remind_at ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending release reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// Claim помечает напоминание отправленным. Возвращает false, если его уже отправили или перенесли
+func (r *ReleaseReminderRepository) Claim(reminderID int, remindAt time.Time) (bool, error) {
+	ctx := context.Background()
+
+	now := time.Now()
+	result, err := r.db.NewUpdate().
+		Model((*model.ReleaseReminder)(nil)).
+		Set("status = ?", model.ReminderStatusSent).
+		Set("sent_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("reminder_id = ?", reminderID).
+		Where("status = ?", model.ReminderStatusPending).
+		Where("remind_at = ?", remindAt).
+		Exec(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to claim release reminder: %w", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	return affected == 1, nil
+}
+
+// Expire помечает ожидающее напоминание неактуальным
+func (r *ReleaseReminderRepository) Expire(reminderID int) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewUpdate().
+		Model((*model.ReleaseReminder)(nil)).
+		Set("status = ?", model.ReminderStatusExpired).
+		Set("updated_at = ?", time.Now()).
+		Where("reminder_id = ?", reminderID).
+		Where("status = ?", model.ReminderStatusPending).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to expire release reminder: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireReleased помечает неактуальными ожидающие напоминания о релизах, которые уже вышли
+func (r *ReleaseReminderRepository) ExpireReleased(now time.Time) (int, error) {
+	return r.expirePending("release_at <= ?", now)
+}
+
+// ExpirePendingByRelease помечает неактуальными ожидающие напоминания о релизе
+func (r *ReleaseReminderRepository) ExpirePendingByRelease(releaseID int) (int, error) {
+	return r.expirePending("release_id = ?", releaseID)
+}
+
+// ExpirePendingByUser помечает неактуальными ожидающие напоминания пользователя
+func (r *ReleaseReminderRepository) ExpirePendingByUser(userID int64) (int, error) {
+	return r.expirePending("user_id = ?", userID)
+}
+
+// expirePending помечает неактуальными ожидающие напоминания по условию
+func (r *ReleaseReminderRepository) expirePending(query string, args ...interface{}) (int, error) {
+	ctx := context.Background()
+
+	result, err := r.db.NewUpdate().
+		Model((*model.ReleaseReminder)(nil)).
+		Set("status = ?", model.ReminderStatusExpired).
+		Set("updated_at = ?", time.Now()).
+		Where("status = ?", model.ReminderStatusPending).
+		Where(query, args...).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire release reminders: %w", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	return int(affected), nil
+}
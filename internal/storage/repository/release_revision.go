@@ -0,0 +1,83 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// ReleaseRevisionRepository реализует интерфейс для работы с историей изменений релизов
+type ReleaseRevisionRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewReleaseRevisionRepository создает новый репозиторий истории изменений релизов
+func NewReleaseRevisionRepository(db *bun.DB, logger *zap.Logger) *ReleaseRevisionRepository {
+	return &ReleaseRevisionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateBatch сохраняет изменения релиза
+func (r *ReleaseRevisionRepository) CreateBatch(revisions []model.ReleaseRevision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	_, err := r.db.NewInsert().
+		Model(&revisions).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create release revisions: %w", err)
+	}
+
+	return nil
+}
+
+// GetSince возвращает изменения начиная с указанного момента вместе с релизами и артистами
+func (r *ReleaseRevisionRepository) GetSince(since time.Time) ([]model.ReleaseRevision, error) {
+	ctx := context.Background()
+	var revisions []model.ReleaseRevision
+
+	err := r.db.NewSelect().
+		Model(&revisions).
+		Relation("Release").
+		Relation("Artist").
+		Where("revision.changed_at >= ?", since).
+		Order("revision.changed_at ASC", "revision.revision_id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query release revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetByRelease возвращает историю изменений релиза
+func (r *ReleaseRevisionRepository) GetByRelease(releaseID int) ([]model.ReleaseRevision, error) {
+	ctx := context.Background()
+	var revisions []model.ReleaseRevision
+
+	err := r.db.NewSelect().
+		Model(&revisions).
+		Where("release_id = ?", releaseID).
+		Order("changed_at ASC", "revision_id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query release revisions by release: %w", err)
+	}
+
+	return revisions, nil
+}
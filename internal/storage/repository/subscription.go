@@ -0,0 +1,148 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// SubscriptionRepository реализует интерфейс для работы с подписками
+type SubscriptionRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewSubscriptionRepository создает новый репозиторий подписок
+func NewSubscriptionRepository(db *bun.DB, logger *zap.Logger) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetByUser возвращает подписки пользователя вместе с артистами
+func (r *SubscriptionRepository) GetByUser(userID int64) ([]model.Subscription, error) {
+	ctx := context.Background()
+	var subscriptions []model.Subscription
+
+	err := r.db.NewSelect().
+		Model(&subscriptions).
+		Relation("Artist").
+		Where("subscription.user_id = ?", userID).
+		Order("artist.name ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subscriptions by user: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// GetByArtist возвращает подписчиков артиста, включая подписчиков его групп с IncludeRelated.
+// Для каждого пользователя возвращается одна подписка, прямая подписка на артиста в приоритете
+func (r *SubscriptionRepository) GetByArtist(artistID int) ([]model.Subscription, error) {
+	ctx := context.Background()
+	var subscriptions []model.Subscription
+
+	err := r.db.NewSelect().
+		Model(&subscriptions).
+		DistinctOn("subscription.user_id").
+		Where("subscription.artist_id = ?", artistID).
+		WhereOr("subscription.include_related AND subscription.artist_id IN (?)", relatedParentsQuery(r.db, artistID)).
+		OrderExpr("subscription.user_id, subscription.artist_id = ? DESC", artistID).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subscriptions by artist: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// GetByUserAndArtist возвращает подписку пользователя на артиста
+func (r *SubscriptionRepository) GetByUserAndArtist(userID int64, artistID int) (*model.Subscription, error) {
+	ctx := context.Background()
+	subscription := new(model.Subscription)
+
+	err := r.db.NewSelect().
+		Model(subscription).
+		Where("user_id = ? AND artist_id = ?", userID, artistID).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// IsFollowing проверяет подписку пользователя на артиста или на его группу с IncludeRelated
+func (r *SubscriptionRepository) IsFollowing(userID int64, artistID int) (bool, error) {
+	ctx := context.Background()
+
+	exists, err := r.db.NewSelect().
+		Model((*model.Subscription)(nil)).
+		Where("user_id = ?", userID).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("artist_id = ?", artistID).
+				WhereOr("include_related AND artist_id IN (?)", relatedParentsQuery(r.db, artistID))
+		}).
+		Exists(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to check subscription: %w", err)
+	}
+
+	return exists, nil
+}
+
+// relatedParentsQuery выбирает группы и артистов, с которыми связан артист
+func relatedParentsQuery(db *bun.DB, artistID int) *bun.SelectQuery {
+	return db.NewSelect().
+		Model((*model.ArtistRelation)(nil)).
+		Column("parent_id").
+		Where("artist_id = ?", artistID)
+}
+
+// Create создает новую подписку
+func (r *SubscriptionRepository) Create(subscription *model.Subscription) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewInsert().
+		Model(subscription).
+		On("CONFLICT (user_id, artist_id) DO UPDATE").
+		Set("chat_id = EXCLUDED.chat_id").
+		Set("include_related = EXCLUDED.include_related").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет подписку пользователя на артиста
+func (r *SubscriptionRepository) Delete(userID int64, artistID int) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewDelete().
+		Model((*model.Subscription)(nil)).
+		Where("user_id = ? AND artist_id = ?", userID, artistID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+
+	return nil
+}
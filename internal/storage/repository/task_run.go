@@ -0,0 +1,115 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// TaskRunRepository реализует интерфейс для работы с историей запусков задач
+type TaskRunRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewTaskRunRepository создает новый репозиторий истории запусков задач
+func NewTaskRunRepository(db *bun.DB, logger *zap.Logger) *TaskRunRepository {
+	return &TaskRunRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create сохраняет начало запуска задачи
+func (r *TaskRunRepository) Create(run *model.TaskRun) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewInsert().
+		Model(run).
+		Returning("run_id").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create task run: %w", err)
+	}
+
+	return nil
+}
+
+// Finish сохраняет результат запуска задачи
+func (r *TaskRunRepository) Finish(run *model.TaskRun) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewUpdate().
+		Model(run).
+		Column("status", "finished_at", "duration_ms", "error", "result").
+		WherePK().
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to finish task run: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecentByTask возвращает последние запуски задачи
+func (r *TaskRunRepository) GetRecentByTask(taskID int, limit int) ([]model.TaskRun, error) {
+	ctx := context.Background()
+	var runs []model.TaskRun
+
+	err := r.db.NewSelect().
+		Model(&runs).
+		Where("task_id = ?", taskID).
+		Order("started_at DESC", "run_id DESC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// MarkInterrupted помечает незавершенные запуски как прерванные (например, после перезапуска)
+func (r *TaskRunRepository) MarkInterrupted() (int, error) {
+	ctx := context.Background()
+
+	result, err := r.db.NewUpdate().
+		Model((*model.TaskRun)(nil)).
+		Set("status = ?", model.TaskRunStatusInterrupted).
+		Set("finished_at = ?", time.Now()).
+		Where("status = ?", model.TaskRunStatusRunning).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark interrupted task runs: %w", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	return int(affected), nil
+}
+
+// DeleteOlderThan удаляет запуски задачи, начатые раньше указанного момента
+func (r *TaskRunRepository) DeleteOlderThan(taskID int, before time.Time) (int, error) {
+	ctx := context.Background()
+
+	result, err := r.db.NewDelete().
+		Model((*model.TaskRun)(nil)).
+		Where("task_id = ?", taskID).
+		Where("started_at < ?", before).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old task runs: %w", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	return int(affected), nil
+}
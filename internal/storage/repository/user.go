@@ -0,0 +1,190 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// UserRepository реализует интерфейс для работы с пользователями и ролями
+type UserRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewUserRepository создает новый репозиторий пользователей
+func NewUserRepository(db *bun.DB, logger *zap.Logger) *UserRepository {
+	return &UserRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetByID возвращает пользователя по Telegram ID
+func (r *UserRepository) GetByID(userID int64) (*model.User, error) {
+	ctx := context.Background()
+	user := new(model.User)
+
+	err := r.db.NewSelect().
+		Model(user).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetByUsername возвращает пользователя по username без учета регистра
+func (r *UserRepository) GetByUsername(username string) (*model.User, error) {
+	ctx := context.Background()
+	user := new(model.User)
+
+	err := r.db.NewSelect().
+		Model(user).
+		Where("LOWER(username) = LOWER(?)", username).
+		Order("updated_at DESC").
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user by username: %w", err)
+	}
+
+	return user, nil
+}
+
+// Create создает пользователя, если его еще нет
+func (r *UserRepository) Create(user *model.User) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewInsert().
+		Model(user).
+		On("CONFLICT (user_id) DO NOTHING").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateUsername обновляет последний известный username пользователя
+func (r *UserRepository) UpdateUsername(userID int64, username string) error {
+	ctx := context.Background()
+
+	_, err := r.db.NewUpdate().
+		Model((*model.User)(nil)).
+		Set("username = ?", username).
+		Set("updated_at = ?", time.Now()).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update username: %w", err)
+	}
+
+	return nil
+}
+
+// SetRole устанавливает роль пользователя, создавая запись при необходимости
+func (r *UserRepository) SetRole(userID int64, role model.Role, grantedBy *int64) error {
+	ctx := context.Background()
+
+	user := &model.User{
+		UserID:    userID,
+		Role:      role,
+		GrantedBy: grantedBy,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	_, err := r.db.NewInsert().
+		Model(user).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("role = EXCLUDED.role").
+		Set("granted_by = EXCLUDED.granted_by").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to set user role: %w", err)
+	}
+
+	return nil
+}
+
+// SetLanguage сохраняет язык интерфейса пользователя, создавая запись при необходимости
+func (r *UserRepository) SetLanguage(userID int64, username, language string) error {
+	ctx := context.Background()
+
+	user := &model.User{
+		UserID:    userID,
+		Username:  username,
+		Role:      model.RoleUser,
+		Language:  language,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	_, err := r.db.NewInsert().
+		Model(user).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("language = EXCLUDED.language").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to set user language: %w", err)
+	}
+
+	return nil
+}
+
+// GetByRoles возвращает пользователей с указанными ролями
+func (r *UserRepository) GetByRoles(roles []model.Role) ([]model.User, error) {
+	ctx := context.Background()
+	var users []model.User
+
+	err := r.db.NewSelect().
+		Model(&users).
+		Where("role IN (?)", bun.In(roles)).
+		Order("username ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by roles: %w", err)
+	}
+
+	return users, nil
+}
+
+// HasRole проверяет, есть ли хотя бы один пользователь с ролью
+func (r *UserRepository) HasRole(role model.Role) (bool, error) {
+	ctx := context.Background()
+
+	exists, err := r.db.NewSelect().
+		Model((*model.User)(nil)).
+		Where("role = ?", role).
+		Exists(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to check role existence: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,92 @@
+// Package repository содержит репозитории для работы с базой данных.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gemfactory/internal/model"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// UserSettingsRepository реализует интерфейс для работы с настройками пользователей
+type UserSettingsRepository struct {
+	db     *bun.DB
+	logger *zap.Logger
+}
+
+// NewUserSettingsRepository создает новый репозиторий настроек пользователей
+func NewUserSettingsRepository(db *bun.DB, logger *zap.Logger) *UserSettingsRepository {
+	return &UserSettingsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetByUserID возвращает настройки пользователя или nil, если он их не менял
+func (r *UserSettingsRepository) GetByUserID(userID int64) (*model.UserSettings, error) {
+	ctx := context.Background()
+	settings := new(model.UserSettings)
+
+	err := r.db.NewSelect().
+		Model(settings).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Upsert сохраняет настройки пользователя
+func (r *UserSettingsRepository) Upsert(settings *model.UserSettings) error {
+	ctx := context.Background()
+
+	now := time.Now()
+	if settings.CreatedAt.IsZero() {
+		settings.CreatedAt = now
+	}
+	settings.UpdatedAt = now
+
+	_, err := r.db.NewInsert().
+		Model(settings).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("timezone = EXCLUDED.timezone").
+		Set("gender_filter = EXCLUDED.gender_filter").
+		Set("layout = EXCLUDED.layout").
+		Set("digest_enabled = EXCLUDED.digest_enabled").
+		Set("reminder_minutes = EXCLUDED.reminder_minutes").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to save user settings: %w", err)
+	}
+
+	return nil
+}
+
+// GetDigestSubscribers возвращает настройки пользователей, подписанных на дайджесты
+func (r *UserSettingsRepository) GetDigestSubscribers() ([]model.UserSettings, error) {
+	ctx := context.Background()
+	var settings []model.UserSettings
+
+	err := r.db.NewSelect().
+		Model(&settings).
+		Where("digest_enabled = ?", true).
+		Order("user_id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest subscribers: %w", err)
+	}
+
+	return settings, nil
+}
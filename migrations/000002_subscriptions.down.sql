@@ -0,0 +1,6 @@
+-- Откат подписок пользователей на артистов
+-- Migration: 002_subscriptions.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.subscriptions CASCADE;
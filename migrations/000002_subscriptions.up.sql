@@ -0,0 +1,16 @@
+-- Подписки пользователей на артистов
+-- Migration: 002_subscriptions.up.sql
+
+SET search_path TO gemfactory, public;
+
+CREATE TABLE IF NOT EXISTS gemfactory.subscriptions (
+    subscription_id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    chat_id BIGINT NOT NULL,
+    artist_id INTEGER NOT NULL REFERENCES gemfactory.artists(artist_id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, artist_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON gemfactory.subscriptions(user_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_artist_id ON gemfactory.subscriptions(artist_id);
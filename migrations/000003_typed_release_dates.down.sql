@@ -0,0 +1,10 @@
+-- Откат типизированных дат релизов
+-- Migration: 003_typed_release_dates.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP INDEX IF EXISTS gemfactory.idx_releases_release_at;
+DROP INDEX IF EXISTS gemfactory.idx_releases_release_date;
+
+ALTER TABLE gemfactory.releases DROP COLUMN IF EXISTS release_at;
+ALTER TABLE gemfactory.releases DROP COLUMN IF EXISTS release_date;
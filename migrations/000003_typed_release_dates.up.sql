@@ -0,0 +1,27 @@
+-- Типизированные даты релизов
+-- Migration: 003_typed_release_dates.up.sql
+
+SET search_path TO gemfactory, public;
+
+ALTER TABLE gemfactory.releases ADD COLUMN IF NOT EXISTS release_date DATE;
+ALTER TABLE gemfactory.releases ADD COLUMN IF NOT EXISTS release_at TIMESTAMPTZ;
+
+-- Заполнение release_date из строк формата DD.MM.YY и DD.MM.YYYY
+UPDATE gemfactory.releases
+SET release_date = to_date(date, 'DD.MM.YY')
+WHERE release_date IS NULL AND date ~ '^\d{1,2}\.\d{1,2}\.\d{2}$';
+
+UPDATE gemfactory.releases
+SET release_date = to_date(date, 'DD.MM.YYYY')
+WHERE release_date IS NULL AND date ~ '^\d{1,2}\.\d{1,2}\.\d{4}$';
+
+-- Заполнение release_at: release_date - дата в KST, time_msk - время MSK (KST = MSK + 6 часов).
+-- Момент строится в KST, чтобы релиз до 06:00 KST не сдвигался на сутки вперед.
+-- Условие IS DISTINCT FROM пересчитывает и строки, заполненные прежней формулой
+UPDATE gemfactory.releases
+SET release_at = (release_date + (time_msk::time + INTERVAL '6 hours')) AT TIME ZONE 'Asia/Seoul'
+WHERE release_date IS NOT NULL AND time_msk ~ '^\d{1,2}:\d{2}$'
+  AND release_at IS DISTINCT FROM (release_date + (time_msk::time + INTERVAL '6 hours')) AT TIME ZONE 'Asia/Seoul';
+
+CREATE INDEX IF NOT EXISTS idx_releases_release_date ON gemfactory.releases(release_date);
+CREATE INDEX IF NOT EXISTS idx_releases_release_at ON gemfactory.releases(release_at);
@@ -0,0 +1,11 @@
+-- Откат настроек LLM провайдера и модели
+-- Migration: 004_llm_provider_config.down.sql
+
+SET search_path TO gemfactory, public;
+
+DELETE FROM gemfactory.config
+WHERE key IN ('LLM_PROVIDER', 'LLM_MODEL', 'LLM_TEMPERATURE', 'LLM_TOP_P', 'LLM_MAX_TOKENS');
+
+UPDATE gemfactory.config
+SET value = 'https://integrate.api.nvidia.com/v1', description = 'LLM base URL'
+WHERE key = 'LLM_BASE_URL' AND value = '';
@@ -0,0 +1,19 @@
+-- Настройки LLM провайдера и модели
+-- Migration: 004_llm_provider_config.up.sql
+
+SET search_path TO gemfactory, public;
+
+-- Адрес по умолчанию теперь выбирается по провайдеру. Миграции применяются при каждом запуске,
+-- поэтому адрес сбрасывается только один раз: до появления ключа LLM_PROVIDER
+UPDATE gemfactory.config
+SET value = '', description = 'LLM base URL (empty - provider default)'
+WHERE key = 'LLM_BASE_URL' AND value = 'https://integrate.api.nvidia.com/v1'
+  AND NOT EXISTS (SELECT 1 FROM gemfactory.config WHERE key = 'LLM_PROVIDER');
+
+INSERT INTO gemfactory.config (key, value, description) VALUES
+('LLM_PROVIDER', 'openai', 'LLM provider (openai - OpenAI-compatible API, ollama - native Ollama API)'),
+('LLM_MODEL', '', 'LLM model name (empty - provider default)'),
+('LLM_TEMPERATURE', '0.2', 'LLM sampling temperature'),
+('LLM_TOP_P', '0.7', 'LLM nucleus sampling top_p'),
+('LLM_MAX_TOKENS', '8192', 'LLM max tokens in response')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,6 @@
+-- Откат кэша ответов LLM
+-- Migration: 005_llm_cache.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.llm_cache CASCADE;
@@ -0,0 +1,18 @@
+-- Кэш ответов LLM для HTML блоков расписания
+-- Migration: 005_llm_cache.up.sql
+
+SET search_path TO gemfactory, public;
+
+CREATE TABLE IF NOT EXISTS gemfactory.llm_cache (
+    cache_key VARCHAR(64) PRIMARY KEY,
+    month VARCHAR(20) NOT NULL,
+    prompt_version VARCHAR(20) NOT NULL,
+    model VARCHAR(255),
+    response JSONB NOT NULL,
+    hit_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_hit_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_llm_cache_prompt_version ON gemfactory.llm_cache(prompt_version);
+CREATE INDEX IF NOT EXISTS idx_llm_cache_created_at ON gemfactory.llm_cache(created_at);
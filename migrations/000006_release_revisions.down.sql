@@ -0,0 +1,6 @@
+-- Откат истории изменений релизов
+-- Migration: 006_release_revisions.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.release_revisions CASCADE;
@@ -0,0 +1,18 @@
+-- История изменений релизов между парсингами
+-- Migration: 006_release_revisions.up.sql
+
+SET search_path TO gemfactory, public;
+
+CREATE TABLE IF NOT EXISTS gemfactory.release_revisions (
+    revision_id SERIAL PRIMARY KEY,
+    release_id INTEGER NOT NULL REFERENCES gemfactory.releases(release_id) ON DELETE CASCADE,
+    artist_id INTEGER NOT NULL REFERENCES gemfactory.artists(artist_id) ON DELETE CASCADE,
+    field VARCHAR(50) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    source_url TEXT,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_release_revisions_changed_at ON gemfactory.release_revisions(changed_at);
+CREATE INDEX IF NOT EXISTS idx_release_revisions_release_id ON gemfactory.release_revisions(release_id);
@@ -0,0 +1,6 @@
+-- Откат истории запусков задач
+-- Migration: 007_task_runs.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.task_runs CASCADE;
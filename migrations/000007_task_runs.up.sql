@@ -0,0 +1,19 @@
+-- История запусков задач планировщика
+-- Migration: 007_task_runs.up.sql
+
+SET search_path TO gemfactory, public;
+
+CREATE TABLE IF NOT EXISTS gemfactory.task_runs (
+    run_id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES gemfactory.tasks(task_id) ON DELETE CASCADE,
+    task_name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    result JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_runs_task_started ON gemfactory.task_runs(task_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_task_runs_status ON gemfactory.task_runs(status);
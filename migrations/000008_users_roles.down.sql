@@ -0,0 +1,6 @@
+-- Откат пользователей и ролей
+-- Migration: 008_users_roles.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.users CASCADE;
@@ -0,0 +1,16 @@
+-- Пользователи бота и роли по Telegram ID
+-- Migration: 008_users_roles.up.sql
+
+SET search_path TO gemfactory, public;
+
+CREATE TABLE IF NOT EXISTS gemfactory.users (
+    user_id BIGINT PRIMARY KEY,
+    username VARCHAR(255),
+    role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'editor', 'admin', 'owner')),
+    granted_by BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_username_lower ON gemfactory.users(LOWER(username));
+CREATE INDEX IF NOT EXISTS idx_users_role ON gemfactory.users(role);
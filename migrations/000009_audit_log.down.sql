@@ -0,0 +1,6 @@
+-- Откат журнала действий администраторов
+-- Migration: 009_audit_log.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.audit_log CASCADE;
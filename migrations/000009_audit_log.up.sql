@@ -0,0 +1,22 @@
+-- Журнал действий администраторов
+-- Migration: 009_audit_log.up.sql
+
+SET search_path TO gemfactory, public;
+
+CREATE TABLE IF NOT EXISTS gemfactory.audit_log (
+    audit_id SERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    actor_username VARCHAR(255),
+    chat_id BIGINT NOT NULL,
+    command VARCHAR(64) NOT NULL,
+    arguments TEXT,
+    before_state TEXT,
+    after_state TEXT,
+    outcome VARCHAR(20) NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON gemfactory.audit_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_command ON gemfactory.audit_log(command, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON gemfactory.audit_log(actor_id);
@@ -0,0 +1,6 @@
+-- Откат языка интерфейса пользователя
+-- Migration: 010_user_language.down.sql
+
+SET search_path TO gemfactory, public;
+
+ALTER TABLE gemfactory.users DROP COLUMN IF EXISTS language;
@@ -0,0 +1,7 @@
+-- Выбранный пользователем язык интерфейса
+-- Migration: 010_user_language.up.sql
+
+SET search_path TO gemfactory, public;
+
+-- NULL - язык определяется по настройкам Telegram клиента
+ALTER TABLE gemfactory.users ADD COLUMN IF NOT EXISTS language VARCHAR(8);
@@ -0,0 +1,6 @@
+-- Откат пользовательских настроек
+-- Migration: 011_user_settings.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.user_settings CASCADE;
@@ -0,0 +1,13 @@
+-- Пользовательские настройки отображения релизов
+-- Migration: 011_user_settings.up.sql
+
+SET search_path TO gemfactory, public;
+
+CREATE TABLE IF NOT EXISTS gemfactory.user_settings (
+    user_id BIGINT PRIMARY KEY,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Europe/Moscow',
+    gender_filter VARCHAR(10) NOT NULL DEFAULT '',
+    layout VARCHAR(16) NOT NULL DEFAULT 'compact',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,10 @@
+-- Откат дайджестов релизов
+-- Migration: 012_digest.down.sql
+
+SET search_path TO gemfactory, public;
+
+DELETE FROM gemfactory.tasks WHERE task_type = 'digest';
+
+DROP INDEX IF EXISTS gemfactory.idx_user_settings_digest;
+
+ALTER TABLE gemfactory.user_settings DROP COLUMN IF EXISTS digest_enabled;
@@ -0,0 +1,14 @@
+-- Ежедневные и еженедельные дайджесты релизов
+-- Migration: 012_digest.up.sql
+
+SET search_path TO gemfactory, public;
+
+ALTER TABLE gemfactory.user_settings ADD COLUMN IF NOT EXISTS digest_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_user_settings_digest ON gemfactory.user_settings(user_id) WHERE digest_enabled;
+
+-- Задачи рассылки: chat_ids - группы, gender - фильтр, lookahead_days - горизонт дайджеста
+INSERT INTO gemfactory.tasks (name, description, task_type, cron_expression, is_active, config) VALUES
+('digest_daily', 'Send releases today digest every morning', 'digest', '0 9 * * *', TRUE, '{"description": "Send releases today digest every morning", "lookahead_days": 1, "chat_ids": [], "gender": "", "users": true}'),
+('digest_weekly', 'Send releases this week digest on Mondays', 'digest', '0 10 * * 1', TRUE, '{"description": "Send releases this week digest on Mondays", "lookahead_days": 7, "chat_ids": [], "gender": "", "users": true}')
+ON CONFLICT (name) DO NOTHING;
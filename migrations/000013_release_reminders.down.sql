@@ -0,0 +1,8 @@
+-- Откат напоминаний о релизах
+-- Migration: 013_release_reminders.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.release_reminders CASCADE;
+
+ALTER TABLE gemfactory.user_settings DROP COLUMN IF EXISTS reminder_minutes;
@@ -0,0 +1,24 @@
+-- Напоминания о релизах за N минут до выхода
+-- Migration: 013_release_reminders.up.sql
+
+SET search_path TO gemfactory, public;
+
+-- За сколько минут напоминать о релизах подписок, 0 - напоминания отключены
+ALTER TABLE gemfactory.user_settings ADD COLUMN IF NOT EXISTS reminder_minutes INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS gemfactory.release_reminders (
+    reminder_id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    chat_id BIGINT NOT NULL,
+    release_id INTEGER NOT NULL REFERENCES gemfactory.releases(release_id) ON DELETE CASCADE,
+    release_at TIMESTAMPTZ NOT NULL,
+    remind_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, release_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_release_reminders_pending ON gemfactory.release_reminders(remind_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_release_reminders_release_id ON gemfactory.release_reminders(release_id);
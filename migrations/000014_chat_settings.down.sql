@@ -0,0 +1,6 @@
+-- Откат настроек групповых чатов
+-- Migration: 014_chat_settings.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.chat_settings CASCADE;
@@ -0,0 +1,18 @@
+-- Настройки групповых чатов
+-- Migration: 014_chat_settings.up.sql
+
+SET search_path TO gemfactory, public;
+
+-- language и gender_filter пустые - используются настройки пользователя, allowed_commands пустой - разрешены все команды
+CREATE TABLE IF NOT EXISTS gemfactory.chat_settings (
+    chat_id BIGINT PRIMARY KEY,
+    language VARCHAR(8) NOT NULL DEFAULT '',
+    gender_filter VARCHAR(10) NOT NULL DEFAULT '',
+    digest_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    quiet_mode BOOLEAN NOT NULL DEFAULT FALSE,
+    allowed_commands TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_settings_digest ON gemfactory.chat_settings(chat_id) WHERE digest_enabled;
@@ -0,0 +1,6 @@
+-- Откат псевдонимов артистов
+-- Migration: 015_artist_aliases.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.artist_aliases CASCADE;
@@ -0,0 +1,15 @@
+-- Псевдонимы артистов для сопоставления имен из источников
+-- Migration: 015_artist_aliases.up.sql
+
+SET search_path TO gemfactory, public;
+
+-- normalized - имя после нормализации (регистр, скобки, пунктуация), по нему ищется совпадение
+CREATE TABLE IF NOT EXISTS gemfactory.artist_aliases (
+    alias_id SERIAL PRIMARY KEY,
+    artist_id INTEGER NOT NULL REFERENCES gemfactory.artists(artist_id) ON DELETE CASCADE,
+    alias VARCHAR(100) NOT NULL,
+    normalized VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_artist_aliases_artist_id ON gemfactory.artist_aliases(artist_id);
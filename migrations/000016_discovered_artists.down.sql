@@ -0,0 +1,6 @@
+-- Откат артистов из расписания, которых нет в списке
+-- Migration: 016_discovered_artists.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.discovered_artists CASCADE;
@@ -0,0 +1,27 @@
+-- Артисты из расписания, которых нет в списке
+-- Migration: 016_discovered_artists.up.sql
+
+SET search_path TO gemfactory, public;
+
+-- normalized - имя после нормализации, по нему объединяются написания одного артиста
+-- status: new - ожидает решения, added - добавлен в список, ignored - скрыт из отчета
+CREATE TABLE IF NOT EXISTS gemfactory.discovered_artists (
+    discovered_id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    normalized VARCHAR(100) NOT NULL UNIQUE,
+    status VARCHAR(16) NOT NULL DEFAULT 'new',
+    seen_count INTEGER NOT NULL DEFAULT 0,
+    first_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_release_date DATE,
+    sample_releases TEXT[] NOT NULL DEFAULT '{}',
+    source_url TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- sightings - встреченные строки (страница, дата, релиз); seen_count - их количество,
+-- поэтому повторный парсинг той же страницы не увеличивает счетчик
+ALTER TABLE gemfactory.discovered_artists ADD COLUMN IF NOT EXISTS sightings TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_discovered_artists_status ON gemfactory.discovered_artists(status, seen_count DESC);
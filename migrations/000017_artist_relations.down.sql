@@ -0,0 +1,9 @@
+-- Откат связей артистов
+-- Migration: 017_artist_relations.down.sql
+
+SET search_path TO gemfactory, public;
+
+ALTER TABLE gemfactory.subscriptions DROP COLUMN IF EXISTS include_related;
+ALTER TABLE gemfactory.artists DROP COLUMN IF EXISTS include_related;
+
+DROP TABLE IF EXISTS gemfactory.artist_relations CASCADE;
@@ -0,0 +1,22 @@
+-- Связи артистов: солисты, саб-юниты и совместные проекты
+-- Migration: 017_artist_relations.up.sql
+
+SET search_path TO gemfactory, public;
+
+-- artist_id - солист, юнит или совместный проект, parent_id - группа или артист, к которому он относится
+-- relation_type: member - участник, subunit - саб-юнит, collab - совместный проект
+CREATE TABLE IF NOT EXISTS gemfactory.artist_relations (
+    relation_id SERIAL PRIMARY KEY,
+    artist_id INTEGER NOT NULL REFERENCES gemfactory.artists(artist_id) ON DELETE CASCADE,
+    parent_id INTEGER NOT NULL REFERENCES gemfactory.artists(artist_id) ON DELETE CASCADE,
+    relation_type VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (artist_id, parent_id),
+    CHECK (artist_id <> parent_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_artist_relations_parent_id ON gemfactory.artist_relations(parent_id);
+
+-- Список артистов и подписки на группу могут включать ее солистов, юниты и совместные проекты
+ALTER TABLE gemfactory.artists ADD COLUMN IF NOT EXISTS include_related BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE gemfactory.subscriptions ADD COLUMN IF NOT EXISTS include_related BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,7 @@
+-- Откат типов релизов
+-- Migration: 018_release_types.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP INDEX IF EXISTS gemfactory.idx_releases_release_type;
+ALTER TABLE gemfactory.releases DROP COLUMN IF EXISTS release_type;
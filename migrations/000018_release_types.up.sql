@@ -0,0 +1,19 @@
+-- Тип релиза: сингл, альбом, мини-альбом, OST или цифровой сингл
+-- Migration: 018_release_types.up.sql
+
+SET search_path TO gemfactory, public;
+
+-- release_type: single, album, ep, ost, digital; NULL - тип не указан в источнике
+ALTER TABLE gemfactory.releases ADD COLUMN IF NOT EXISTS release_type VARCHAR(16);
+
+CREATE INDEX IF NOT EXISTS idx_releases_release_type ON gemfactory.releases(release_type);
+
+-- Заполняем тип уже сохраненных релизов по названию альбома, в том же порядке, что и классификатор парсера
+UPDATE gemfactory.releases SET release_type = CASE
+    WHEN album_name ~* '\m(ost|soundtrack)\M' THEN 'ost'
+    WHEN album_name ~* '\mdigital\s+single\M' THEN 'digital'
+    WHEN album_name ~* '\m(ep|mini)\M' THEN 'ep'
+    WHEN album_name ~* '\msingle\M' THEN 'single'
+    WHEN album_name ~* '\m(album|repackage)\M' THEN 'album'
+END
+WHERE release_type IS NULL;
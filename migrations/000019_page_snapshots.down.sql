@@ -0,0 +1,6 @@
+-- Откат снимков страниц расписания
+-- Migration: 019_page_snapshots.down.sql
+
+SET search_path TO gemfactory, public;
+
+DROP TABLE IF EXISTS gemfactory.page_snapshots CASCADE;
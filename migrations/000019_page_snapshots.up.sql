@@ -0,0 +1,24 @@
+-- Снимки страниц расписания для воспроизведения парсинга и условных запросов
+-- Migration: 019_page_snapshots.up.sql
+
+SET search_path TO gemfactory, public;
+
+-- body - страница, сжатая gzip; content_hash - SHA-256 несжатой страницы
+-- filter_hash - отпечаток списка артистов и версии промпта, с которым снимок успешно разобран
+CREATE TABLE IF NOT EXISTS gemfactory.page_snapshots (
+    snapshot_id SERIAL PRIMARY KEY,
+    source VARCHAR(64) NOT NULL,
+    url TEXT NOT NULL,
+    month VARCHAR(16) NOT NULL,
+    year VARCHAR(4) NOT NULL,
+    fetched_at TIMESTAMP NOT NULL,
+    etag TEXT,
+    last_modified TEXT,
+    content_hash VARCHAR(64) NOT NULL,
+    size INTEGER NOT NULL,
+    body BYTEA NOT NULL,
+    filter_hash VARCHAR(64),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_page_snapshots_url_fetched_at ON gemfactory.page_snapshots(url, fetched_at DESC);